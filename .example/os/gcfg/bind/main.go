@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/os/gcmd"
+)
+
+type ServerConfig struct {
+	Address string `d:":8000" v:"required"`
+	Timeout int    `d:"30"    v:"min:1#server timeout should be positive"`
+}
+
+type DatabaseConfig struct {
+	Link string `v:"required#database link is required"`
+}
+
+type AppConfig struct {
+	Server   ServerConfig
+	Database DatabaseConfig
+}
+
+// Usage:
+// go run main.go        : binds and validates the configuration at startup.
+// go run main.go dump   : prints the effective configuration with secrets masked.
+func main() {
+	if gcmd.GetArg(1) == "dump" {
+		g.Cfg().DumpMasked()
+		return
+	}
+	var config AppConfig
+	if err := g.Cfg().Bind(".", &config); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	g.Dump(config)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gogf/gf/os/gcfg"
+	"github.com/gogf/gf/os/gcmd"
+)
+
+// Usage:
+// GF_GCFG_SECRETKEY=<key> go run main.go encrypt <value>
+// GF_GCFG_SECRETKEY=<key> go run main.go decrypt <ENC(...)>
+// GF_GCFG_SECRETKEY=<old key> GF_GCFG_NEWSECRETKEY=<new key> go run main.go rekey <config file>
+//
+// The keys can also be passed by command options like: --gf.gcfg.secretkey=<key>.
+func main() {
+	key, err := gcfg.NewKeyProviderEnv("gf.gcfg.secretkey").Key()
+	if err != nil {
+		exit(err)
+	}
+	switch gcmd.GetArg(1) {
+	case "encrypt":
+		value, err := gcfg.EncryptValue(gcmd.GetArg(2), key)
+		if err != nil {
+			exit(err)
+		}
+		fmt.Println(value)
+
+	case "decrypt":
+		value, err := gcfg.DecryptValue(gcmd.GetArg(2), key)
+		if err != nil {
+			exit(err)
+		}
+		fmt.Println(value)
+
+	case "rekey":
+		newKey, err := gcfg.NewKeyProviderEnv("gf.gcfg.newsecretkey").Key()
+		if err != nil {
+			exit(err)
+		}
+		if err = gcfg.RekeyFile(gcmd.GetArg(2), key, newKey); err != nil {
+			exit(err)
+		}
+		fmt.Println("done")
+
+	default:
+		exit(fmt.Errorf(`unknown command "%s", it should be one of: encrypt, decrypt, rekey`, gcmd.GetArg(1)))
+	}
+}
+
+func exit(err error) {
+	fmt.Println(err)
+	os.Exit(1)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/os/gcmd"
+)
+
+type ServeOptions struct {
+	Port    int    `short:"p" brief:"listening port" d:"8000" v:"min:1"`
+	Address string `name:"addr" brief:"listening address" d:"0.0.0.0"`
+}
+
+// Usage:
+// go run main.go serve -p 8080
+// go run main.go --help
+// source <(go run main.go completion bash)
+func main() {
+	var (
+		serveOptions = &ServeOptions{}
+		root         = &gcmd.Command{
+			Name:  "app",
+			Brief: "app is a demo application",
+		}
+	)
+	root.Sub = []*gcmd.Command{
+		{
+			Name:      "serve",
+			Brief:     "start http server",
+			Options:   serveOptions,
+			EnvPrefix: "APP",
+			Func: func(ctx context.Context, parser *gcmd.Parser) error {
+				g.Dump(serveOptions)
+				return nil
+			},
+		},
+		{
+			Name:  "completion",
+			Brief: "print shell completion script, bash or zsh",
+			Func: func(ctx context.Context, parser *gcmd.Parser) error {
+				script, err := root.Completion(parser.GetArg(1, "bash"))
+				if err != nil {
+					return err
+				}
+				fmt.Print(script)
+				return nil
+			},
+		},
+	}
+	if err := root.Run(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
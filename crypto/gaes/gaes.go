@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/rand"
 	"errors"
 )
 
@@ -171,3 +172,50 @@ func ZeroUnPadding(plaintext []byte, unPadding int) []byte {
 	length := len(plaintext)
 	return plaintext[:(length - unPadding)]
 }
+
+// EncryptGCM encrypts <plainText> using GCM mode, which also authenticates the <plainText>
+// and the optional <additionalData>.
+// Note that the key must be 16/24/32 bit length.
+// The random nonce is generated and prepended to the returned cipher text.
+func EncryptGCM(plainText []byte, key []byte, additionalData ...[]byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	var data []byte
+	if len(additionalData) > 0 {
+		data = additionalData[0]
+	}
+	return gcm.Seal(nonce, nonce, plainText, data), nil
+}
+
+// DecryptGCM decrypts <cipherText> using GCM mode, which is encrypted by EncryptGCM.
+// Note that the key must be 16/24/32 bit length.
+// It returns error if the <cipherText> or <additionalData> is tampered.
+func DecryptGCM(cipherText []byte, key []byte, additionalData ...[]byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonceSize := gcm.NonceSize()
+	if len(cipherText) < nonceSize+gcm.Overhead() {
+		return nil, errors.New("cipherText too short")
+	}
+	var data []byte
+	if len(additionalData) > 0 {
+		data = additionalData[0]
+	}
+	return gcm.Open(nil, cipherText[:nonceSize], cipherText[nonceSize:], data)
+}
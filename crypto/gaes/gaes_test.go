@@ -148,3 +148,31 @@ func TestDecryptCFB(t *testing.T) {
 		t.Assert(decrypt, content)
 	})
 }
+
+func TestEncryptGCM(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, key := range [][]byte{key_16, key_24, key_32} {
+			data, err := gaes.EncryptGCM(content, key)
+			t.Assert(err, nil)
+			t.AssertNE(data, content)
+			decrypted, err := gaes.DecryptGCM(data, key)
+			t.Assert(err, nil)
+			t.Assert(decrypted, content)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gaes.EncryptGCM(content, key_32, []byte("id"))
+		t.Assert(err, nil)
+		_, err = gaes.DecryptGCM(data, key_32, []byte("other"))
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptGCM(data, keys, []byte("id"))
+		t.AssertNE(err, nil)
+		data[len(data)-1] ^= 1
+		_, err = gaes.DecryptGCM(data, key_32, []byte("id"))
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptGCM([]byte("short"), key_32)
+		t.AssertNE(err, nil)
+		_, err = gaes.EncryptGCM(content, key_err)
+		t.AssertNE(err, nil)
+	})
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/gogf/gf/container/gmap"
 	"github.com/gogf/gf/container/gtype"
+	"github.com/gogf/gf/database/gredis"
 	"github.com/gogf/gf/os/gcache"
 	"github.com/gogf/gf/util/grand"
 )
@@ -302,6 +303,8 @@ var (
 func init() {
 	// allDryRun is initialized from environment or command options.
 	allDryRun = gcmd.GetOptWithEnv("gf.gdb.dryrun", false).Bool()
+	// Result is registered for remote cache adapters decoding the cached result as its type.
+	gcache.RegisterType(Result{})
 }
 
 // Register registers custom database driver to gdb.
@@ -334,6 +337,14 @@ func New(group ...string) (db DB, err error) {
 				logger: glog.New(),
 				config: node,
 			}
+			// It uses redis adapter for SQL result cache if configured.
+			if node.CacheRedis != "" {
+				if redis := gredis.Instance(node.CacheRedis); redis != nil {
+					c.cache.SetAdapter(gcache.NewAdapterRedis(redis, fmt.Sprintf(`gdb:%s:`, groupName)))
+				} else {
+					intlog.Printf(`redis configuration group "%s" not found for SQL result cache`, node.CacheRedis)
+				}
+			}
 			if v, ok := driverMap[node.Type]; ok {
 				c.db, err = v.New(c, node)
 				if err != nil {
@@ -49,6 +49,7 @@ type ConfigNode struct {
 	UpdatedAt            string        `json:"updatedAt"`            // (Optional) The filed name of table for automatic-filled updated datetime.
 	DeletedAt            string        `json:"deletedAt"`            // (Optional) The filed name of table for automatic-filled updated datetime.
 	TimeMaintainDisabled bool          `json:"timeMaintainDisabled"` // (Optional) Disable the automatic time maintaining feature.
+	CacheRedis           string        `json:"cacheRedis"`           // (Optional) Redis configuration group name for SQL result cache, which uses memory cache if empty.
 }
 
 const (
@@ -479,8 +479,20 @@ func (m *Model) doGetAllBySql(sql string, args ...interface{}) (result Result, e
 				return result, nil
 			} else {
 				// Other cache, it needs conversion.
-				var result Result
-				if err = json.UnmarshalUseNumber(v.Bytes(), &result); err != nil {
+				var (
+					result Result
+					bytes  []byte
+				)
+				switch v.Val().(type) {
+				case []byte, string:
+					bytes = v.Bytes()
+				default:
+					// Remote cache adapters may return decoded value, which is encoded again here.
+					if bytes, err = json.Marshal(v.Val()); err != nil {
+						return nil, err
+					}
+				}
+				if err = json.UnmarshalUseNumber(bytes, &result); err != nil {
 					return nil, err
 				} else {
 					return result, nil
@@ -264,3 +264,11 @@ func (r *Redis) DoVar(commandName string, args ...interface{}) (*gvar.Var, error
 func (r *Redis) DoVarWithTimeout(timeout time.Duration, commandName string, args ...interface{}) (*gvar.Var, error) {
 	return resultToVar(r.DoWithTimeout(timeout, commandName, args...))
 }
+
+// evalScript evaluates lua <script> with <keysAndArgs> using a connection from the pool.
+// It tries EVALSHA first and falls back to EVAL if the script is not cached by the server.
+func (r *Redis) evalScript(script *redis.Script, keysAndArgs ...interface{}) (interface{}, error) {
+	conn := r.Conn()
+	defer conn.Close()
+	return script.Do(conn, keysAndArgs...)
+}
@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gredis
+
+import (
+	"context"
+
+	"github.com/gogf/gf/util/gconv"
+)
+
+// Broker publishes and subscribes payloads using Pub/Sub of a channel, which implements the
+// broker interfaces of other packages without importing them, like ghttp.WebSocketBroker.
+type Broker struct {
+	redis   *Redis // Redis client.
+	channel string // Pub/Sub channel name.
+}
+
+// Broker creates and returns a Broker using Pub/Sub of <channel>, eg:
+// ghttp.WebSocketHubConfig{Broker: redis.Broker("ws")}, which delivers the broadcasts to the
+// hubs using the same channel on other nodes.
+func (r *Redis) Broker(channel string) *Broker {
+	return &Broker{
+		redis:   r,
+		channel: channel,
+	}
+}
+
+// Publish publishes <payload> to all subscribers of the channel.
+func (b *Broker) Publish(ctx context.Context, payload []byte) error {
+	_, err := b.redis.Ctx(ctx).Do("PUBLISH", b.channel, payload)
+	return err
+}
+
+// Subscribe receives the payloads of the channel and calls <handler> with them, which blocks
+// until <ctx> is done. It returns error if the subscribing connection is broken.
+func (b *Broker) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	conn := b.redis.Conn()
+	defer conn.Close()
+	if _, err := conn.Do("SUBSCRIBE", b.channel); err != nil {
+		return err
+	}
+	// Closing the connection breaks the blocking receiving.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-stop:
+		}
+	}()
+	for {
+		v, err := conn.ReceiveVar()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		// Message reply: ["message", channel, payload].
+		array := v.Interfaces()
+		if len(array) == 3 && gconv.String(array[0]) == "message" {
+			handler(gconv.Bytes(array[2]))
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gredis
+
+import (
+	"time"
+
+	"github.com/gogf/gf/util/gconv"
+	"github.com/gogf/gf/util/guid"
+	"github.com/gomodule/redigo/redis"
+)
+
+// Limiter is the interface for redis based rate limiters.
+type Limiter interface {
+	// Allow reports whether one request with <key> is allowed.
+	Allow(key string) (bool, error)
+
+	// AllowN checks whether <n> requests with <key> are allowed, and consumes the quota if so.
+	AllowN(key string, n int) (*LimitResult, error)
+
+	// Reset clears the limiting state of <key>.
+	Reset(key string) error
+}
+
+// LimitResult is the result of a rate limiting check.
+type LimitResult struct {
+	Allowed    bool          // Whether the requests are allowed.
+	Limit      int           // Maximum requests in a window, or the burst size of a token bucket.
+	Remaining  int           // Remaining requests allowed currently.
+	RetryAfter time.Duration // Time to wait before the requests can be allowed, 0 if allowed, -1 if never.
+	ResetAfter time.Duration // Time before the quota is fully restored.
+}
+
+// SlidingWindowLimiter limits requests within a sliding time window, which is implemented
+// with a sorted set of request timestamps per key.
+type SlidingWindowLimiter struct {
+	redis  *Redis        // Redis client.
+	limit  int           // Maximum requests in a window.
+	window time.Duration // Window size.
+}
+
+// TokenBucketLimiter limits requests with a token bucket per key, which is refilled with
+// <rate> tokens per second up to <burst> tokens.
+type TokenBucketLimiter struct {
+	redis *Redis  // Redis client.
+	rate  float64 // Tokens refilled per second.
+	burst int     // Bucket capacity.
+}
+
+var (
+	// slidingWindowScript removes the timestamps out of the window, and adds ARGV[3] members
+	// scored by current time if the requests are allowed.
+	// It returns {allowed, remaining, retry after milliseconds, reset after milliseconds}.
+	slidingWindowScript = redis.NewScript(1, `
+redis.replicate_commands()
+local key    = KEYS[1]
+local limit  = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n      = tonumber(ARGV[3])
+local member = ARGV[4]
+local time   = redis.call("TIME")
+local now    = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+redis.call("ZREMRANGEBYSCORE", key, "-inf", now - window)
+local count = redis.call("ZCARD", key)
+if count + n <= limit then
+	for i = 1, n do
+		redis.call("ZADD", key, now, member .. ":" .. i)
+	end
+	redis.call("PEXPIRE", key, window)
+	local reset  = window
+	local oldest = redis.call("ZRANGE", key, 0, 0, "WITHSCORES")
+	if #oldest > 0 then
+		reset = tonumber(oldest[2]) + window - now
+	end
+	return {1, limit - count - n, 0, reset}
+end
+local retry = -1
+local reset = window
+local oldest = redis.call("ZRANGE", key, 0, 0, "WITHSCORES")
+if #oldest > 0 then
+	reset = tonumber(oldest[2]) + window - now
+end
+if n <= limit then
+	local index = count + n - limit - 1
+	local entry = redis.call("ZRANGE", key, index, index, "WITHSCORES")
+	if #entry > 0 then
+		retry = tonumber(entry[2]) + window - now
+	end
+end
+return {0, limit - count, retry, reset}
+`)
+
+	// tokenBucketScript refills the bucket by elapsed time and takes ARGV[3] tokens from it
+	// if there are enough tokens.
+	// It returns {allowed, remaining, retry after milliseconds, reset after milliseconds}.
+	tokenBucketScript = redis.NewScript(1, `
+redis.replicate_commands()
+local key   = KEYS[1]
+local rate  = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n     = tonumber(ARGV[3])
+local time  = redis.call("TIME")
+local now   = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local state  = redis.call("HMGET", key, "tokens", "timestamp")
+local tokens = tonumber(state[1])
+local last   = tonumber(state[2])
+if tokens == nil or last == nil then
+	tokens = burst
+	last   = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - last) * rate / 1000)
+local allowed = 0
+local retry   = -1
+if tokens >= n then
+	tokens  = tokens - n
+	allowed = 1
+	retry   = 0
+elseif n <= burst then
+	retry = math.ceil((n - tokens) * 1000 / rate)
+end
+local reset = math.ceil((burst - tokens) * 1000 / rate)
+redis.call("HMSET", key, "tokens", tostring(tokens), "timestamp", now)
+redis.call("PEXPIRE", key, reset + 1000)
+return {allowed, math.floor(tokens), retry, reset}
+`)
+)
+
+// SlidingWindowLimiter creates and returns a sliding window limiter on current redis,
+// which allows at most <limit> requests per key within any <window>.
+// It panics if <limit> or <window> is not positive.
+func (r *Redis) SlidingWindowLimiter(limit int, window time.Duration) *SlidingWindowLimiter {
+	if limit <= 0 || window < time.Millisecond {
+		panic("gredis: SlidingWindowLimiter requires positive limit and window no less than 1 millisecond")
+	}
+	return &SlidingWindowLimiter{
+		redis:  r,
+		limit:  limit,
+		window: window,
+	}
+}
+
+// Allow reports whether one request with <key> is allowed.
+func (l *SlidingWindowLimiter) Allow(key string) (bool, error) {
+	result, err := l.AllowN(key, 1)
+	if err != nil {
+		return false, err
+	}
+	return result.Allowed, nil
+}
+
+// AllowN checks whether <n> requests with <key> are allowed, and records them if so.
+func (l *SlidingWindowLimiter) AllowN(key string, n int) (*LimitResult, error) {
+	reply, err := l.redis.evalScript(
+		slidingWindowScript, key, l.limit, int64(l.window/time.Millisecond), n, guid.S(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newLimitResult(reply, l.limit), nil
+}
+
+// Reset clears the recorded requests of <key>.
+func (l *SlidingWindowLimiter) Reset(key string) error {
+	_, err := l.redis.Do("DEL", key)
+	return err
+}
+
+// TokenBucketLimiter creates and returns a token bucket limiter on current redis,
+// whose bucket per key holds at most <burst> tokens and is refilled with <rate> tokens per second.
+// It panics if <rate> or <burst> is not positive.
+func (r *Redis) TokenBucketLimiter(rate float64, burst int) *TokenBucketLimiter {
+	if rate <= 0 || burst <= 0 {
+		panic("gredis: TokenBucketLimiter requires positive rate and burst")
+	}
+	return &TokenBucketLimiter{
+		redis: r,
+		rate:  rate,
+		burst: burst,
+	}
+}
+
+// Allow reports whether one request with <key> is allowed.
+func (l *TokenBucketLimiter) Allow(key string) (bool, error) {
+	result, err := l.AllowN(key, 1)
+	if err != nil {
+		return false, err
+	}
+	return result.Allowed, nil
+}
+
+// AllowN checks whether <n> tokens are available in the bucket of <key>, and takes them if so.
+func (l *TokenBucketLimiter) AllowN(key string, n int) (*LimitResult, error) {
+	reply, err := l.redis.evalScript(
+		tokenBucketScript, key, gconv.String(l.rate), l.burst, n,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newLimitResult(reply, l.burst), nil
+}
+
+// Reset refills the bucket of <key>.
+func (l *TokenBucketLimiter) Reset(key string) error {
+	_, err := l.redis.Do("DEL", key)
+	return err
+}
+
+// newLimitResult converts the reply of the limiting scripts to LimitResult.
+func newLimitResult(reply interface{}, limit int) *LimitResult {
+	values := gconv.Int64s(reply)
+	for len(values) < 4 {
+		values = append(values, 0)
+	}
+	result := &LimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      limit,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		ResetAfter: time.Duration(values[3]) * time.Millisecond,
+	}
+	if values[2] < 0 {
+		result.RetryAfter = -1
+	}
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+	return result
+}
@@ -0,0 +1,339 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gredis
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/os/gtimer"
+	"github.com/gogf/gf/util/gconv"
+	"github.com/gogf/gf/util/guid"
+	"github.com/gomodule/redigo/redis"
+)
+
+// Mutex is a distributed mutex implemented on redis.
+//
+// It uses "SET NX PX" to acquire the lock with a random value identifying the holder,
+// which makes sure only the holder can release or extend the lock. Each successful
+// acquiring also increases a fencing counter, the returned token of which can be passed
+// to the protected resource to reject stale holders whose lease was expired.
+//
+// If the Mutex is created with multiple redis instances, it implements the Redlock
+// algorithm: the lock is considered acquired only if it is acquired on the majority
+// of the instances within the lease time.
+type Mutex struct {
+	mu       sync.Mutex    // Mutex for concurrent safety of the following attributes.
+	name     string        // Lock key name in redis.
+	redises  []*Redis      // Redis instances the lock is acquired on.
+	options  MutexOptions  // Mutex options.
+	value    string        // Random value identifying current holding, empty if not held.
+	token    int64         // Fencing token of current holding.
+	until    time.Time     // Time before which current holding is valid.
+	watchdog *gtimer.Entry // Timer entry renewing the lease automatically.
+}
+
+// MutexOptions is the options for Mutex.
+type MutexOptions struct {
+	TTL         time.Duration // Lease time of the lock (default is 30 seconds).
+	RetryDelay  time.Duration // Delay between two acquiring attempts for Lock (default is 100 milliseconds).
+	RetryTimes  int           // Maximum acquiring attempts for Lock, 0 means retrying until acquired.
+	DriftFactor float64       // Clock drift factor for calculating lock validity (default is 0.01).
+	Watchdog    bool          // Renew the lease automatically every TTL/3 until Unlock is called.
+}
+
+const (
+	defaultMutexTTL         = 30 * time.Second
+	defaultMutexRetryDelay  = 100 * time.Millisecond
+	defaultMutexDriftFactor = 0.01
+	mutexFencingKeySuffix   = ":fencing"
+)
+
+var (
+	// ErrorLockNotHeld is returned if the operation requires the lock being held,
+	// but it is not held by current Mutex or it was already expired.
+	ErrorLockNotHeld = gerror.New("lock is not held")
+
+	// ErrorLockFailed is returned if Lock fails acquiring the lock after all attempts.
+	ErrorLockFailed = gerror.New("failed acquiring lock")
+
+	// mutexAcquireScript sets the lock key if not exists and increases the fencing counter.
+	// It returns the fencing token if the lock is acquired, or else 0.
+	mutexAcquireScript = redis.NewScript(2, `
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0
+`)
+
+	// mutexReleaseScript deletes the lock key only if it is held by given value.
+	mutexReleaseScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+	// mutexExtendScript resets the lease of the lock key only if it is held by given value.
+	mutexExtendScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+)
+
+// Mutex creates and returns a distributed mutex with given <name> on current redis.
+func (r *Redis) Mutex(name string, options ...MutexOptions) *Mutex {
+	return NewMutex(name, []*Redis{r}, options...)
+}
+
+// NewMutex creates and returns a distributed mutex with given <name> on <redises>.
+// It uses the Redlock algorithm if more than one redis instances are given, in which case
+// the instances should be independent masters.
+func NewMutex(name string, redises []*Redis, options ...MutexOptions) *Mutex {
+	m := &Mutex{
+		name:    name,
+		redises: redises,
+	}
+	if len(options) > 0 {
+		m.options = options[0]
+	}
+	if m.options.TTL <= 0 {
+		m.options.TTL = defaultMutexTTL
+	}
+	if m.options.RetryDelay <= 0 {
+		m.options.RetryDelay = defaultMutexRetryDelay
+	}
+	if m.options.DriftFactor <= 0 {
+		m.options.DriftFactor = defaultMutexDriftFactor
+	}
+	return m
+}
+
+// Name returns the lock key name of the mutex.
+func (m *Mutex) Name() string {
+	return m.name
+}
+
+// Token returns the fencing token of current holding, which is 0 if the lock is not held.
+//
+// The token increases monotonically for each successful acquiring of the same lock name.
+// For Redlock, it returns the maximum token among the acquired instances.
+func (m *Mutex) Token() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.value == "" {
+		return 0
+	}
+	return m.token
+}
+
+// Until returns the time before which current holding is valid.
+// It returns zero time if the lock is not held.
+func (m *Mutex) Until() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.until
+}
+
+// Lock acquires the lock, retrying with RetryDelay until it is acquired or
+// the RetryTimes attempts are exhausted, in which case it returns ErrorLockFailed.
+func (m *Mutex) Lock() error {
+	for i := 0; m.options.RetryTimes == 0 || i < m.options.RetryTimes; i++ {
+		if i > 0 {
+			time.Sleep(m.options.RetryDelay)
+		}
+		ok, err := m.TryLock()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+	}
+	return ErrorLockFailed
+}
+
+// TryLock tries acquiring the lock only once, it returns true if success.
+// Note that it returns false if the lock is already held by current Mutex.
+func (m *Mutex) TryLock() (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.value != "" && time.Now().Before(m.until) {
+		return false, nil
+	}
+	var (
+		value    = guid.S()
+		start    = time.Now()
+		acquired = 0
+		token    = int64(0)
+		lastErr  error
+	)
+	for _, r := range m.redises {
+		v, err := r.evalScript(mutexAcquireScript, m.name, m.name+mutexFencingKeySuffix, value, m.ttlMilli())
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if t := gconv.Int64(v); t > 0 {
+			acquired++
+			if t > token {
+				token = t
+			}
+		}
+	}
+	until := start.Add(m.options.TTL - m.drift())
+	if acquired >= m.quorum() && time.Now().Before(until) {
+		m.value = value
+		m.token = token
+		m.until = until
+		if m.options.Watchdog {
+			m.startWatchdog()
+		}
+		return true, nil
+	}
+	// Release the partially acquired locks.
+	for _, r := range m.redises {
+		if _, err := r.evalScript(mutexReleaseScript, m.name, value); err != nil {
+			intlog.Error(err)
+		}
+	}
+	if acquired == 0 && lastErr != nil {
+		return false, lastErr
+	}
+	return false, nil
+}
+
+// Unlock releases the lock. It returns ErrorLockNotHeld if the lock is not held
+// by current Mutex, for example, it was expired and acquired by others.
+func (m *Mutex) Unlock() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.value == "" {
+		return ErrorLockNotHeld
+	}
+	m.stopWatchdog()
+	var (
+		released = 0
+		lastErr  error
+	)
+	for _, r := range m.redises {
+		v, err := r.evalScript(mutexReleaseScript, m.name, m.value)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if gconv.Int(v) > 0 {
+			released++
+		}
+	}
+	m.value = ""
+	m.token = 0
+	m.until = time.Time{}
+	if released >= m.quorum() {
+		return nil
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return ErrorLockNotHeld
+}
+
+// Extend resets the lease of the held lock to TTL. It returns ErrorLockNotHeld if the
+// lock is not held by current Mutex any longer.
+func (m *Mutex) Extend() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.doExtend()
+}
+
+// LockFunc acquires the lock, calls <f> and releases the lock after <f> is done.
+func (m *Mutex) LockFunc(f func()) error {
+	if err := m.Lock(); err != nil {
+		return err
+	}
+	defer m.Unlock()
+	f()
+	return nil
+}
+
+// doExtend resets the lease of the held lock without locking.
+func (m *Mutex) doExtend() error {
+	if m.value == "" {
+		return ErrorLockNotHeld
+	}
+	var (
+		start    = time.Now()
+		extended = 0
+		lastErr  error
+	)
+	for _, r := range m.redises {
+		v, err := r.evalScript(mutexExtendScript, m.name, m.value, m.ttlMilli())
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if gconv.Int(v) > 0 {
+			extended++
+		}
+	}
+	if extended >= m.quorum() {
+		m.until = start.Add(m.options.TTL - m.drift())
+		return nil
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return ErrorLockNotHeld
+}
+
+// startWatchdog starts a timer renewing the lease every TTL/3 until the lock is released
+// or it fails renewing.
+func (m *Mutex) startWatchdog() {
+	m.stopWatchdog()
+	var entry *gtimer.Entry
+	entry = gtimer.AddSingleton(m.options.TTL/3, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.watchdog != entry {
+			gtimer.Exit()
+		}
+		if err := m.doExtend(); err != nil {
+			intlog.Printf(`mutex "%s" watchdog stops renewing: %v`, m.name, err)
+			m.watchdog = nil
+			gtimer.Exit()
+		}
+	})
+	m.watchdog = entry
+}
+
+// stopWatchdog stops the renewing timer if it is running.
+func (m *Mutex) stopWatchdog() {
+	if m.watchdog != nil {
+		m.watchdog.Close()
+		m.watchdog = nil
+	}
+}
+
+// quorum returns the number of instances the lock should be acquired on.
+func (m *Mutex) quorum() int {
+	return len(m.redises)/2 + 1
+}
+
+// drift returns the clock drift for calculating lock validity, which is added
+// 2 milliseconds for redis expiration precision.
+func (m *Mutex) drift() time.Duration {
+	return time.Duration(float64(m.options.TTL)*m.options.DriftFactor) + 2*time.Millisecond
+}
+
+// ttlMilli returns the lease time in milliseconds.
+func (m *Mutex) ttlMilli() int64 {
+	return int64(m.options.TTL / time.Millisecond)
+}
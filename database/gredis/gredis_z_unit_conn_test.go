@@ -7,8 +7,10 @@
 package gredis_test
 
 import (
+	"context"
 	"github.com/gogf/gf/database/gredis"
 	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/util/guid"
 	"testing"
 	"time"
 )
@@ -49,3 +51,34 @@ func TestConn_ReceiveVarWithTimeout(t *testing.T) {
 		t.Assert(v.Strings()[2], "test")
 	})
 }
+
+func Test_Broker(t *testing.T) {
+	redis := gredis.New(config)
+	defer redis.Close()
+	if _, err := redis.Do("PING"); err != nil {
+		t.Skip("redis is unreachable:", err)
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			broker      = redis.Broker(guid.S())
+			received    = make(chan []byte, 1)
+			done        = make(chan error, 1)
+		)
+		go func() {
+			done <- broker.Subscribe(ctx, func(payload []byte) {
+				received <- payload
+			})
+		}()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(broker.Publish(ctx, []byte("hello")), nil)
+		select {
+		case payload := <-received:
+			t.Assert(payload, []byte("hello"))
+		case <-time.After(time.Second):
+			t.Error("payload is not received")
+		}
+		cancel()
+		t.Assert(<-done, nil)
+	})
+}
@@ -0,0 +1,218 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gredis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/database/gredis"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/util/guid"
+)
+
+func Test_Mutex(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			redis = gredis.New(config)
+			name  = guid.S()
+		)
+		defer redis.Close()
+		defer redis.Do("DEL", name, name+":fencing")
+
+		m1 := redis.Mutex(name, gredis.MutexOptions{TTL: time.Second})
+		m2 := redis.Mutex(name, gredis.MutexOptions{TTL: time.Second, RetryTimes: 2})
+		t.Assert(m1.Lock(), nil)
+		t.Assert(m1.Token(), 1)
+
+		ok, err := m2.TryLock()
+		t.Assert(err, nil)
+		t.Assert(ok, false)
+		t.Assert(m2.Lock(), gredis.ErrorLockFailed)
+
+		t.Assert(m1.Extend(), nil)
+		t.Assert(m1.Unlock(), nil)
+		t.Assert(m1.Unlock(), gredis.ErrorLockNotHeld)
+		t.Assert(m1.Token(), 0)
+
+		t.Assert(m2.Lock(), nil)
+		t.Assert(m2.Token(), 2)
+		t.Assert(m2.Unlock(), nil)
+	})
+}
+
+func Test_Mutex_Expired(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			redis = gredis.New(config)
+			name  = guid.S()
+		)
+		defer redis.Close()
+		defer redis.Do("DEL", name, name+":fencing")
+
+		m1 := redis.Mutex(name, gredis.MutexOptions{TTL: 200 * time.Millisecond})
+		m2 := redis.Mutex(name)
+		t.Assert(m1.Lock(), nil)
+		time.Sleep(300 * time.Millisecond)
+
+		t.Assert(m2.Lock(), nil)
+		t.Assert(m1.Extend(), gredis.ErrorLockNotHeld)
+		t.Assert(m1.Unlock(), gredis.ErrorLockNotHeld)
+		t.Assert(m2.Unlock(), nil)
+	})
+}
+
+func Test_Mutex_Watchdog(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			redis = gredis.New(config)
+			name  = guid.S()
+		)
+		defer redis.Close()
+		defer redis.Do("DEL", name, name+":fencing")
+
+		m1 := redis.Mutex(name, gredis.MutexOptions{TTL: 600 * time.Millisecond, Watchdog: true})
+		t.Assert(m1.Lock(), nil)
+		time.Sleep(1500 * time.Millisecond)
+
+		ok, err := redis.Mutex(name).TryLock()
+		t.Assert(err, nil)
+		t.Assert(ok, false)
+		t.Assert(m1.Unlock(), nil)
+	})
+}
+
+func Test_Mutex_Redlock(t *testing.T) {
+	var (
+		name    = guid.S()
+		redises = make([]*gredis.Redis, 3)
+	)
+	for i := range redises {
+		redises[i] = gredis.New(&gredis.Config{
+			Host: config.Host,
+			Port: config.Port,
+			Db:   config.Db + i,
+		})
+		defer redises[i].Close()
+		defer redises[i].Do("DEL", name, name+":fencing")
+	}
+	if _, err := redises[0].Do("PING"); err != nil {
+		t.Skip("redis is unreachable:", err)
+	}
+	gtest.C(t, func(t *gtest.T) {
+		m1 := gredis.NewMutex(name, redises, gredis.MutexOptions{TTL: time.Second})
+		m2 := gredis.NewMutex(name, redises, gredis.MutexOptions{TTL: time.Second, RetryTimes: 2})
+		t.Assert(m1.Lock(), nil)
+		t.Assert(m1.Token(), 1)
+		for _, r := range redises {
+			v, err := r.DoVar("EXISTS", name)
+			t.Assert(err, nil)
+			t.Assert(v.Int(), 1)
+		}
+		t.Assert(m2.Lock(), gredis.ErrorLockFailed)
+		t.Assert(m1.Unlock(), nil)
+
+		// The lock can be acquired on the majority of the instances.
+		_, err := redises[0].Do("SET", name, "other")
+		t.Assert(err, nil)
+		t.Assert(m2.Lock(), nil)
+		t.Assert(m2.Unlock(), nil)
+
+		// The lock cannot be acquired on the minority of the instances,
+		// and the partially acquired ones are released.
+		_, err = redises[1].Do("SET", name, "other")
+		t.Assert(err, nil)
+		ok, err := m1.TryLock()
+		t.Assert(err, nil)
+		t.Assert(ok, false)
+		v, err := redises[2].DoVar("EXISTS", name)
+		t.Assert(err, nil)
+		t.Assert(v.Int(), 0)
+	})
+}
+
+func Test_Limiter_InvalidConfig(t *testing.T) {
+	redis := gredis.New(config)
+	defer redis.Close()
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		redis.TokenBucketLimiter(0, 10)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		redis.TokenBucketLimiter(10, 0)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		redis.SlidingWindowLimiter(3, 0)
+	})
+}
+
+func Test_SlidingWindowLimiter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			redis   = gredis.New(config)
+			key     = guid.S()
+			limiter = redis.SlidingWindowLimiter(3, time.Second)
+		)
+		defer redis.Close()
+		defer limiter.Reset(key)
+
+		for i := 0; i < 3; i++ {
+			result, err := limiter.AllowN(key, 1)
+			t.Assert(err, nil)
+			t.Assert(result.Allowed, true)
+			t.Assert(result.Remaining, 2-i)
+		}
+		result, err := limiter.AllowN(key, 1)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, false)
+		t.Assert(result.RetryAfter > 0, true)
+
+		time.Sleep(time.Second)
+		ok, err := limiter.Allow(key)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+	})
+}
+
+func Test_TokenBucketLimiter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			redis   = gredis.New(config)
+			key     = guid.S()
+			limiter = redis.TokenBucketLimiter(10, 2)
+		)
+		defer redis.Close()
+		defer limiter.Reset(key)
+
+		result, err := limiter.AllowN(key, 2)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 0)
+
+		result, err = limiter.AllowN(key, 1)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, false)
+		t.Assert(result.RetryAfter > 0, true)
+
+		result, err = limiter.AllowN(key, 3)
+		t.Assert(err, nil)
+		t.Assert(result.RetryAfter < 0, true)
+
+		time.Sleep(200 * time.Millisecond)
+		ok, err := limiter.Allow(key)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcompress_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/encoding/gcompress"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_Zstd_UnZstd(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		src := []byte("hello, world, hello, world, hello, world")
+		data, err := gcompress.Zstd(src)
+		t.Assert(err, nil)
+		t.AssertNE(data, src)
+
+		data, err = gcompress.UnZstd(data)
+		t.Assert(err, nil)
+		t.Assert(data, src)
+
+		data, err = gcompress.Zstd(src, 19)
+		t.Assert(err, nil)
+		data, err = gcompress.UnZstd(data)
+		t.Assert(err, nil)
+		t.Assert(data, src)
+	})
+}
+
+func Test_Zstd_UnZstd_File(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			path    = gfile.TempDir(gtime.TimestampNanoStr())
+			srcPath = gfile.Join(path, "file")
+			dstPath = gfile.Join(path, "file.zst")
+			content = "hello, world, hello, world, hello, world"
+		)
+		defer gfile.Remove(path)
+		t.Assert(gfile.PutContents(srcPath, content), nil)
+		t.Assert(gcompress.ZstdFile(srcPath, dstPath), nil)
+		t.Assert(gfile.Remove(srcPath), nil)
+		t.Assert(gcompress.UnZstdFile(dstPath, srcPath), nil)
+		t.Assert(gfile.GetContents(srcPath), content)
+	})
+}
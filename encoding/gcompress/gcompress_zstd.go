@@ -0,0 +1,93 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcompress
+
+import (
+	"io"
+
+	"github.com/gogf/gf/os/gfile"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Zstd compresses <data> using zstd algorithm.
+// The optional parameter <level> specifies the compression level from
+// 1 to 22 as zstd standard levels, which is mapped to the closest supported level.
+func Zstd(data []byte, level ...int) ([]byte, error) {
+	encoder, err := zstd.NewWriter(nil, zstdEncoderOptions(level...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer encoder.Close()
+	return encoder.EncodeAll(data, nil), nil
+}
+
+// ZstdFile compresses the file <src> to <dst> using zstd algorithm.
+func ZstdFile(src, dst string, level ...int) error {
+	srcFile, err := gfile.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	dstFile, err := gfile.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	encoder, err := zstd.NewWriter(dstFile, zstdEncoderOptions(level...)...)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(encoder, srcFile); err != nil {
+		encoder.Close()
+		return err
+	}
+	return encoder.Close()
+}
+
+// UnZstd decompresses <data> with zstd algorithm.
+func UnZstd(data []byte) ([]byte, error) {
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer decoder.Close()
+	return decoder.DecodeAll(data, nil)
+}
+
+// UnZstdFile decompresses file <src> to <dst> using zstd algorithm.
+func UnZstdFile(src, dst string) error {
+	srcFile, err := gfile.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	dstFile, err := gfile.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	decoder, err := zstd.NewReader(srcFile)
+	if err != nil {
+		return err
+	}
+	defer decoder.Close()
+
+	if _, err = io.Copy(dstFile, decoder); err != nil {
+		return err
+	}
+	return nil
+}
+
+// zstdEncoderOptions returns the encoder options for compression <level>.
+func zstdEncoderOptions(level ...int) []zstd.EOption {
+	if len(level) > 0 && level[0] > 0 {
+		return []zstd.EOption{zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level[0]))}
+	}
+	return nil
+}
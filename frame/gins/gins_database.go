@@ -14,6 +14,7 @@ import (
 	"github.com/gogf/gf/util/gutil"
 
 	"github.com/gogf/gf/database/gdb"
+	"github.com/gogf/gf/database/gredis"
 	"github.com/gogf/gf/text/gregex"
 	"github.com/gogf/gf/util/gconv"
 )
@@ -118,6 +119,14 @@ func Database(name ...string) gdb.DB {
 				}
 			}
 		}
+		// Initialize redis configuration for SQL result cache if configured.
+		for _, node := range gdb.GetConfig(group) {
+			if node.CacheRedis != "" {
+				if _, ok := gredis.GetConfig(node.CacheRedis); !ok {
+					initRedisConfig(node.CacheRedis)
+				}
+			}
+		}
 		// Create a new ORM object with given configurations.
 		if db, err := gdb.New(name...); err == nil {
 			if Config().Available() {
@@ -20,46 +20,49 @@ const (
 
 // Redis returns an instance of redis client with specified configuration group name.
 func Redis(name ...string) *gredis.Redis {
-	config := Config()
 	group := gredis.DefaultGroupName
 	if len(name) > 0 && name[0] != "" {
 		group = name[0]
 	}
 	instanceKey := fmt.Sprintf("%s.%s", frameCoreComponentNameRedis, group)
 	result := instances.GetOrSetFuncLock(instanceKey, func() interface{} {
-		// If already configured, it returns the redis instance.
-		if _, ok := gredis.GetConfig(group); ok {
-			return gredis.Instance(group)
+		// If not configured, it parses the default configuration file for the redis instance.
+		if _, ok := gredis.GetConfig(group); !ok {
+			initRedisConfig(group)
 		}
-		// Or else, it parses the default configuration file and returns a new redis instance.
-		var m map[string]interface{}
-		if _, v := gutil.MapPossibleItemByKey(Config().GetMap("."), configNodeNameRedis); v != nil {
-			m = gconv.Map(v)
-		}
-		if len(m) > 0 {
-			if v, ok := m[group]; ok {
-				redisConfig, err := gredis.ConfigFromStr(gconv.String(v))
-				if err != nil {
-					panic(err)
-				}
-				return gredis.New(redisConfig)
-			} else {
-				panic(fmt.Sprintf(`configuration for redis not found for group "%s"`, group))
-			}
-		} else {
-			filepath, err := config.GetFilePath()
-			if err != nil {
-				panic(err)
-			}
-			panic(fmt.Sprintf(
-				`incomplete configuration for redis: "redis" node not found in config file "%s"`,
-				filepath,
-			))
-		}
-		return nil
+		return gredis.Instance(group)
 	})
 	if result != nil {
 		return result.(*gredis.Redis)
 	}
 	return nil
 }
+
+// initRedisConfig parses the redis configuration of <group> from the default configuration
+// file and sets it to package gredis. It panics if the configuration is not found.
+func initRedisConfig(group string) {
+	var m map[string]interface{}
+	if _, v := gutil.MapPossibleItemByKey(Config().GetMap("."), configNodeNameRedis); v != nil {
+		m = gconv.Map(v)
+	}
+	if len(m) > 0 {
+		if v, ok := m[group]; ok {
+			redisConfig, err := gredis.ConfigFromStr(gconv.String(v))
+			if err != nil {
+				panic(err)
+			}
+			gredis.SetConfig(redisConfig, group)
+		} else {
+			panic(fmt.Sprintf(`configuration for redis not found for group "%s"`, group))
+		}
+	} else {
+		filepath, err := Config().GetFilePath()
+		if err != nil {
+			panic(err)
+		}
+		panic(fmt.Sprintf(
+			`incomplete configuration for redis: "redis" node not found in config file "%s"`,
+			filepath,
+		))
+	}
+}
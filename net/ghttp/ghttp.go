@@ -72,6 +72,13 @@ type (
 		hookName   string             // Hook type name.
 		router     *Router            // Router object.
 		source     string             // Source file path:line when registering.
+		api        *handlerApi        // API definition of the handler, which is used for OpenAPI document.
+	}
+
+	// handlerApi is the API definition of handler, with its request and response struct declared.
+	handlerApi struct {
+		request  interface{} // Request struct or its pointer.
+		response interface{} // Response struct or its pointer.
 	}
 
 	// handlerParsedItem is the item parsed from URL.Path.
@@ -0,0 +1,20 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+// MiddlewareCompress is a server middleware that enables response compression for the requests
+// of the routes it's bound to, using the compression configuration of the server, see
+// ServerConfig.CompressLevel/CompressMinSize/CompressMimeTypes. The content is compressed
+// using gzip/deflate or the registered encoding by RegisterCompressEncoder, according to the
+// Accept-Encoding header of the request.
+//
+// Use ServerConfig.CompressEnabled instead if you want compression for all requests, including
+// the static files, which are not served by middleware.
+func MiddlewareCompress(r *Request) {
+	r.Response.Writer.enableCompression(r)
+	r.Middleware.Next()
+}
@@ -0,0 +1,62 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+
+	"github.com/gogf/gf/errors/gerror"
+)
+
+// DefaultHandlerResponse is the default response envelope written by MiddlewareHandlerResponse.
+type DefaultHandlerResponse struct {
+	Code    int         `json:"code"    dc:"Error code, which is 0 if success"`
+	Message string      `json:"message" dc:"Error message"`
+	Data    interface{} `json:"data"    dc:"Result data for certain request according API definition"`
+}
+
+const (
+	ResponseCodeOk               = 0  // Response code for success.
+	ResponseCodeInternalError    = 50 // Response code for error without code, or panic.
+	ResponseCodeValidationFailed = 51 // Response code for request parsing or validation failure.
+)
+
+// MiddlewareHandlerResponse is the default response handler middleware for typed handlers, which
+// writes the response object and error of the handler as JSON of DefaultHandlerResponse.
+//
+// The code of the envelope is the code of the error, see gerror.Code. The error without code
+// is of code ResponseCodeInternalError. It does nothing if the response is written by the handler,
+// unless there's an error. The custom response handler middleware can be implemented like this
+// using Request.GetHandlerResponse.
+func MiddlewareHandlerResponse(r *Request) {
+	r.Middleware.Next()
+
+	// The response is already written by the handler, or sent to client in streaming mode.
+	if (r.Response.BufferLength() > 0 && r.GetError() == nil) || r.Response.IsStreaming() {
+		return
+	}
+	var (
+		res, err = r.GetHandlerResponse()
+		response = DefaultHandlerResponse{
+			Code: ResponseCodeOk,
+			Data: res,
+		}
+	)
+	if err != nil {
+		response.Code = gerror.Code(err)
+		if response.Code == -1 {
+			response.Code = ResponseCodeInternalError
+		}
+		response.Message = err.Error()
+		response.Data = nil
+		r.Response.ClearBuffer()
+	} else if r.Response.Status != 0 && r.Response.Status != http.StatusOK {
+		// Status like 404 of unmatched route.
+		return
+	}
+	r.Response.WriteJson(response)
+}
@@ -0,0 +1,173 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/util/gconv"
+)
+
+// RateLimitStore checks and consumes the request quota of keys, which holds the limiting state.
+//
+// The redis limiters of package gredis, like gredis.Redis.SlidingWindowLimiter and
+// gredis.Redis.TokenBucketLimiter, share the limiting state between nodes, which can be
+// adapted using RateLimitStoreFunc:
+//
+//	limiter := redis.TokenBucketLimiter(10, 20)
+//	store := ghttp.RateLimitStoreFunc(func(key string, n int) (*ghttp.RateLimitResult, error) {
+//	    r, err := limiter.AllowN(key, n)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return &ghttp.RateLimitResult{
+//	        Allowed: r.Allowed, Limit: r.Limit, Remaining: r.Remaining,
+//	        RetryAfter: r.RetryAfter, ResetAfter: r.ResetAfter,
+//	    }, nil
+//	})
+type RateLimitStore interface {
+	// AllowN checks whether <n> requests with <key> are allowed, and consumes the quota if so.
+	AllowN(key string, n int) (*RateLimitResult, error)
+}
+
+// RateLimitStoreFunc is the function adapter of RateLimitStore.
+type RateLimitStoreFunc func(key string, n int) (*RateLimitResult, error)
+
+// RateLimitResult is the result of RateLimitStore.AllowN.
+type RateLimitResult struct {
+	Allowed    bool          // Whether the requests are allowed.
+	Limit      int           // Maximum requests in a window, or the burst size of a token bucket.
+	Remaining  int           // Remaining requests allowed currently.
+	RetryAfter time.Duration // Time to wait before the requests can be allowed, 0 if allowed, -1 if never.
+	ResetAfter time.Duration // Time before the quota is fully restored.
+}
+
+// RateLimitKeyFunc extracts and returns the limiting key from request.
+type RateLimitKeyFunc func(r *Request) string
+
+// RateLimitConfig is the configuration for MiddlewareRateLimit.
+type RateLimitConfig struct {
+	Store   RateLimitStore   // Store of the limiting state, like: NewSlidingWindowMemoryStore, NewTokenBucketMemoryStore.
+	KeyFunc RateLimitKeyFunc // KeyFunc extracts the limiting key, which is RateLimitKeyByIp in default. The request is not limited if the key is empty.
+	Prefix  string           // Prefix of the limiting key, which separates the keys of different limits sharing the same store, like redis.
+}
+
+const (
+	defaultRateLimitPrefix = "ratelimit:"
+)
+
+// MiddlewareRateLimit creates and returns a middleware limiting the requests using <config>,
+// which is commonly bound to a group or route:
+//
+//	group.Middleware(ghttp.MiddlewareRateLimit(ghttp.RateLimitConfig{
+//	    Store: ghttp.NewSlidingWindowMemoryStore(100, time.Minute),
+//	}))
+//
+// It sets the headers X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (seconds)
+// to the response. The request exceeding the limit is responded with status 429 and header
+// Retry-After (seconds), and the content can be customized by Server.BindStatusHandler for
+// status http.StatusTooManyRequests. The request is allowed if the store fails.
+func MiddlewareRateLimit(config RateLimitConfig) HandlerFunc {
+	if config.Store == nil {
+		panic(gerror.New(`rate limit store should not be nil`))
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = RateLimitKeyByIp
+	}
+	if config.Prefix == "" {
+		config.Prefix = defaultRateLimitPrefix
+	}
+	return func(r *Request) {
+		key := config.KeyFunc(r)
+		if key == "" {
+			r.Middleware.Next()
+			return
+		}
+		result, err := config.Store.AllowN(config.Prefix+key, 1)
+		if err != nil {
+			r.Server.handleErrorLog(gerror.Wrap(err, "rate limit checking failed"), r)
+			r.Middleware.Next()
+			return
+		}
+		header := r.Response.Header()
+		header.Set("X-RateLimit-Limit", gconv.String(result.Limit))
+		header.Set("X-RateLimit-Remaining", gconv.String(result.Remaining))
+		header.Set("X-RateLimit-Reset", gconv.String(ceilSeconds(result.ResetAfter)))
+		if result.Allowed {
+			r.Middleware.Next()
+			return
+		}
+		// The request never can be allowed if RetryAfter is negative.
+		if result.RetryAfter >= 0 {
+			header.Set("Retry-After", gconv.String(ceilSeconds(result.RetryAfter)))
+		}
+		// The content is written by status handler, or the default status text.
+		r.Response.WriteHeader(http.StatusTooManyRequests)
+	}
+}
+
+// AllowN implements the interface function of RateLimitStore.AllowN.
+func (f RateLimitStoreFunc) AllowN(key string, n int) (*RateLimitResult, error) {
+	return f(key, n)
+}
+
+// RateLimitKeyByIp is the RateLimitKeyFunc limiting requests by client ip.
+func RateLimitKeyByIp(r *Request) string {
+	return r.GetClientIp()
+}
+
+// RateLimitKeyByRoute is the RateLimitKeyFunc limiting requests by the route of serving handler,
+// like: GET:/user/{id}, which limits all requests of the route in total.
+func RateLimitKeyByRoute(r *Request) string {
+	for _, item := range r.handlers {
+		switch item.handler.itemType {
+		case handlerTypeHandler, handlerTypeObject, handlerTypeController:
+			return fmt.Sprintf(`%s:%s`, item.handler.router.Method, item.handler.router.Uri)
+		}
+	}
+	return r.URL.Path
+}
+
+// RateLimitKeyByCtxVar returns a RateLimitKeyFunc limiting requests by the context variable of <key>,
+// like the user id set by authentication middleware, see Request.SetCtxVar. It limits the requests
+// by client ip if the variable is empty.
+func RateLimitKeyByCtxVar(key interface{}) RateLimitKeyFunc {
+	return func(r *Request) string {
+		if v := r.GetCtxVar(key).String(); v != "" {
+			return v
+		}
+		return "ip:" + r.GetClientIp()
+	}
+}
+
+// RateLimitKeyJoin returns a RateLimitKeyFunc joining the keys of <funcs>, like limiting the requests
+// by user and route: RateLimitKeyJoin(RateLimitKeyByCtxVar("UserId"), RateLimitKeyByRoute).
+// The request is not limited if any of the keys is empty.
+func RateLimitKeyJoin(funcs ...RateLimitKeyFunc) RateLimitKeyFunc {
+	return func(r *Request) string {
+		keys := make([]string, len(funcs))
+		for i, f := range funcs {
+			if keys[i] = f(r); keys[i] == "" {
+				return ""
+			}
+		}
+		return strings.Join(keys, "|")
+	}
+}
+
+// ceilSeconds returns the seconds of <d> rounded up.
+func ceilSeconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(d.Seconds()))
+}
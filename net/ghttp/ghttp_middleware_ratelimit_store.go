@@ -0,0 +1,164 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/os/gcache"
+)
+
+// slidingWindowMemoryStore is the in-memory RateLimitStore using sliding window.
+type slidingWindowMemoryStore struct {
+	cache  *gcache.Cache // Cache for states of keys.
+	limit  int           // Maximum requests in a window.
+	window time.Duration // Window size.
+}
+
+// slidingWindowState is the timestamps of allowed requests in the window of a key.
+type slidingWindowState struct {
+	mu         sync.Mutex
+	timestamps []time.Time
+}
+
+// tokenBucketMemoryStore is the in-memory RateLimitStore using token bucket.
+type tokenBucketMemoryStore struct {
+	cache *gcache.Cache // Cache for states of keys.
+	rate  float64       // Tokens refilled per second.
+	burst int           // Bucket capacity.
+}
+
+// tokenBucketState is the bucket of a key.
+type tokenBucketState struct {
+	mu        sync.Mutex
+	tokens    float64
+	timestamp time.Time
+}
+
+// NewSlidingWindowMemoryStore creates and returns an in-memory RateLimitStore, which allows at most
+// <limit> requests per key within any <window>. The states of keys are stored using gcache,
+// which expire after the window.
+// It panics if <limit> or <window> is not positive.
+func NewSlidingWindowMemoryStore(limit int, window time.Duration) RateLimitStore {
+	if limit <= 0 || window <= 0 {
+		panic(gerror.New("NewSlidingWindowMemoryStore requires positive limit and window"))
+	}
+	return &slidingWindowMemoryStore{
+		cache:  gcache.New(),
+		limit:  limit,
+		window: window,
+	}
+}
+
+// NewTokenBucketMemoryStore creates and returns an in-memory RateLimitStore, whose bucket per key
+// holds at most <burst> tokens and is refilled with <rate> tokens per second. The states of keys
+// are stored using gcache, which expire after the buckets are full.
+// It panics if <rate> or <burst> is not positive, as the bucket never refills if <rate> is not positive.
+func NewTokenBucketMemoryStore(rate float64, burst int) RateLimitStore {
+	if rate <= 0 || burst <= 0 {
+		panic(gerror.New("NewTokenBucketMemoryStore requires positive rate and burst"))
+	}
+	return &tokenBucketMemoryStore{
+		cache: gcache.New(),
+		rate:  rate,
+		burst: burst,
+	}
+}
+
+// AllowN implements the interface function of RateLimitStore.AllowN.
+func (s *slidingWindowMemoryStore) AllowN(key string, n int) (*RateLimitResult, error) {
+	v, err := s.cache.GetOrSetFuncLock(key, func() (interface{}, error) {
+		return &slidingWindowState{}, nil
+	}, s.window)
+	if err != nil {
+		return nil, err
+	}
+	state := v.(*slidingWindowState)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	var (
+		now    = time.Now()
+		start  = now.Add(-s.window)
+		index  = 0
+		result = &RateLimitResult{Limit: s.limit}
+	)
+	// Remove the timestamps out of the window.
+	for index < len(state.timestamps) && !state.timestamps[index].After(start) {
+		index++
+	}
+	state.timestamps = state.timestamps[index:]
+	count := len(state.timestamps)
+	if count+n <= s.limit {
+		for i := 0; i < n; i++ {
+			state.timestamps = append(state.timestamps, now)
+		}
+		result.Allowed = true
+		result.Remaining = s.limit - count - n
+		_, _ = s.cache.UpdateExpire(key, s.window)
+	} else {
+		result.Remaining = s.limit - count
+		result.RetryAfter = -1
+		if n <= s.limit {
+			result.RetryAfter = state.timestamps[count+n-s.limit-1].Add(s.window).Sub(now)
+		}
+	}
+	result.ResetAfter = s.window
+	if len(state.timestamps) > 0 {
+		result.ResetAfter = state.timestamps[0].Add(s.window).Sub(now)
+	}
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+	return result, nil
+}
+
+// AllowN implements the interface function of RateLimitStore.AllowN.
+func (s *tokenBucketMemoryStore) AllowN(key string, n int) (*RateLimitResult, error) {
+	v, err := s.cache.GetOrSetFuncLock(key, func() (interface{}, error) {
+		return &tokenBucketState{
+			tokens:    float64(s.burst),
+			timestamp: time.Now(),
+		}, nil
+	}, s.secondsToDuration(float64(s.burst))+time.Second)
+	if err != nil {
+		return nil, err
+	}
+	state := v.(*tokenBucketState)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	var (
+		now    = time.Now()
+		result = &RateLimitResult{Limit: s.burst}
+	)
+	// Refill the bucket by elapsed time.
+	if elapsed := now.Sub(state.timestamp); elapsed > 0 {
+		state.tokens = math.Min(float64(s.burst), state.tokens+elapsed.Seconds()*s.rate)
+	}
+	state.timestamp = now
+	if state.tokens >= float64(n) {
+		state.tokens -= float64(n)
+		result.Allowed = true
+	} else if n <= s.burst {
+		result.RetryAfter = s.secondsToDuration(float64(n) - state.tokens)
+	} else {
+		result.RetryAfter = -1
+	}
+	result.Remaining = int(math.Floor(state.tokens))
+	result.ResetAfter = s.secondsToDuration(float64(s.burst) - state.tokens)
+	_, _ = s.cache.UpdateExpire(key, result.ResetAfter+time.Second)
+	return result, nil
+}
+
+// secondsToDuration returns the duration refilling <tokens>.
+func (s *tokenBucketMemoryStore) secondsToDuration(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(tokens / s.rate * float64(time.Second)))
+}
@@ -32,6 +32,7 @@ const (
 	tracingEventHttpResponse        = "http.response"
 	tracingEventHttpResponseHeaders = "http.response.headers"
 	tracingEventHttpResponseBody    = "http.response.body"
+	tracingEventHttpResponseStream  = "http.response.streamed"
 )
 
 // MiddlewareClientTracing is a client middleware that enables tracing feature using standards of OpenTelemetry.
@@ -81,9 +82,14 @@ func MiddlewareServerTracing(r *Request) {
 		"...",
 	)
 
-	span.AddEvent(tracingEventHttpResponse, trace.WithAttributes(
+	attributes := []attribute.KeyValue{
 		attribute.Any(tracingEventHttpResponseHeaders, httputil.HeaderToMap(r.Response.Header())),
 		attribute.String(tracingEventHttpResponseBody, resBodyContent),
-	))
+	}
+	// The content is sent to client in time in streaming mode, only its length is logged.
+	if r.Response.IsStreaming() {
+		attributes = append(attributes, attribute.Int64(tracingEventHttpResponseStream, r.Response.streamed))
+	}
+	span.AddEvent(tracingEventHttpResponse, trace.WithAttributes(attributes...))
 	return
 }
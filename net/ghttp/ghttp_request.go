@@ -48,6 +48,7 @@ type Request struct {
 	formMap         map[string]interface{} // Form parameters map, which is nil if there's no form data from client.
 	bodyMap         map[string]interface{} // Body parameters map, which might be nil if there're no body content.
 	error           error                  // Current executing error of the request.
+	handlerResponse interface{}            // Response object returned by the typed handler.
 	exit            bool                   // A bool marking whether current request is exited.
 	parsedHost      string                 // The parsed host name for current host used by GetHost function.
 	clientIp        string                 // The parsed client ip for current host used by GetClientIp function.
@@ -224,6 +225,17 @@ func (r *Request) GetError() error {
 	return r.error
 }
 
+// SetError sets custom error for current request.
+func (r *Request) SetError(err error) {
+	r.error = err
+}
+
+// GetHandlerResponse retrieves and returns the response object and error returned by the
+// typed handler like: func(context.Context, *XxxReq) (*XxxRes, error).
+func (r *Request) GetHandlerResponse() (res interface{}, err error) {
+	return r.handlerResponse, r.error
+}
+
 // ReloadParam is used for modifying request parameter.
 // Sometimes, we want to modify request parameters through middleware, but directly modifying Request.Body
 // is invalid, so it clears the parsed* marks to make the parameters re-parsed.
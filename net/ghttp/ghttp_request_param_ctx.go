@@ -11,6 +11,18 @@ import (
 	"github.com/gogf/gf/container/gvar"
 )
 
+// ctxKeyForRequest is the context key for the Request object in context.
+type ctxKeyForRequest struct{}
+
+// RequestFromCtx retrieves and returns the Request object from context <ctx>, which is
+// available in the context passed to the typed handler. It returns nil if not found.
+func RequestFromCtx(ctx context.Context) *Request {
+	if v, ok := ctx.Value(ctxKeyForRequest{}).(*Request); ok {
+		return v
+	}
+	return nil
+}
+
 // Context is alias for function GetCtx.
 // This function overwrites the http.Request.Context function.
 // See GetCtx.
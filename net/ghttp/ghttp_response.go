@@ -24,6 +24,7 @@ type Response struct {
 	Server          *Server         // Parent server.
 	Writer          *ResponseWriter // Alias of ResponseWriter.
 	Request         *Request        // According request.
+	sse             *SSEWriter      // Server-Sent Events writer, which is nil if it's not an event stream.
 }
 
 // newResponse creates and returns a new Response object.
@@ -143,3 +144,15 @@ func (r *Response) Flush() {
 	}
 	r.Writer.Flush()
 }
+
+// finish outputs the remaining buffer content to the client, which is called
+// after all handlers of the request are done.
+func (r *Response) finish() {
+	if r.sse != nil {
+		r.sse.Close()
+	}
+	if r.Server.config.ServerAgent != "" {
+		r.Header().Set("Server", r.Server.config.ServerAgent)
+	}
+	r.Writer.finish()
+}
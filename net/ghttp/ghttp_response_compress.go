@@ -0,0 +1,371 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/os/gfile"
+	"github.com/klauspost/compress/flate"
+	"github.com/klauspost/compress/gzip"
+)
+
+// CompressEncoderFunc creates and returns an encoder writing compressed content to <w>
+// with compression <level>. The level -1 means the default level of the encoding.
+type CompressEncoderFunc func(w io.Writer, level int) (io.WriteCloser, error)
+
+// compressEncoder is a registered content encoding for response compression.
+type compressEncoder struct {
+	encoding  string              // Content encoding name, like: gzip.
+	extension string              // File extension of precompressed static file, like: gz.
+	create    CompressEncoderFunc // Function creating the encoder.
+}
+
+// compressWriter is the http.ResponseWriter compressing the response content according to
+// the Accept-Encoding header of the request and the compression configuration of the server.
+// The compression is decided when the header is written to the underlying writer.
+type compressWriter struct {
+	writer      http.ResponseWriter // The underlying ResponseWriter.
+	request     *http.Request       // According request.
+	config      *ServerConfig       // Server configuration for compression.
+	length      int                 // Known content length, which is -1 if it's unknown, like streaming.
+	status      int                 // Delayed status of WriteHeader.
+	encoder     io.WriteCloser      // Encoder for compressed content, which is nil if not compressed.
+	wroteHeader bool                // Is header wrote or not to the underlying writer.
+	hijacked    bool                // Mark this request is hijacked or not.
+}
+
+var (
+	// compressEncoders are the registered encodings in order of preference.
+	compressEncoders = []*compressEncoder{
+		{encoding: "gzip", extension: "gz", create: func(w io.Writer, level int) (io.WriteCloser, error) {
+			return gzip.NewWriterLevel(w, level)
+		}},
+		{encoding: "deflate", create: func(w io.Writer, level int) (io.WriteCloser, error) {
+			return flate.NewWriter(w, level)
+		}},
+	}
+	compressEncodersMu sync.RWMutex
+
+	// defaultCompressMimeTypes are the default content types to be compressed.
+	defaultCompressMimeTypes = []string{
+		"text/*",
+		"application/json",
+		"application/javascript",
+		"application/xml",
+		"application/xhtml+xml",
+		"application/wasm",
+		"image/svg+xml",
+	}
+)
+
+const (
+	defaultCompressMinSize = 1024
+)
+
+// RegisterCompressEncoder registers encoder function <f> for content encoding <encoding>, which
+// overwrites the existing one of the same name. The newly registered encoding is preferred over
+// the built-in gzip and deflate encodings if the client accepts them in the same quality.
+// The optional parameter <extension> specifies the file extension of precompressed static file.
+//
+// Eg, brotli compression can be registered using package github.com/andybalholm/brotli:
+//
+//	ghttp.RegisterCompressEncoder("br", func(w io.Writer, level int) (io.WriteCloser, error) {
+//	    if level < 0 {
+//	        level = brotli.DefaultCompression
+//	    }
+//	    return brotli.NewWriterLevel(w, level), nil
+//	}, "br")
+func RegisterCompressEncoder(encoding string, f CompressEncoderFunc, extension ...string) {
+	encoder := &compressEncoder{
+		encoding: strings.ToLower(encoding),
+		create:   f,
+	}
+	if len(extension) > 0 {
+		encoder.extension = strings.TrimLeft(extension[0], ".")
+	}
+	compressEncodersMu.Lock()
+	defer compressEncodersMu.Unlock()
+	encoders := []*compressEncoder{encoder}
+	for _, v := range compressEncoders {
+		if v.encoding != encoder.encoding {
+			encoders = append(encoders, v)
+		}
+	}
+	compressEncoders = encoders
+}
+
+// newCompressWriter creates and returns a compressWriter wrapping <w>.
+func newCompressWriter(w http.ResponseWriter, r *http.Request, config *ServerConfig) *compressWriter {
+	return &compressWriter{
+		writer:  w,
+		request: r,
+		config:  config,
+		length:  -1,
+	}
+}
+
+// Header implements the interface function of http.ResponseWriter.Header.
+func (w *compressWriter) Header() http.Header {
+	return w.writer.Header()
+}
+
+// WriteHeader implements the interface of http.ResponseWriter.WriteHeader.
+// The status is delayed to be written with the first content, as the content type is
+// needed for compressing.
+func (w *compressWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+	}
+}
+
+// Write implements the interface function of http.ResponseWriter.Write.
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.writeHeader(data)
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.writer.Write(data)
+}
+
+// Flush implements the interface function of http.Flusher.Flush, which flushes the buffered
+// compressed content and sends it to the client.
+func (w *compressWriter) Flush() {
+	if w.hijacked {
+		return
+	}
+	if !w.wroteHeader {
+		w.writeHeader(nil)
+	}
+	if f, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := w.writer.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements the interface function of http.Hijacker.Hijack.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.hijacked = true
+	return w.writer.(http.Hijacker).Hijack()
+}
+
+// Close writes the delayed header if necessary, and closes the encoder, which writes the
+// remaining compressed content to the underlying writer.
+func (w *compressWriter) Close() error {
+	if w.hijacked {
+		return nil
+	}
+	if !w.wroteHeader {
+		if w.status == 0 {
+			return nil
+		}
+		w.length = 0
+		w.writeHeader(nil)
+	}
+	if w.encoder != nil {
+		return w.encoder.Close()
+	}
+	return nil
+}
+
+// writeHeader decides the compression with the first content <data>, and writes the header
+// to the underlying writer.
+func (w *compressWriter) writeHeader(data []byte) {
+	w.wroteHeader = true
+	status := w.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	header := w.writer.Header()
+	// The content type should be detected before compressing, or else the http server
+	// detects the content type using the compressed content.
+	if _, ok := header["Content-Type"]; !ok && len(data) > 0 {
+		header.Set("Content-Type", http.DetectContentType(data))
+	}
+	if w.isCompressible(status) {
+		addVaryHeader(header, "Accept-Encoding")
+		length := w.length
+		if length < 0 {
+			if v := header.Get("Content-Length"); v != "" {
+				length, _ = strconv.Atoi(v)
+			}
+		}
+		minSize := w.config.CompressMinSize
+		if minSize <= 0 {
+			minSize = defaultCompressMinSize
+		}
+		if length < 0 || length >= minSize {
+			for _, encoder := range negotiateCompressEncoders(w.request.Header.Get("Accept-Encoding")) {
+				e, err := encoder.create(w.writer, w.config.CompressLevel)
+				if err != nil {
+					continue
+				}
+				w.encoder = e
+				header.Set("Content-Encoding", encoder.encoding)
+				header.Del("Content-Length")
+				break
+			}
+		}
+	}
+	if w.status != 0 {
+		w.writer.WriteHeader(status)
+	}
+}
+
+// isCompressible checks whether the content of <status> and current content type is compressible.
+func (w *compressWriter) isCompressible(status int) bool {
+	switch {
+	case status < http.StatusOK,
+		status == http.StatusNoContent,
+		status == http.StatusPartialContent,
+		status == http.StatusNotModified:
+		return false
+	}
+	header := w.writer.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	return isCompressMimeType(header.Get("Content-Type"), w.config.CompressMimeTypes)
+}
+
+// serveFile serves precompressed static file of <path> if it exists and its encoding is
+// accepted by the client, and returns true if served.
+func (w *compressWriter) serveFile(path string) bool {
+	for _, encoder := range negotiateCompressEncoders(w.request.Header.Get("Accept-Encoding")) {
+		if encoder.extension == "" {
+			continue
+		}
+		file, err := os.Open(path + "." + encoder.extension)
+		if err != nil {
+			continue
+		}
+		info, err := file.Stat()
+		if err != nil || info.IsDir() {
+			file.Close()
+			continue
+		}
+		header := w.writer.Header()
+		if header.Get("Content-Type") == "" {
+			contentType := mime.TypeByExtension(gfile.Ext(path))
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+			header.Set("Content-Type", contentType)
+		}
+		header.Set("Content-Encoding", encoder.encoding)
+		addVaryHeader(header, "Accept-Encoding")
+		http.ServeContent(w, w.request, gfile.Basename(path), info.ModTime(), file)
+		file.Close()
+		return true
+	}
+	return false
+}
+
+// negotiateCompressEncoders returns the registered encoders accepted by <acceptEncoding>,
+// in order of the quality values and the preference of the server.
+func negotiateCompressEncoders(acceptEncoding string) []*compressEncoder {
+	if acceptEncoding == "" {
+		return nil
+	}
+	var (
+		qualities     = make(map[string]float64)
+		wildcard      = -1.0
+		acceptedItems = strings.Split(strings.ToLower(acceptEncoding), ",")
+	)
+	for _, item := range acceptedItems {
+		var (
+			array    = strings.Split(item, ";")
+			encoding = strings.TrimSpace(array[0])
+			quality  = 1.0
+		)
+		for _, param := range array[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if encoding == "*" {
+			wildcard = quality
+		} else if encoding != "" {
+			qualities[encoding] = quality
+		}
+	}
+	compressEncodersMu.RLock()
+	defer compressEncodersMu.RUnlock()
+	var (
+		encoders      = make([]*compressEncoder, 0, len(compressEncoders))
+		encoderValues = make(map[*compressEncoder]float64)
+	)
+	for _, encoder := range compressEncoders {
+		quality, ok := qualities[encoder.encoding]
+		if !ok {
+			quality = wildcard
+		}
+		if quality > 0 {
+			encoders = append(encoders, encoder)
+			encoderValues[encoder] = quality
+		}
+	}
+	sort.SliceStable(encoders, func(i, j int) bool {
+		return encoderValues[encoders[i]] > encoderValues[encoders[j]]
+	})
+	return encoders
+}
+
+// isCompressMimeType checks whether <contentType> matches any of <mimeTypes>. The mime type
+// ending with '*' matches by prefix. It uses the default mime types if <mimeTypes> is empty.
+func isCompressMimeType(contentType string, mimeTypes []string) bool {
+	if contentType == "" {
+		return false
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	// Event stream is flushed event by event, which is not compressed.
+	if mediaType == "text/event-stream" {
+		return false
+	}
+	if len(mimeTypes) == 0 {
+		mimeTypes = defaultCompressMimeTypes
+	}
+	for _, v := range mimeTypes {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if strings.HasSuffix(v, "*") {
+			if strings.HasPrefix(mediaType, v[:len(v)-1]) {
+				return true
+			}
+		} else if mediaType == v {
+			return true
+		}
+	}
+	return false
+}
+
+// addVaryHeader adds <value> to the Vary header if it does not exist.
+func addVaryHeader(header http.Header, value string) {
+	for _, v := range header.Values("Vary") {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item == "*" || strings.EqualFold(item, value) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", value)
+}
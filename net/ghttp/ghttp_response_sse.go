@@ -0,0 +1,174 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/internal/json"
+)
+
+// SSEWriter is the writer of Server-Sent Events for the response, which is created by Response.SSE.
+// It is safe for concurrent use, like writing events and heartbeat in different goroutines.
+type SSEWriter struct {
+	mu        sync.Mutex      // Mutex for writing events concurrently.
+	response  *Response       // According response.
+	ctx       context.Context // Context of the request, which is done if client disconnects.
+	closed    bool            // Mark this writer is closed or not.
+	heartbeat chan struct{}   // Channel for stopping the heartbeat, which is nil if no heartbeat.
+}
+
+// SSE switches the response to streaming mode for Server-Sent Events, and returns the event writer.
+// It sets the necessary headers: Content-Type, Cache-Control, Connection and X-Accel-Buffering
+// (disabling buffering of nginx), and sends the header to client immediately.
+//
+// The handler should keep writing events until client disconnects, which can be detected by
+// SSEWriter.Done, or the error returned by SSEWriter.Event. The writer is closed automatically
+// after the handler returns.
+func (r *Response) SSE() *SSEWriter {
+	if r.sse != nil {
+		return r.sse
+	}
+	header := r.Header()
+	header.Set("Content-Type", "text/event-stream; charset=utf-8")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	r.sse = &SSEWriter{
+		response: r,
+		ctx:      r.Request.Context(),
+	}
+	r.Stream()
+	return r.sse
+}
+
+// LastEventId returns the Last-Event-ID header of the request, which is sent by client
+// reconnecting the stream, for resuming the events after it.
+func (w *SSEWriter) LastEventId() string {
+	return w.response.Request.Header.Get("Last-Event-ID")
+}
+
+// Done returns a channel that's closed when client disconnects.
+func (w *SSEWriter) Done() <-chan struct{} {
+	return w.ctx.Done()
+}
+
+// Event sends an event with <id>, <name> and <data> to client. The <id> and <name> are optional,
+// which are not sent if empty. The <data> of type string/[]byte is sent as it is, or else it is
+// encoded as JSON. Multiple lines of <data> are sent as multiple data fields.
+//
+// It returns error if client disconnects or the writer is closed.
+func (w *SSEWriter) Event(id, name string, data interface{}) error {
+	var content string
+	switch v := data.(type) {
+	case string:
+		content = v
+	case []byte:
+		content = string(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		content = string(b)
+	}
+	buffer := bytes.NewBuffer(nil)
+	if id != "" {
+		buffer.WriteString("id: " + sseEscape(id) + "\n")
+	}
+	if name != "" {
+		buffer.WriteString("event: " + sseEscape(name) + "\n")
+	}
+	for _, line := range strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n") {
+		buffer.WriteString("data: " + line + "\n")
+	}
+	buffer.WriteString("\n")
+	return w.write(buffer.Bytes())
+}
+
+// Data sends an event only with <data> to client, see Event.
+func (w *SSEWriter) Data(data interface{}) error {
+	return w.Event("", "", data)
+}
+
+// Comment sends a comment line to client, which is ignored by client but keeps the connection alive.
+func (w *SSEWriter) Comment(text string) error {
+	return w.write([]byte(": " + sseEscape(text) + "\n\n"))
+}
+
+// Retry sends the reconnection time <retry> to client, which is used by client
+// reconnecting the stream after disconnection.
+func (w *SSEWriter) Retry(retry time.Duration) error {
+	return w.write([]byte(fmt.Sprintf("retry: %d\n\n", retry.Milliseconds())))
+}
+
+// Heartbeat starts sending comment to client every <interval> in another goroutine, which keeps
+// the connection alive through the proxies closing idle connections. The heartbeat is stopped
+// when client disconnects or the writer is closed.
+func (w *SSEWriter) Heartbeat(interval time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed || w.heartbeat != nil || interval <= 0 {
+		return
+	}
+	w.heartbeat = make(chan struct{})
+	go func(stop <-chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-w.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := w.Comment("heartbeat"); err != nil {
+					return
+				}
+			}
+		}
+	}(w.heartbeat)
+}
+
+// Close closes the writer and stops the heartbeat. No more event can be sent after closed.
+func (w *SSEWriter) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	w.closed = true
+	if w.heartbeat != nil {
+		close(w.heartbeat)
+	}
+}
+
+// write sends <data> to client.
+func (w *SSEWriter) write(data []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return gerror.New(`sse writer is closed`)
+	}
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	w.response.Write(data)
+	return nil
+}
+
+// sseEscape removes the line breaks from the field value, which are not allowed in the
+// id, event and comment fields.
+func sseEscape(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
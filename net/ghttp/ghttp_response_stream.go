@@ -0,0 +1,38 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+)
+
+// Stream switches the response to streaming mode, which sends the status, header and current
+// buffer content to client immediately, and then every content written to the response is sent
+// to client in time without buffering until the handler returns.
+//
+// The handler and middleware are executed as usual in streaming mode, so the access logging and
+// tracing work for the whole lifetime of the stream. Use Request.Context().Done() to detect the
+// disconnection of client. Note that the header and cookies set after Stream are not sent.
+func (r *Response) Stream() {
+	if r.streaming || r.hijacked {
+		return
+	}
+	r.streaming = true
+	if r.Status == 0 {
+		r.Status = http.StatusOK
+	}
+	// The content length is unknown for streaming.
+	r.Header().Del("Content-Length")
+	// The cookies are sent along with the header.
+	r.Request.Cookie.Flush()
+	r.Flush()
+}
+
+// IsStreaming checks and returns whether the response is in streaming mode.
+func (r *Response) IsStreaming() bool {
+	return r.streaming
+}
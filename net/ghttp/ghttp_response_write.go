@@ -34,6 +34,9 @@ func (r *Response) Write(content ...interface{}) {
 			r.buffer.WriteString(gconv.String(v))
 		}
 	}
+	if r.streaming {
+		r.Flush()
+	}
 }
 
 // WriteExit writes <content> to the response buffer and exits executing of current handler.
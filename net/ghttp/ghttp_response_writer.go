@@ -21,6 +21,8 @@ type ResponseWriter struct {
 	buffer      *bytes.Buffer       // The output buffer.
 	hijacked    bool                // Mark this request is hijacked or not.
 	wroteHeader bool                // Is header wrote or not, avoiding error: superfluous/multiple response.WriteHeader call.
+	streaming   bool                // Mark this response is in streaming mode or not, which flushes the content in time.
+	streamed    int64               // Length of the content sent to client in streaming mode.
 }
 
 // RawWriter returns the underlying ResponseWriter.
@@ -34,8 +36,12 @@ func (w *ResponseWriter) Header() http.Header {
 }
 
 // Write implements the interface function of http.ResponseWriter.Write.
+// The content is sent to client in time in streaming mode.
 func (w *ResponseWriter) Write(data []byte) (int, error) {
 	w.buffer.Write(data)
+	if w.streaming {
+		w.Flush()
+	}
 	return len(data), nil
 }
 
@@ -50,8 +56,21 @@ func (w *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return w.writer.(http.Hijacker).Hijack()
 }
 
-// OutputBuffer outputs the buffer to client and clears the buffer.
+// Flush outputs the buffer to client and clears the buffer.
+// It also flushes the underlying writer if it implements http.Flusher, which sends the
+// content to client immediately, like streaming.
 func (w *ResponseWriter) Flush() {
+	w.flush()
+	if w.hijacked {
+		return
+	}
+	if f, ok := w.writer.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// flush outputs the buffer to the underlying writer and clears the buffer.
+func (w *ResponseWriter) flush() {
 	if w.hijacked {
 		return
 	}
@@ -60,11 +79,44 @@ func (w *ResponseWriter) Flush() {
 		w.writer.WriteHeader(w.Status)
 	}
 	// Default status text output.
-	if w.Status != http.StatusOK && w.buffer.Len() == 0 {
+	if w.Status != http.StatusOK && w.buffer.Len() == 0 && !w.streaming {
 		w.buffer.WriteString(http.StatusText(w.Status))
 	}
 	if w.buffer.Len() > 0 {
-		w.writer.Write(w.buffer.Bytes())
+		n, _ := w.writer.Write(w.buffer.Bytes())
+		if w.streaming {
+			w.streamed += int64(n)
+		}
 		w.buffer.Reset()
 	}
 }
+
+// finish outputs the remaining buffer to client after all handlers are done,
+// and closes the compression of the response if it's enabled.
+func (w *ResponseWriter) finish() {
+	if c, ok := w.writer.(*compressWriter); ok {
+		if !c.wroteHeader {
+			// The whole content is in buffer.
+			c.length = w.buffer.Len()
+			if c.length == 0 && w.Status != http.StatusOK && !w.streaming {
+				c.length = len(http.StatusText(w.Status))
+			}
+		}
+		w.flush()
+		_ = c.Close()
+		return
+	}
+	w.flush()
+}
+
+// enableCompression enables compressing the response content for request <r>.
+// It does nothing if the compression is already enabled, or the header is already written.
+func (w *ResponseWriter) enableCompression(r *Request) {
+	if w.wroteHeader || w.hijacked {
+		return
+	}
+	if _, ok := w.writer.(*compressWriter); ok {
+		return
+	}
+	w.writer = newCompressWriter(w.writer, r.Request, &r.Server.config)
+}
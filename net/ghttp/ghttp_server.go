@@ -157,6 +157,9 @@ func (s *Server) Start() error {
 		s.EnablePProf(s.config.PProfPattern)
 	}
 
+	// OpenAPI document and its UI page.
+	s.bindOpenApiHandlers()
+
 	// Default HTTP handler.
 	if s.config.Handler == nil {
 		s.config.Handler = s
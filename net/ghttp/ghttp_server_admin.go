@@ -34,6 +34,7 @@ func (p *utilAdmin) Index(r *Request) {
             <body>
                 <p>Pid: {{.pid}}</p>
                 <p>File Path: {{.path}}</p>
+                <p><a href="{{$.uri}}/loggers">Loggers</a></p>
                 <p><a href="{{$.uri}}/restart">Restart</a></p>
                 <p><a href="{{$.uri}}/shutdown">Shutdown</a></p>
             </body>
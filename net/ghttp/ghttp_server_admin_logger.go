@@ -0,0 +1,54 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+
+	"github.com/gogf/gf/os/glog"
+)
+
+// Loggers lists the logger instances with their effective levels and the level overrides,
+// or changes the level of loggers at runtime without restart.
+//
+// The parameter <name> is the logger name or name pattern, eg: db.query, db.*, and the parameter
+// <level> is the level string, eg: DEBUG, INFO, ERROR. The level override of <name> is set if
+// <level> is a valid level string, or it is removed if <level> is "RESET".
+func (p *utilAdmin) Loggers(r *Request) {
+	var (
+		name  = r.GetString("name")
+		level = r.GetString("level")
+	)
+	if name != "" && level != "" {
+		if r.Method == http.MethodGet {
+			r.Response.WriteStatusExit(http.StatusMethodNotAllowed, "changing level requires POST or PUT method")
+		}
+		if level == "RESET" || level == "reset" {
+			glog.RemoveLevelOverride(name)
+		} else if err := glog.SetLevelOverrideStr(name, level); err != nil {
+			r.Response.WriteStatusExit(http.StatusBadRequest, err.Error())
+		}
+	}
+	var (
+		loggers   = make([]map[string]interface{}, 0)
+		overrides = make(map[string]string)
+	)
+	for _, name := range glog.Names() {
+		logger := glog.Instance(name)
+		loggers = append(loggers, map[string]interface{}{
+			"name":  name,
+			"level": glog.LevelToString(logger.GetEffectiveLevel()),
+		})
+	}
+	for pattern, level := range glog.GetLevelOverrides() {
+		overrides[pattern] = glog.LevelToString(level)
+	}
+	r.Response.WriteJsonExit(map[string]interface{}{
+		"loggers":   loggers,
+		"overrides": overrides,
+	})
+}
@@ -191,6 +191,30 @@ type ServerConfig struct {
 	PProfEnabled bool   `json:"pprofEnabled"` // PProfEnabled enables PProf feature.
 	PProfPattern string `json:"pprofPattern"` // PProfPattern specifies the PProf service pattern for router.
 
+	// ==================================
+	// API & Swagger.
+	// ==================================
+	OpenApiPath    string `json:"openapiPath"`    // OpenApiPath specifies the path of OpenAPI specification document, like: /api.json.
+	OpenApiTitle   string `json:"openapiTitle"`   // OpenApiTitle specifies the title of the OpenAPI document.
+	OpenApiVersion string `json:"openapiVersion"` // OpenApiVersion specifies the API version of the OpenAPI document.
+	SwaggerPath    string `json:"swaggerPath"`    // SwaggerPath specifies the path of API document UI page, like: /swagger.
+	SwaggerUI      string `json:"swaggerUI"`      // SwaggerUI specifies the API document UI: "swagger" or "redoc", it's "swagger" in default.
+
+	// SwaggerAssetUrl specifies the base URL of the UI assets, which is the public CDN in default.
+	// It can be a local path served by the server for offline usage, like: /swagger-ui, which serves
+	// the files "swagger-ui.css" and "swagger-ui-bundle.js" of package swagger-ui-dist,
+	// or the file "redoc.standalone.js" of package redoc.
+	SwaggerAssetUrl string `json:"swaggerAssetUrl"`
+
+	// ==================================
+	// Compression.
+	// ==================================
+	CompressEnabled       bool     `json:"compressEnabled"`       // CompressEnabled enables response compression for all requests, including static files.
+	CompressLevel         int      `json:"compressLevel"`         // CompressLevel specifies the compression level, which is -1 for the default level of the encoding.
+	CompressMinSize       int      `json:"compressMinSize"`       // CompressMinSize specifies the minimum content size in bytes to be compressed, which is 1KB in default.
+	CompressMimeTypes     []string `json:"compressMimeTypes"`     // CompressMimeTypes specifies the content types to be compressed, and the type ending with '*' matches by prefix, like: text/*.
+	CompressPrecompressed bool     `json:"compressPrecompressed"` // CompressPrecompressed enables serving precompressed static file if exists, like: index.js.gz for index.js.
+
 	// ==================================
 	// Other.
 	// ==================================
@@ -264,6 +288,9 @@ func NewConfig() ServerConfig {
 		AccessLogEnabled:    false,
 		AccessLogPattern:    "access-{Ymd}.log",
 		DumpRouterMap:       true,
+		CompressLevel:       -1,
+		CompressMinSize:     1024, // 1KB
+		CompressMimeTypes:   append([]string{}, defaultCompressMimeTypes...),
 		ClientMaxBodySize:   8 * 1024 * 1024, // 8MB
 		FormParsingMemory:   1024 * 1024,     // 1MB
 		Rewrites:            make(map[string]string),
@@ -299,6 +326,9 @@ func (s *Server) SetConfigWithMap(m map[string]interface{}) error {
 	if k, v := gutil.MapPossibleItemByKey(m, "FormParsingMemory"); k != "" {
 		m[k] = gfile.StrToSize(gconv.String(v))
 	}
+	if k, v := gutil.MapPossibleItemByKey(m, "CompressMinSize"); k != "" {
+		m[k] = gfile.StrToSize(gconv.String(v))
+	}
 	// Update the current configuration object.
 	// It only updates the configured keys not all the object.
 	if err := gconv.Struct(m, &s.config); err != nil {
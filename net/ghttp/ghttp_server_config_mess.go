@@ -26,3 +26,44 @@ func (s *Server) SetClientMaxBodySize(maxSize int64) {
 func (s *Server) SetFormParsingMemory(maxMemory int64) {
 	s.config.FormParsingMemory = maxMemory
 }
+
+// SetOpenApiPath sets the OpenApiPath for server, which serves the OpenAPI document.
+func (s *Server) SetOpenApiPath(path string) {
+	s.config.OpenApiPath = path
+}
+
+// SetSwaggerPath sets the SwaggerPath for server, which serves the API document UI page.
+func (s *Server) SetSwaggerPath(path string) {
+	s.config.SwaggerPath = path
+}
+
+// SetSwaggerAssetUrl sets the SwaggerAssetUrl for server, which is the base URL of the UI assets.
+func (s *Server) SetSwaggerAssetUrl(url string) {
+	s.config.SwaggerAssetUrl = url
+}
+
+// SetCompressEnabled sets the CompressEnabled for server, which enables response compression
+// for all requests, including static files.
+func (s *Server) SetCompressEnabled(enabled bool) {
+	s.config.CompressEnabled = enabled
+}
+
+// SetCompressLevel sets the CompressLevel for server.
+func (s *Server) SetCompressLevel(level int) {
+	s.config.CompressLevel = level
+}
+
+// SetCompressMinSize sets the CompressMinSize for server.
+func (s *Server) SetCompressMinSize(size int) {
+	s.config.CompressMinSize = size
+}
+
+// SetCompressMimeTypes sets the CompressMimeTypes for server.
+func (s *Server) SetCompressMimeTypes(mimeTypes []string) {
+	s.config.CompressMimeTypes = mimeTypes
+}
+
+// SetCompressPrecompressed sets the CompressPrecompressed for server.
+func (s *Server) SetCompressPrecompressed(enabled bool) {
+	s.config.CompressPrecompressed = enabled
+}
@@ -56,6 +56,9 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Create a new request object.
 	request := newRequest(s, r, w)
+	if s.config.CompressEnabled {
+		request.Response.Writer.enableCompression(request)
+	}
 
 	defer func() {
 		request.LeaveTime = gtime.TimestampMilli()
@@ -170,15 +173,19 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Automatically set the session id to cookie
 	// if it creates a new session id in this request
 	// and SessionCookieOutput is enabled.
-	if s.config.SessionCookieOutput &&
-		request.Session.IsDirty() &&
-		request.Session.Id() != request.GetSessionId() {
-		request.Cookie.SetSessionId(request.Session.Id())
+	// The session id is re-encoded before that if the session data is stored in it,
+	// like gsession.StorageCookie.
+	if s.config.SessionCookieOutput && request.Session.IsDirty() {
+		if err := request.Session.Encode(); err != nil {
+			s.handleErrorLog(gerror.Wrap(err, "session encoding failed"), request)
+		} else if request.Session.Id() != request.GetSessionId() {
+			request.Cookie.SetSessionId(request.Session.Id())
+		}
 	}
 	// Output the cookie content to client.
 	request.Cookie.Flush()
 	// Output the buffer content to client.
-	request.Response.Flush()
+	request.Response.finish()
 	// HOOK - AfterOutput
 	if !request.IsExited() {
 		s.callHookHandler(HookAfterOutput, request)
@@ -286,6 +293,12 @@ func (s *Server) serveFile(r *Request, f *staticFile, allowIndex ...bool) {
 		}
 	} else {
 		r.Response.wroteHeader = true
+		// Precompressed file like: index.js.gz.
+		if c, ok := r.Response.Writer.writer.(*compressWriter); ok && s.config.CompressPrecompressed {
+			if c.serveFile(f.Path) {
+				return
+			}
+		}
 		http.ServeContent(r.Response.Writer.RawWriter(), r.Request, info.Name(), info.ModTime(), file)
 	}
 }
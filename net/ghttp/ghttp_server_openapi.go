@@ -0,0 +1,216 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/gogf/gf/debug/gdebug"
+	"github.com/gogf/gf/net/goai"
+	"github.com/gogf/gf/text/gregex"
+	"github.com/gogf/gf/util/gmeta"
+)
+
+// ApiHandler is the handler with its request and response struct declared, which are used
+// for generating the OpenAPI document of the route. The request struct is described by
+// struct tags like "p", "in", "v", "dc", and its embedded gmeta.Meta, see package goai.
+//
+// It can be registered by Server.BindApiHandler, or by RouterGroup like other handlers, eg:
+// group.POST("/user", ghttp.ApiHandler{Handler: h, Request: UserCreateReq{}, Response: UserCreateRes{}})
+type ApiHandler struct {
+	Handler  HandlerFunc // Handler function, which parses the Request struct and writes the Response struct.
+	Request  interface{} // Request struct or its pointer.
+	Response interface{} // Response struct or its pointer.
+}
+
+const (
+	defaultOpenApiPath = "/api.json"
+	swaggerUIRedoc     = "redoc"
+
+	// Default base URLs of the UI assets, which can be changed by configuration SwaggerAssetUrl.
+	defaultSwaggerAssetUrl = "https://unpkg.com/swagger-ui-dist@3"
+	defaultRedocAssetUrl   = "https://cdn.jsdelivr.net/npm/redoc@2.0.0-rc.54/bundles"
+
+	swaggerUITemplate = `<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>{Title}</title>
+    <link rel="stylesheet" href="{AssetUrl}/swagger-ui.css">
+</head>
+<body>
+    <div id="swagger-ui"></div>
+    <script src="{AssetUrl}/swagger-ui-bundle.js"></script>
+    <script>
+        window.onload = function () {
+            SwaggerUIBundle({url: "{OpenApiPath}", dom_id: "#swagger-ui"});
+        };
+    </script>
+</body>
+</html>`
+	redocUITemplate = `<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>{Title}</title>
+</head>
+<body>
+    <redoc spec-url="{OpenApiPath}"></redoc>
+    <script src="{AssetUrl}/redoc.standalone.js"></script>
+</body>
+</html>`
+)
+
+// BindApiHandler registers a handler with its API definition to server with given pattern.
+func (s *Server) BindApiHandler(pattern string, handler ApiHandler) {
+	s.doBindApiHandler(pattern, handler, nil, "")
+}
+
+// doBindApiHandler registers a handler with its API definition to server with given pattern.
+func (s *Server) doBindApiHandler(
+	pattern string, handler ApiHandler,
+	middleware []HandlerFunc, source string,
+) {
+	s.setHandler(pattern, &handlerItem{
+		itemName:   gdebug.FuncPath(handler.Handler),
+		itemType:   handlerTypeHandler,
+		itemFunc:   handler.Handler,
+		middleware: middleware,
+		source:     source,
+		api: &handlerApi{
+			request:  handler.Request,
+			response: handler.Response,
+		},
+	})
+}
+
+// BindApiHandler registers a handler with its API definition to server of specified domain.
+func (d *Domain) BindApiHandler(pattern string, handler ApiHandler) {
+	for domain, _ := range d.domains {
+		d.server.BindApiHandler(pattern+"@"+domain, handler)
+	}
+}
+
+func (d *Domain) doBindApiHandler(
+	pattern string, handler ApiHandler,
+	middleware []HandlerFunc, source string,
+) {
+	for domain, _ := range d.domains {
+		d.server.doBindApiHandler(pattern+"@"+domain, handler, middleware, source)
+	}
+}
+
+// GetOpenApi generates and returns the OpenAPI document from the routes registered with
+// API definition, see ApiHandler.
+//
+// The route of method "ALL" is documented with the method in meta data "method" of the
+// request struct, or else method POST.
+func (s *Server) GetOpenApi() (*goai.OpenApiV3, error) {
+	oai := goai.New()
+	oai.Info.Title = s.config.OpenApiTitle
+	if oai.Info.Title == "" {
+		oai.Info.Title = s.name
+	}
+	if s.config.OpenApiVersion != "" {
+		oai.Info.Version = s.config.OpenApiVersion
+	}
+	for _, item := range s.GetRouterArray() {
+		if item.handler.api == nil || item.handler.itemType != handlerTypeHandler {
+			continue
+		}
+		var (
+			api     = item.handler.api
+			methods = []string{item.Method}
+		)
+		if item.Method == defaultMethod {
+			methods = []string{http.MethodPost}
+			if api.request != nil {
+				if method := gmeta.Get(api.request, "method").String(); method != "" {
+					methods = strings.Split(strings.ToUpper(method), ",")
+				}
+			}
+		}
+		for _, method := range methods {
+			err := oai.Add(goai.AddInput{
+				Path:     routeToOpenApiPath(item.Route),
+				Method:   strings.TrimSpace(method),
+				Request:  api.request,
+				Response: api.response,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return oai, nil
+}
+
+// openapiSpecHandler serves the OpenAPI document.
+func (s *Server) openapiSpecHandler(r *Request) {
+	oai, err := s.GetOpenApi()
+	if err != nil {
+		s.handleErrorLog(err, r)
+		r.Response.WriteStatus(http.StatusInternalServerError)
+		return
+	}
+	r.Response.WriteJson(oai)
+}
+
+// openapiUIHandler serves the API document UI page.
+func (s *Server) openapiUIHandler(r *Request) {
+	var (
+		template = swaggerUITemplate
+		assetUrl = defaultSwaggerAssetUrl
+	)
+	if strings.EqualFold(s.config.SwaggerUI, swaggerUIRedoc) {
+		template = redocUITemplate
+		assetUrl = defaultRedocAssetUrl
+	}
+	if s.config.SwaggerAssetUrl != "" {
+		assetUrl = strings.TrimRight(s.config.SwaggerAssetUrl, "/")
+	}
+	title := s.config.OpenApiTitle
+	if title == "" {
+		title = s.name
+	}
+	r.Response.Header().Set("Content-Type", "text/html; charset=utf-8")
+	r.Response.Write(strings.NewReplacer(
+		"{Title}", html.EscapeString(title),
+		"{OpenApiPath}", html.EscapeString(s.openapiPath()),
+		"{AssetUrl}", html.EscapeString(assetUrl),
+	).Replace(template))
+}
+
+// bindOpenApiHandlers registers the handlers of OpenAPI document and its UI page if configured.
+func (s *Server) bindOpenApiHandlers() {
+	if s.config.SwaggerPath != "" {
+		s.BindHandler("GET:"+s.config.SwaggerPath, s.openapiUIHandler)
+	}
+	if s.config.OpenApiPath != "" || s.config.SwaggerPath != "" {
+		s.BindHandler("GET:"+s.openapiPath(), s.openapiSpecHandler)
+	}
+}
+
+// openapiPath returns the path of OpenAPI document, which is "/api.json" in default if
+// only the UI page is enabled.
+func (s *Server) openapiPath() string {
+	if s.config.OpenApiPath != "" {
+		return s.config.OpenApiPath
+	}
+	return defaultOpenApiPath
+}
+
+// routeToOpenApiPath converts the route to OpenAPI path, eg:
+// /user/:id       => /user/{id}
+// /user/{id}.html => /user/{id}.html
+// /file/*path     => /file/{path}
+func routeToOpenApiPath(route string) string {
+	path, _ := gregex.ReplaceString(`/[:\*]([\w\.\-]+)`, `/{${1}}`, route)
+	return path
+}
@@ -315,6 +315,12 @@ func (g *RouterGroup) doBindRoutersToServer(item *preBindItem) *RouterGroup {
 			} else {
 				g.domain.doBindHandler(pattern, h, g.middleware, source)
 			}
+		} else if h, ok := g.toApiHandler(object); ok {
+			if g.server != nil {
+				g.server.doBindApiHandler(pattern, h, g.middleware, source)
+			} else {
+				g.domain.doBindApiHandler(pattern, h, g.middleware, source)
+			}
 		} else if g.isController(object) {
 			if len(extras) > 0 {
 				if g.server != nil {
@@ -434,3 +440,28 @@ func (g *RouterGroup) isController(value interface{}) bool {
 	}
 	return false
 }
+
+// toApiHandler checks and converts given <value> to ApiHandler.
+// The <value> can be ApiHandler/*ApiHandler, or typed handler function like:
+// func(context.Context, *XxxReq) (*XxxRes, error).
+func (g *RouterGroup) toApiHandler(value interface{}) (ApiHandler, bool) {
+	switch v := value.(type) {
+	case ApiHandler:
+		return v, true
+	case *ApiHandler:
+		return *v, true
+	}
+	if reflect.TypeOf(value).Kind() != reflect.Func {
+		return ApiHandler{}, false
+	}
+	h, err := NewApiHandler(value)
+	if err != nil {
+		server := g.server
+		if server == nil {
+			server = g.domain.server
+		}
+		server.Logger().Fatal(err.Error())
+		return ApiHandler{}, false
+	}
+	return h, true
+}
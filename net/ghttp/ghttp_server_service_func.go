@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/gogf/gf/errors/gerror"
+)
+
+var (
+	// contextType is the reflect type of context.Context.
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+	// errorType is the reflect type of error.
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// NewApiHandler creates and returns an ApiHandler from typed handler function <f>, which is like:
+// func(ctx context.Context, req *XxxReq) (res *XxxRes, err error).
+//
+// The returned handler parses the request parameters from query, form, body and router into a new
+// request struct using Request.Parse, which also validates the struct using package gvalid, calls
+// <f> with the request context and the request struct, and then stores the returned response and
+// error, which can be retrieved by Request.GetHandlerResponse. The parsing error is of code
+// ResponseCodeValidationFailed.
+//
+// The response is written by response handler middleware, like MiddlewareHandlerResponse,
+// and the Request object can be retrieved from <ctx> using RequestFromCtx in <f>.
+func NewApiHandler(f interface{}) (ApiHandler, error) {
+	var (
+		funcValue = reflect.ValueOf(f)
+		funcType  = funcValue.Type()
+	)
+	if !isTypedHandler(funcType) {
+		return ApiHandler{}, gerror.Newf(
+			`invalid handler type %s, it should be like: func(context.Context, *XxxReq) (*XxxRes, error)`,
+			funcType.String(),
+		)
+	}
+	var (
+		reqType = funcType.In(1).Elem()
+		resType = funcType.Out(0)
+	)
+	handler := func(r *Request) {
+		req := reflect.New(reqType)
+		if err := r.Parse(req.Interface()); err != nil {
+			r.SetError(gerror.WrapCode(ResponseCodeValidationFailed, err, ""))
+			return
+		}
+		ctx := context.WithValue(r.Context(), ctxKeyForRequest{}, r)
+		results := funcValue.Call([]reflect.Value{reflect.ValueOf(ctx), req})
+		if res := results[0]; !isNilValue(res) {
+			r.handlerResponse = res.Interface()
+		}
+		if err := results[1]; !err.IsNil() {
+			r.SetError(err.Interface().(error))
+		}
+	}
+	return ApiHandler{
+		Handler:  handler,
+		Request:  reflect.New(reqType).Interface(),
+		Response: reflect.New(indirectType(resType)).Interface(),
+	}, nil
+}
+
+// isTypedHandler checks whether <t> is type of typed handler function like:
+// func(context.Context, *XxxReq) (*XxxRes, error).
+func isTypedHandler(t reflect.Type) bool {
+	return t.Kind() == reflect.Func &&
+		t.NumIn() == 2 && t.NumOut() == 2 &&
+		t.In(0) == contextType &&
+		t.In(1).Kind() == reflect.Ptr && t.In(1).Elem().Kind() == reflect.Struct &&
+		t.Out(1) == errorType
+}
+
+// isNilValue checks whether <v> is nil value of nillable kinds.
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	}
+	return false
+}
+
+// indirectType returns the type that <t> points to, if <t> is a pointer type.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
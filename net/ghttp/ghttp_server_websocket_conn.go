@@ -0,0 +1,210 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketConn is a websocket connection managed by WebSocketHub.
+type WebSocketConn struct {
+	id        string              // Unique id of the connection.
+	hub       *WebSocketHub       // Belonged hub.
+	ws        *WebSocket          // Underlying websocket connection.
+	request   *Request            // According request upgraded as websocket.
+	rooms     map[string]struct{} // Joined rooms, which is guarded by the mutex of hub.
+	queue     chan webSocketFrame // Send queue of the connection.
+	done      chan struct{}       // Closed when the connection is closed.
+	closeOnce sync.Once           // Closing the connection only once.
+	closeCode int                 // Close code sent to client.
+	closeText string              // Close reason sent to client.
+}
+
+// webSocketFrame is a message in the send queue.
+type webSocketFrame struct {
+	messageType int
+	data        []byte
+}
+
+// Id returns the unique id of the connection.
+func (c *WebSocketConn) Id() string {
+	return c.id
+}
+
+// Request returns the request upgraded as the websocket connection, which can be used
+// retrieving the request information, like the authenticated user in context.
+func (c *WebSocketConn) Request() *Request {
+	return c.request
+}
+
+// Join adds the connection to <room>.
+func (c *WebSocketConn) Join(room string) {
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	if _, ok := c.hub.conns[c.id]; !ok {
+		return
+	}
+	c.rooms[room] = struct{}{}
+	if _, ok := c.hub.rooms[room]; !ok {
+		c.hub.rooms[room] = make(map[string]*WebSocketConn)
+	}
+	c.hub.rooms[room][c.id] = c
+}
+
+// Leave removes the connection from <room>.
+func (c *WebSocketConn) Leave(room string) {
+	c.hub.mu.Lock()
+	defer c.hub.mu.Unlock()
+	c.hub.leave(c, room)
+}
+
+// Rooms returns the rooms the connection joined.
+func (c *WebSocketConn) Rooms() []string {
+	c.hub.mu.RLock()
+	defer c.hub.mu.RUnlock()
+	rooms := make([]string, 0, len(c.rooms))
+	for room := range c.rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// Send sends message of <msgType> and <data> in JSON format to client, like:
+// {"type": "chat", "data": {...}}.
+//
+// The message is put to the send queue of the connection. If the queue is full, it waits
+// at most WebSocketHubConfig.SendTimeout, and returns error if the queue is still full.
+func (c *WebSocketConn) Send(msgType string, data interface{}) error {
+	message, err := encodeWebSocketMessage(msgType, data)
+	if err != nil {
+		return err
+	}
+	return c.enqueue(webSocketFrame{messageType: websocket.TextMessage, data: message}, c.hub.config.SendTimeout)
+}
+
+// SendRaw sends <data> of websocket <messageType> as it is to client, like: WS_MSG_BINARY.
+// See Send.
+func (c *WebSocketConn) SendRaw(messageType int, data []byte) error {
+	return c.enqueue(webSocketFrame{messageType: messageType, data: data}, c.hub.config.SendTimeout)
+}
+
+// Close closes the connection with normal closure.
+func (c *WebSocketConn) Close() {
+	c.CloseWithReason(websocket.CloseNormalClosure, "")
+}
+
+// CloseWithReason closes the connection with close <code> and <reason> sent to client.
+func (c *WebSocketConn) CloseWithReason(code int, reason string) {
+	c.closeOnce.Do(func() {
+		c.closeCode, c.closeText = code, reason
+		close(c.done)
+	})
+}
+
+// IsClosed checks and returns whether the connection is closed.
+func (c *WebSocketConn) IsClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// enqueue puts <frame> to the send queue, which waits at most <timeout> if the queue is full.
+func (c *WebSocketConn) enqueue(frame webSocketFrame, timeout time.Duration) error {
+	if c.IsClosed() {
+		return gerror.New(`websocket connection is closed`)
+	}
+	select {
+	case c.queue <- frame:
+		return nil
+	default:
+	}
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case c.queue <- frame:
+			return nil
+		case <-c.done:
+			return gerror.New(`websocket connection is closed`)
+		case <-timer.C:
+		}
+	}
+	return gerror.New(`websocket send queue is full`)
+}
+
+// readLoop reads and dispatches the messages from client until the connection is broken,
+// or there's nothing received from client in IdleTimeout.
+func (c *WebSocketConn) readLoop() {
+	var (
+		config   = c.hub.config
+		deadline = func() time.Time {
+			return time.Now().Add(config.IdleTimeout)
+		}
+	)
+	if config.MaxMessageSize > 0 {
+		c.ws.SetReadLimit(config.MaxMessageSize)
+	}
+	_ = c.ws.SetReadDeadline(deadline())
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(deadline())
+	})
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = c.ws.SetReadDeadline(deadline())
+		c.hub.dispatch(c, decodeWebSocketMessage(data))
+	}
+}
+
+// writeLoop writes the messages in send queue and ping messages to client until the connection
+// is closed, and then closes the underlying connection, which also breaks the readLoop.
+func (c *WebSocketConn) writeLoop() {
+	var (
+		config   = c.hub.config
+		ticker   = time.NewTicker(config.PingInterval)
+		deadline = func() time.Time {
+			return time.Now().Add(config.WriteTimeout)
+		}
+	)
+	defer func() {
+		ticker.Stop()
+		_ = c.ws.Close()
+	}()
+	for {
+		select {
+		case frame := <-c.queue:
+			_ = c.ws.SetWriteDeadline(deadline())
+			if err := c.ws.WriteMessage(frame.messageType, frame.data); err != nil {
+				c.Close()
+				return
+			}
+
+		case <-ticker.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, deadline()); err != nil {
+				c.Close()
+				return
+			}
+
+		case <-c.done:
+			_ = c.ws.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(c.closeCode, c.closeText),
+				deadline(),
+			)
+			return
+		}
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/encoding/gjson"
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/internal/json"
+	"github.com/gogf/gf/util/guid"
+	"github.com/gorilla/websocket"
+)
+
+// WebSocketHub manages the websocket connections, which provides rooms, broadcast, send queues
+// with backpressure, automatic ping/pong with idle timeout and message handlers by type.
+//
+// The messages in JSON format like {"type": "chat", "data": {...}} are dispatched to the handlers
+// registered by Handle using their types, and the others are dispatched to the handler of empty type.
+//
+// The broadcasts are delivered to the connections of hubs on other nodes through the Broker
+// in configuration, like the redis broker created by gredis.Redis.Broker.
+type WebSocketHub struct {
+	mu           sync.RWMutex                         // Mutex for connections and rooms.
+	config       WebSocketHubConfig                   // Hub configuration.
+	nodeId       string                               // Unique id of current hub for broker.
+	conns        map[string]*WebSocketConn            // Connections by id.
+	rooms        map[string]map[string]*WebSocketConn // Connections of rooms.
+	handlers     map[string]WebSocketHandlerFunc      // Message handlers by type.
+	onConnect    func(conn *WebSocketConn)            // Callback after connection is registered.
+	onDisconnect func(conn *WebSocketConn)            // Callback after connection is unregistered.
+	cancel       context.CancelFunc                   // Cancel function for broker subscription.
+	closed       bool                                 // Mark this hub is closed or not.
+}
+
+// WebSocketHubConfig is the configuration for WebSocketHub.
+type WebSocketHubConfig struct {
+	SendQueueSize  int             // SendQueueSize specifies the size of send queue of each connection, which is 256 in default.
+	SendTimeout    time.Duration   // SendTimeout specifies the max waiting time of Send if the queue is full, which does not wait in default.
+	WriteTimeout   time.Duration   // WriteTimeout specifies the timeout for writing a message to client, which is 10 seconds in default.
+	PingInterval   time.Duration   // PingInterval specifies the interval of ping messages, which is 30 seconds in default.
+	IdleTimeout    time.Duration   // IdleTimeout specifies the timeout closing the connection receiving nothing, including pong, which is 60 seconds in default.
+	MaxMessageSize int64           // MaxMessageSize specifies the max size in bytes of message from client, which is no limit in default.
+	Broker         WebSocketBroker // Broker delivers the broadcasts to hubs on other nodes, which is optional.
+}
+
+// WebSocketHandlerFunc is the handler for messages of a type.
+type WebSocketHandlerFunc func(conn *WebSocketConn, msg *WebSocketMessage)
+
+// WebSocketMessage is the message received from client.
+type WebSocketMessage struct {
+	Type string      // Message type, which is empty if the message is not in JSON format like {"type": "", "data": {...}}.
+	Data *gjson.Json // Data of the message in JSON format, which is nil if the message is not in JSON format.
+	Raw  []byte      // Raw content of the message.
+}
+
+// WebSocketBroker delivers the broadcast payloads between hubs on different nodes, which is
+// commonly implemented using Pub/Sub of message queue, like: redis.
+type WebSocketBroker interface {
+	// Publish publishes <payload> to all subscribers, including the publisher itself.
+	Publish(ctx context.Context, payload []byte) error
+
+	// Subscribe receives the payloads and calls <handler> with them, which blocks until <ctx> is done.
+	Subscribe(ctx context.Context, handler func(payload []byte)) error
+}
+
+// webSocketEnvelope is the JSON envelope for messages sent to client.
+type webSocketEnvelope struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// webSocketBrokerPayload is the payload of broadcast delivered by broker.
+type webSocketBrokerPayload struct {
+	Node    string `json:"node"`    // Id of the hub publishing the payload.
+	Room    string `json:"room"`    // Room of the broadcast, which is empty for all connections.
+	Message []byte `json:"message"` // Message content.
+}
+
+const (
+	defaultWebSocketSendQueueSize = 256
+	defaultWebSocketWriteTimeout  = 10 * time.Second
+	defaultWebSocketPingInterval  = 30 * time.Second
+	defaultWebSocketIdleTimeout   = 60 * time.Second
+	webSocketBrokerRetryInterval  = time.Second
+)
+
+// NewWebSocketHub creates and returns a new websocket hub with optional configuration <config>.
+// The hub serves websocket connections by binding Serve as the handler, like:
+// s.BindHandler("/ws", hub.Serve).
+func NewWebSocketHub(config ...WebSocketHubConfig) *WebSocketHub {
+	h := &WebSocketHub{
+		nodeId:   guid.S(),
+		conns:    make(map[string]*WebSocketConn),
+		rooms:    make(map[string]map[string]*WebSocketConn),
+		handlers: make(map[string]WebSocketHandlerFunc),
+	}
+	if len(config) > 0 {
+		h.config = config[0]
+	}
+	if h.config.SendQueueSize <= 0 {
+		h.config.SendQueueSize = defaultWebSocketSendQueueSize
+	}
+	if h.config.WriteTimeout <= 0 {
+		h.config.WriteTimeout = defaultWebSocketWriteTimeout
+	}
+	if h.config.PingInterval <= 0 {
+		h.config.PingInterval = defaultWebSocketPingInterval
+	}
+	if h.config.IdleTimeout <= 0 {
+		h.config.IdleTimeout = defaultWebSocketIdleTimeout
+	}
+	if h.config.Broker != nil {
+		var ctx context.Context
+		ctx, h.cancel = context.WithCancel(context.Background())
+		go h.subscribe(ctx)
+	}
+	return h
+}
+
+// Handle registers <handler> for messages of type <msgType>.
+// The empty <msgType> is for messages not in JSON format and messages of unregistered types.
+func (h *WebSocketHub) Handle(msgType string, handler WebSocketHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[msgType] = handler
+}
+
+// OnConnect registers callback <f>, which is called after a connection is registered.
+func (h *WebSocketHub) OnConnect(f func(conn *WebSocketConn)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onConnect = f
+}
+
+// OnDisconnect registers callback <f>, which is called after a connection is unregistered.
+func (h *WebSocketHub) OnDisconnect(f func(conn *WebSocketConn)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onDisconnect = f
+}
+
+// Serve upgrades the request as websocket connection, and serves it until it's closed.
+// It is used as the handler of the websocket route.
+func (h *WebSocketHub) Serve(r *Request) {
+	ws, err := r.WebSocket()
+	if err != nil {
+		r.Server.handleErrorLog(gerror.Wrap(err, "websocket upgrading failed"), r)
+		return
+	}
+	conn := &WebSocketConn{
+		id:      guid.S(),
+		hub:     h,
+		ws:      ws,
+		request: r,
+		rooms:   make(map[string]struct{}),
+		queue:   make(chan webSocketFrame, h.config.SendQueueSize),
+		done:    make(chan struct{}),
+	}
+	if !h.register(conn) {
+		_ = ws.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "hub is closed"),
+			time.Now().Add(h.config.WriteTimeout),
+		)
+		_ = ws.Close()
+		return
+	}
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		conn.writeLoop()
+	}()
+	defer func() {
+		conn.Close()
+		<-writerDone
+		h.unregister(conn)
+	}()
+	conn.readLoop()
+}
+
+// Conn retrieves and returns the connection of <id> on current node, or nil if it does not exist.
+func (h *WebSocketHub) Conn(id string) *WebSocketConn {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.conns[id]
+}
+
+// Count returns the count of connections on current node.
+func (h *WebSocketHub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.conns)
+}
+
+// RoomConns returns the connections of <room> on current node.
+func (h *WebSocketHub) RoomConns(room string) []*WebSocketConn {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	conns := make([]*WebSocketConn, 0, len(h.rooms[room]))
+	for _, conn := range h.rooms[room] {
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// SendTo sends message of <msgType> and <data> to the connection of <id> on current node.
+func (h *WebSocketHub) SendTo(id string, msgType string, data interface{}) error {
+	conn := h.Conn(id)
+	if conn == nil {
+		return gerror.Newf(`websocket connection "%s" not found`, id)
+	}
+	return conn.Send(msgType, data)
+}
+
+// Broadcast sends message of <msgType> and <data> to all connections, including the ones on
+// other nodes if broker is configured.
+func (h *WebSocketHub) Broadcast(msgType string, data interface{}) error {
+	return h.broadcast("", msgType, data)
+}
+
+// BroadcastToRoom sends message of <msgType> and <data> to all connections in <room>, including
+// the ones on other nodes if broker is configured.
+func (h *WebSocketHub) BroadcastToRoom(room string, msgType string, data interface{}) error {
+	if room == "" {
+		return gerror.New(`room should not be empty`)
+	}
+	return h.broadcast(room, msgType, data)
+}
+
+// Close closes all connections and stops the broker subscription.
+// The hub cannot serve new connections after closed.
+func (h *WebSocketHub) Close() {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	h.closed = true
+	conns := make([]*WebSocketConn, 0, len(h.conns))
+	for _, conn := range h.conns {
+		conns = append(conns, conn)
+	}
+	h.mu.Unlock()
+	if h.cancel != nil {
+		h.cancel()
+	}
+	for _, conn := range conns {
+		conn.CloseWithReason(websocket.CloseGoingAway, "hub is closed")
+	}
+}
+
+// broadcast encodes the message and delivers it to the connections of <room> on current node,
+// and publishes it to the broker.
+func (h *WebSocketHub) broadcast(room string, msgType string, data interface{}) error {
+	message, err := encodeWebSocketMessage(msgType, data)
+	if err != nil {
+		return err
+	}
+	h.deliver(room, message)
+	if h.config.Broker == nil {
+		return nil
+	}
+	payload, err := json.Marshal(webSocketBrokerPayload{
+		Node:    h.nodeId,
+		Room:    room,
+		Message: message,
+	})
+	if err != nil {
+		return err
+	}
+	return h.config.Broker.Publish(context.Background(), payload)
+}
+
+// deliver sends <message> to the connections of <room> on current node, or all connections if <room>
+// is empty. The connection whose send queue is full is closed, as it is too slow to receive messages.
+func (h *WebSocketHub) deliver(room string, message []byte) {
+	var conns []*WebSocketConn
+	if room == "" {
+		h.mu.RLock()
+		conns = make([]*WebSocketConn, 0, len(h.conns))
+		for _, conn := range h.conns {
+			conns = append(conns, conn)
+		}
+		h.mu.RUnlock()
+	} else {
+		conns = h.RoomConns(room)
+	}
+	frame := webSocketFrame{messageType: websocket.TextMessage, data: message}
+	for _, conn := range conns {
+		if err := conn.enqueue(frame, 0); err != nil && !conn.IsClosed() {
+			conn.CloseWithReason(websocket.CloseTryAgainLater, "send queue is full")
+		}
+	}
+}
+
+// subscribe receives the broadcasts of hubs on other nodes from broker until <ctx> is done.
+func (h *WebSocketHub) subscribe(ctx context.Context) {
+	for {
+		err := h.config.Broker.Subscribe(ctx, func(payload []byte) {
+			var p *webSocketBrokerPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				intlog.Error(err)
+				return
+			}
+			// It's already delivered by current node.
+			if p.Node == h.nodeId {
+				return
+			}
+			h.deliver(p.Room, p.Message)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			intlog.Error(err)
+		}
+		// Resubscribe after interval, like the connection of broker is broken.
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(webSocketBrokerRetryInterval):
+		}
+	}
+}
+
+// register adds <conn> to the hub, and returns false if the hub is closed.
+func (h *WebSocketHub) register(conn *WebSocketConn) bool {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return false
+	}
+	h.conns[conn.id] = conn
+	onConnect := h.onConnect
+	h.mu.Unlock()
+	if onConnect != nil {
+		onConnect(conn)
+	}
+	return true
+}
+
+// unregister removes <conn> and its rooms from the hub.
+func (h *WebSocketHub) unregister(conn *WebSocketConn) {
+	h.mu.Lock()
+	delete(h.conns, conn.id)
+	for room := range conn.rooms {
+		h.leave(conn, room)
+	}
+	onDisconnect := h.onDisconnect
+	h.mu.Unlock()
+	if onDisconnect != nil {
+		onDisconnect(conn)
+	}
+}
+
+// leave removes <conn> from <room>, which should be called with lock.
+func (h *WebSocketHub) leave(conn *WebSocketConn, room string) {
+	delete(conn.rooms, room)
+	if members, ok := h.rooms[room]; ok {
+		delete(members, conn.id)
+		if len(members) == 0 {
+			delete(h.rooms, room)
+		}
+	}
+}
+
+// dispatch calls the handler of <msg> type.
+func (h *WebSocketHub) dispatch(conn *WebSocketConn, msg *WebSocketMessage) {
+	h.mu.RLock()
+	handler, ok := h.handlers[msg.Type]
+	if !ok {
+		handler = h.handlers[""]
+	}
+	h.mu.RUnlock()
+	if handler != nil {
+		handler(conn, msg)
+	}
+}
+
+// encodeWebSocketMessage encodes <msgType> and <data> as JSON envelope.
+func encodeWebSocketMessage(msgType string, data interface{}) ([]byte, error) {
+	return json.Marshal(webSocketEnvelope{
+		Type: msgType,
+		Data: data,
+	})
+}
+
+// decodeWebSocketMessage decodes message content <raw> from client.
+func decodeWebSocketMessage(raw []byte) *WebSocketMessage {
+	msg := &WebSocketMessage{Raw: raw}
+	var envelope struct {
+		Type string      `json:"type"`
+		Data interface{} `json:"data"`
+	}
+	if err := json.UnmarshalUseNumber(raw, &envelope); err == nil && envelope.Type != "" {
+		msg.Type = envelope.Type
+		msg.Data = gjson.New(envelope.Data)
+	}
+	return msg
+}
@@ -0,0 +1,62 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/encoding/gjson"
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/os/glog"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func TestServer_EnableAdmin_Loggers(t *testing.T) {
+	p, _ := ports.PopRand()
+	s := g.Server(p)
+	s.EnableAdmin()
+	s.SetDumpRouterMap(false)
+	s.SetPort(p)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	logger := glog.Instance("ghttp.admin.test")
+	logger.SetLevelStr("ERROR")
+	defer glog.RemoveLevelOverride("ghttp.admin.*")
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+
+		j, err := gjson.LoadContent(client.GetContent("/debug/admin/loggers"))
+		t.Assert(err, nil)
+		t.AssertGT(len(j.GetArray("loggers")), 0)
+		t.Assert(logger.GetEffectiveLevel(), glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+
+		// Changing level requires POST method.
+		r, err := client.Get("/debug/admin/loggers?name=ghttp.admin.*&level=DEBUG")
+		t.Assert(err, nil)
+		t.Assert(r.StatusCode, 405)
+		r.Close()
+
+		j, err = gjson.LoadContent(client.PostContent("/debug/admin/loggers", "name=ghttp.admin.*&level=DEBUG"))
+		t.Assert(err, nil)
+		t.Assert(j.GetMap("overrides")["ghttp.admin.*"], "DEBUG")
+		t.Assert(logger.GetEffectiveLevel(), glog.LEVEL_ALL)
+
+		r, err = client.Post("/debug/admin/loggers", "name=ghttp.admin.*&level=INVALID")
+		t.Assert(err, nil)
+		t.Assert(r.StatusCode, 400)
+		r.Close()
+
+		client.PostContent("/debug/admin/loggers", "name=ghttp.admin.*&level=RESET")
+		t.Assert(logger.GetEffectiveLevel(), glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/net/ghttp"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/text/gstr"
+)
+
+func gunzipString(data []byte) string {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	defer reader.Close()
+	content, _ := ioutil.ReadAll(reader)
+	return string(content)
+}
+
+func Test_Compress_Middleware(t *testing.T) {
+	var (
+		p, _    = ports.PopRand()
+		s       = g.Server(p)
+		content = gstr.Repeat("hello world ", 200)
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompress)
+		group.ALL("/text", func(r *ghttp.Request) {
+			r.Response.Write(content)
+		})
+		group.ALL("/small", func(r *ghttp.Request) {
+			r.Response.Write("small")
+		})
+		group.ALL("/binary", func(r *ghttp.Request) {
+			r.Response.Header().Set("Content-Type", "image/png")
+			r.Response.Write(content)
+		})
+		group.ALL("/stream", func(r *ghttp.Request) {
+			r.Response.Header().Set("Content-Type", "text/plain")
+			r.Response.Write("chunk1")
+			r.Response.Flush()
+			r.Response.Write("chunk2")
+		})
+	})
+	s.BindHandler("/none", func(r *ghttp.Request) {
+		r.Response.Write(content)
+	})
+	s.SetPort(p)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+		client.SetHeader("Accept-Encoding", "deflate;q=0.5, gzip")
+
+		resp, err := client.Get("/text")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(resp.Header.Get("Vary"), "Accept-Encoding")
+		t.Assert(resp.Header.Get("Content-Type"), "text/plain; charset=utf-8")
+		t.Assert(gunzipString(resp.ReadAll()), content)
+		resp.Close()
+
+		resp, err = client.Get("/small")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.Header.Get("Vary"), "Accept-Encoding")
+		t.Assert(resp.ReadAllString(), "small")
+		resp.Close()
+
+		resp, err = client.Get("/binary")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.Header.Get("Vary"), "")
+		t.Assert(resp.ReadAllString(), content)
+		resp.Close()
+
+		resp, err = client.Get("/stream")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(gunzipString(resp.ReadAll()), "chunk1chunk2")
+		resp.Close()
+
+		resp, err = client.Get("/none")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), content)
+		resp.Close()
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+		client.SetHeader("Accept-Encoding", "gzip;q=0.5, deflate")
+
+		resp, err := client.Get("/text")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "deflate")
+		reader := flate.NewReader(bytes.NewReader(resp.ReadAll()))
+		data, _ := ioutil.ReadAll(reader)
+		t.Assert(string(data), content)
+		resp.Close()
+
+		client.SetHeader("Accept-Encoding", "gzip;q=0, identity")
+		resp, err = client.Get("/text")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), content)
+		resp.Close()
+	})
+}
+
+func Test_Compress_Static(t *testing.T) {
+	var (
+		p, _    = ports.PopRand()
+		s       = g.Server(p)
+		path    = fmt.Sprintf(`%s/ghttp/compress/test/%d`, gfile.TempDir(), p)
+		content = gstr.Repeat("body {color: red;}\n", 100)
+	)
+	defer gfile.Remove(path)
+	gfile.PutContents(path+"/index.css", content)
+	gfile.PutContents(path+"/app.js", "plain")
+	gfile.PutContents(path+"/app.js.gz", "precompressed")
+	s.SetServerRoot(path)
+	s.SetCompressEnabled(true)
+	s.SetCompressPrecompressed(true)
+	s.SetPort(p)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+		client.SetHeader("Accept-Encoding", "gzip")
+
+		resp, err := client.Get("/index.css")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(resp.Header.Get("Content-Type"), "text/css; charset=utf-8")
+		t.Assert(gunzipString(resp.ReadAll()), content)
+		resp.Close()
+
+		resp, err = client.Get("/app.js")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "gzip")
+		t.Assert(resp.Header.Get("Vary"), "Accept-Encoding")
+		t.Assert(gstr.Contains(resp.Header.Get("Content-Type"), "javascript"), true)
+		t.Assert(resp.ReadAllString(), "precompressed")
+		resp.Close()
+
+		client.SetHeader("Accept-Encoding", "identity")
+		resp, err = client.Get("/app.js")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), "plain")
+		resp.Close()
+
+		resp, err = client.Get("/index.css")
+		t.Assert(err, nil)
+		t.Assert(resp.Header.Get("Content-Encoding"), "")
+		t.Assert(resp.ReadAllString(), content)
+		resp.Close()
+	})
+}
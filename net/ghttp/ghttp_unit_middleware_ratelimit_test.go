@@ -0,0 +1,178 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/net/ghttp"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_Middleware_RateLimit(t *testing.T) {
+	p, _ := ports.PopRand()
+	s := g.Server(p)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareRateLimit(ghttp.RateLimitConfig{
+			Store: ghttp.NewSlidingWindowMemoryStore(2, time.Minute),
+		}))
+		group.ALL("/ip", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+	})
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(func(r *ghttp.Request) {
+			r.SetCtxVar("UserId", r.GetString("user"))
+			r.Middleware.Next()
+		})
+		group.Middleware(ghttp.MiddlewareRateLimit(ghttp.RateLimitConfig{
+			Store: ghttp.NewSlidingWindowMemoryStore(1, time.Minute),
+			KeyFunc: ghttp.RateLimitKeyJoin(
+				ghttp.RateLimitKeyByCtxVar("UserId"),
+				ghttp.RateLimitKeyByRoute,
+			),
+		}))
+		group.ALL("/user/{id}", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
+	})
+	s.BindStatusHandler(http.StatusTooManyRequests, func(r *ghttp.Request) {
+		r.Response.ClearBuffer()
+		r.Response.Write("too many requests")
+	})
+	s.SetPort(p)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+
+		for i := 1; i >= 0; i-- {
+			resp, err := c.Get("/ip")
+			t.Assert(err, nil)
+			t.Assert(resp.StatusCode, 200)
+			t.Assert(resp.ReadAllString(), "ok")
+			t.Assert(resp.Header.Get("X-RateLimit-Limit"), 2)
+			t.Assert(resp.Header.Get("X-RateLimit-Remaining"), i)
+			t.Assert(resp.Header.Get("X-RateLimit-Reset"), 60)
+			t.Assert(resp.Header.Get("Retry-After"), "")
+			resp.Close()
+		}
+		resp, err := c.Get("/ip")
+		t.Assert(err, nil)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, http.StatusTooManyRequests)
+		t.Assert(resp.ReadAllString(), "too many requests")
+		t.Assert(resp.Header.Get("X-RateLimit-Remaining"), 0)
+		t.Assert(resp.Header.Get("Retry-After"), 60)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+
+		// The route is limited for each user, in spite of the route parameters.
+		t.Assert(c.GetContent("/user/1?user=john"), "ok")
+		t.Assert(c.GetContent("/user/2?user=john"), "too many requests")
+		t.Assert(c.GetContent("/user/1?user=smith"), "ok")
+	})
+}
+
+func Test_Middleware_RateLimit_TokenBucket(t *testing.T) {
+	store := ghttp.NewTokenBucketMemoryStore(10, 2)
+	gtest.C(t, func(t *gtest.T) {
+		for i := 1; i >= 0; i-- {
+			result, err := store.AllowN("key", 1)
+			t.Assert(err, nil)
+			t.Assert(result.Allowed, true)
+			t.Assert(result.Limit, 2)
+			t.Assert(result.Remaining, i)
+		}
+		result, err := store.AllowN("key", 1)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, false)
+		t.Assert(result.RetryAfter > 0, true)
+		t.Assert(result.RetryAfter <= 100*time.Millisecond, true)
+
+		result, err = store.AllowN("key", 3)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, false)
+		t.Assert(result.RetryAfter < 0, true)
+
+		// Refilled after a while.
+		time.Sleep(120 * time.Millisecond)
+		result, err = store.AllowN("key", 1)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, true)
+
+		// Other keys are not affected.
+		result, err = store.AllowN("other", 2)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 0)
+	})
+}
+
+func Test_Middleware_RateLimit_SlidingWindow(t *testing.T) {
+	store := ghttp.NewSlidingWindowMemoryStore(2, 200*time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		result, err := store.AllowN("key", 2)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 0)
+
+		result, err = store.AllowN("key", 1)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, false)
+		t.Assert(result.RetryAfter > 0, true)
+
+		time.Sleep(250 * time.Millisecond)
+		result, err = store.AllowN("key", 1)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Remaining, 1)
+	})
+}
+
+func Test_Middleware_RateLimit_StoreFunc(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var store ghttp.RateLimitStore = ghttp.RateLimitStoreFunc(func(key string, n int) (*ghttp.RateLimitResult, error) {
+			return &ghttp.RateLimitResult{Allowed: key == "allowed", Limit: n}, nil
+		})
+		result, err := store.AllowN("allowed", 3)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, true)
+		t.Assert(result.Limit, 3)
+		result, err = store.AllowN("denied", 1)
+		t.Assert(err, nil)
+		t.Assert(result.Allowed, false)
+	})
+}
+
+func Test_Middleware_RateLimit_InvalidStore(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, f := range []func(){
+			func() { ghttp.NewTokenBucketMemoryStore(0, 10) },
+			func() { ghttp.NewTokenBucketMemoryStore(1, 0) },
+			func() { ghttp.NewSlidingWindowMemoryStore(0, time.Second) },
+			func() { ghttp.NewSlidingWindowMemoryStore(1, 0) },
+		} {
+			func() {
+				defer func() {
+					t.AssertNE(recover(), nil)
+				}()
+				f()
+			}()
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/encoding/gjson"
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/net/ghttp"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/text/gstr"
+	"github.com/gogf/gf/util/gmeta"
+)
+
+type openapiUserReq struct {
+	gmeta.Meta `summary:"get user" tags:"user" method:"get"`
+	Id         int    `p:"id" v:"required|min:1" dc:"user id"`
+	Fields     string `p:"fields"`
+}
+
+type openapiUserRes struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func Test_OpenApi(t *testing.T) {
+	p, _ := ports.PopRand()
+	s := g.Server(p)
+	s.Group("/api", func(group *ghttp.RouterGroup) {
+		group.ALL("/user/:id", ghttp.ApiHandler{
+			Handler: func(r *ghttp.Request) {
+				var req *openapiUserReq
+				if err := r.Parse(&req); err != nil {
+					r.Response.WriteExit(err.Error())
+				}
+				r.Response.WriteJson(openapiUserRes{Id: req.Id, Name: "john"})
+			},
+			Request:  openapiUserReq{},
+			Response: openapiUserRes{},
+		})
+		group.POST("/plain", func(r *ghttp.Request) {
+			r.Response.Write("plain")
+		})
+	})
+	s.BindApiHandler("PUT:/user/{id}", ghttp.ApiHandler{
+		Handler: func(r *ghttp.Request) {},
+		Request: &openapiUserReq{},
+	})
+	s.SetOpenApiPath("/api.json")
+	s.SetSwaggerPath("/swagger")
+	s.SetPort(p)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+
+		t.Assert(client.GetContent("/api/user/1"), `{"id":1,"name":"john"}`)
+
+		j, err := gjson.LoadContent(client.GetContent("/api.json"))
+		t.Assert(err, nil)
+		t.Assert(j.GetString("openapi"), "3.0.0")
+		t.Assert(j.GetString("paths./api/user/{id}.get.summary"), "get user")
+		t.Assert(j.GetString("paths./api/user/{id}.get.parameters.0.in"), "path")
+		t.Assert(j.GetString("paths./api/user/{id}.get.parameters.0.description"), "user id")
+		t.Assert(j.GetString("paths./api/user/{id}.get.parameters.1.in"), "query")
+		t.Assert(
+			j.GetString("paths./api/user/{id}.get.responses.200.content.application/json.schema.$ref"),
+			"#/components/schemas/ghttp_test.openapiUserRes",
+		)
+		t.Assert(j.Contains("paths./user/{id}.put"), true)
+		t.Assert(j.Contains("paths./api/plain"), false)
+
+		t.Assert(gstr.Contains(client.GetContent("/swagger"), `url: "/api.json"`), true)
+		t.Assert(gstr.Contains(client.GetContent("/swagger"), `href="https://unpkg.com/swagger-ui-dist@3/swagger-ui.css"`), true)
+
+		s.SetSwaggerAssetUrl("/swagger-ui/")
+		t.Assert(gstr.Contains(client.GetContent("/swagger"), `src="/swagger-ui/swagger-ui-bundle.js"`), true)
+	})
+}
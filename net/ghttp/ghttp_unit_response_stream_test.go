@@ -0,0 +1,148 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/net/ghttp"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/util/gconv"
+)
+
+func Test_Response_Stream(t *testing.T) {
+	var (
+		p, _    = ports.PopRand()
+		s       = g.Server(p)
+		next    = make(chan struct{})
+		results = make(chan bool, 1)
+	)
+	s.BindHandler("/stream", func(r *ghttp.Request) {
+		r.Response.Header().Set("Content-Type", "text/plain")
+		r.Response.Write("first\n")
+		r.Response.Stream()
+		<-next
+		r.Response.Write("second\n")
+		r.Response.Writef("%s\n", "third")
+	})
+	s.BindHandler("/accepted", func(r *ghttp.Request) {
+		r.Response.WriteHeader(http.StatusAccepted)
+		r.Response.Stream()
+		r.Response.Flush()
+		r.Response.Write("job")
+		r.Response.Flush()
+		r.Response.Flush()
+	})
+	s.BindHandler("/disconnect", func(r *ghttp.Request) {
+		r.Response.Stream()
+		for {
+			select {
+			case <-r.Context().Done():
+				results <- true
+				return
+			case <-time.After(10 * time.Millisecond):
+				r.Response.Write(".")
+			}
+		}
+	})
+	s.SetPort(p)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+
+		resp, err := client.Get("/stream")
+		t.Assert(err, nil)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, 200)
+		reader := bufio.NewReader(resp.Body)
+		// The content is received before the handler returns.
+		line, err := reader.ReadString('\n')
+		t.Assert(err, nil)
+		t.Assert(line, "first\n")
+		close(next)
+		rest, err := ioutil.ReadAll(reader)
+		t.Assert(err, nil)
+		t.Assert(string(rest), "second\nthird\n")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// No status text is written for the empty flushes of non-200 stream.
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/accepted", p))
+		t.Assert(err, nil)
+		defer resp.Body.Close()
+		t.Assert(resp.StatusCode, http.StatusAccepted)
+		content, err := ioutil.ReadAll(resp.Body)
+		t.Assert(err, nil)
+		t.Assert(string(content), "job")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/disconnect", p))
+		t.Assert(err, nil)
+		b := make([]byte, 1)
+		_, err = resp.Body.Read(b)
+		t.Assert(err, nil)
+		t.Assert(string(b), ".")
+		// Closing the body before EOF closes the connection.
+		resp.Body.Close()
+		select {
+		case v := <-results:
+			t.Assert(v, true)
+		case <-time.After(time.Second):
+			t.Error("client disconnection not detected")
+		}
+	})
+}
+
+func Test_Response_SSE(t *testing.T) {
+	p, _ := ports.PopRand()
+	s := g.Server(p)
+	s.BindHandler("/events", func(r *ghttp.Request) {
+		sse := r.Response.SSE()
+		sse.Heartbeat(50 * time.Millisecond)
+		sse.Retry(3 * time.Second)
+		start := gconv.Int(sse.LastEventId()) + 1
+		for i := start; i < start+2; i++ {
+			sse.Event(gconv.String(i), "message", g.Map{"index": i})
+		}
+		sse.Data("multiple\nlines")
+		time.Sleep(80 * time.Millisecond)
+	})
+	s.SetPort(p)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+		client.SetHeader("Last-Event-ID", "5")
+
+		resp, err := client.Get("/events")
+		t.Assert(err, nil)
+		defer resp.Close()
+		t.Assert(resp.Header.Get("Content-Type"), "text/event-stream; charset=utf-8")
+		t.Assert(resp.Header.Get("Cache-Control"), "no-cache")
+		t.Assert(resp.ReadAllString(), ""+
+			"retry: 3000\n\n"+
+			"id: 6\nevent: message\ndata: {\"index\":6}\n\n"+
+			"id: 7\nevent: message\ndata: {\"index\":7}\n\n"+
+			"data: multiple\ndata: lines\n\n"+
+			": heartbeat\n\n",
+		)
+	})
+}
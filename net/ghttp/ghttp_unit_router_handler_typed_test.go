@@ -0,0 +1,108 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/encoding/gjson"
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/net/ghttp"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/util/gmeta"
+)
+
+type typedHelloReq struct {
+	gmeta.Meta `summary:"say hello" method:"get"`
+	Name       string `p:"name" v:"required#name is required"`
+}
+
+type typedHelloRes struct {
+	Content string `json:"content"`
+	Path    string `json:"path"`
+}
+
+func typedHello(ctx context.Context, req *typedHelloReq) (res *typedHelloRes, err error) {
+	switch req.Name {
+	case "error":
+		return nil, gerror.NewCode(100, "custom error")
+	case "plain":
+		return nil, gerror.New("plain error")
+	case "panic":
+		panic("exception")
+	}
+	return &typedHelloRes{
+		Content: "hello " + req.Name,
+		Path:    ghttp.RequestFromCtx(ctx).URL.Path,
+	}, nil
+}
+
+func Test_Router_Handler_Typed(t *testing.T) {
+	p, _ := ports.PopRand()
+	s := g.Server(p)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.ALL("/hello", typedHello)
+		group.ALL("/empty", func(ctx context.Context, req *typedHelloReq) (res *typedHelloRes, err error) {
+			return
+		})
+		group.ALL("/write", func(r *ghttp.Request) {
+			r.Response.Write("written")
+		})
+	})
+	s.SetOpenApiPath("/api.json")
+	s.SetPort(p)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+
+		t.Assert(
+			client.GetContent("/hello?name=john"),
+			`{"code":0,"message":"","data":{"content":"hello john","path":"/hello"}}`,
+		)
+		t.Assert(client.GetContent("/hello"), `{"code":51,"message":"name is required","data":null}`)
+		t.Assert(client.GetContent("/hello?name=error"), `{"code":100,"message":"custom error","data":null}`)
+		t.Assert(client.GetContent("/hello?name=plain"), `{"code":50,"message":"plain error","data":null}`)
+		t.Assert(client.GetContent("/empty?name=john"), `{"code":0,"message":"","data":null}`)
+		t.Assert(client.GetContent("/write"), `written`)
+		t.Assert(client.GetContent("/none"), `Not Found`)
+
+		resp, err := client.Get("/hello?name=panic")
+		t.Assert(err, nil)
+		defer resp.Close()
+		t.Assert(resp.StatusCode, 500)
+		t.Assert(resp.ReadAllString(), `{"code":50,"message":"exception","data":null}`)
+
+		j, err := gjson.LoadContent(client.GetContent("/api.json"))
+		t.Assert(err, nil)
+		t.Assert(j.GetString("paths./hello.get.summary"), "say hello")
+		t.Assert(
+			j.GetString("paths./hello.get.responses.200.content.application/json.schema.$ref"),
+			"#/components/schemas/ghttp_test.typedHelloRes",
+		)
+	})
+}
+
+func Test_Router_Handler_Typed_Invalid(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, err := ghttp.NewApiHandler(func(ctx context.Context, name string) error { return nil })
+		t.AssertNE(err, nil)
+		_, err = ghttp.NewApiHandler(func(ctx context.Context, req *typedHelloReq) (*typedHelloRes, error) {
+			return nil, nil
+		})
+		t.Assert(err, nil)
+	})
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/gogf/gf/frame/g"
 	"github.com/gogf/gf/net/ghttp"
+	"github.com/gogf/gf/os/gsession"
 	"github.com/gogf/gf/test/gtest"
 )
 
@@ -196,3 +197,44 @@ func Test_Session_Custom_Id(t *testing.T) {
 		t.Assert(client.GetContent("/value"), value)
 	})
 }
+
+func Test_Session_StorageCookie(t *testing.T) {
+	p, _ := ports.PopRand()
+	s := g.Server(p)
+	s.SetSessionStorage(gsession.NewStorageCookie(gsession.StorageCookieOptions{
+		HashKeys:   [][]byte{[]byte("hash-key")},
+		CryptoKeys: [][]byte{[]byte("1234567891234567")},
+	}))
+	s.BindHandler("/set", func(r *ghttp.Request) {
+		r.Session.Set(r.GetString("k"), r.GetString("v"))
+	})
+	s.BindHandler("/get", func(r *ghttp.Request) {
+		r.Response.Write(r.Session.Get(r.GetString("k")))
+	})
+	s.BindHandler("/remove", func(r *ghttp.Request) {
+		r.Session.Remove(r.GetString("k"))
+	})
+	s.SetPort(p)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetBrowserMode(true)
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+		t.Assert(client.GetContent("/set?k=key1&v=100"), "")
+		t.Assert(client.GetContent("/set?k=key2&v=200"), "")
+		t.Assert(client.GetContent("/get?k=key1"), "100")
+		t.Assert(client.GetContent("/get?k=key2"), "200")
+		t.Assert(client.GetContent("/remove?k=key1"), "")
+		t.Assert(client.GetContent("/get?k=key1"), "")
+		t.Assert(client.GetContent("/get?k=key2"), "200")
+
+		// The session data is carried by cookie, which is not shared by other clients.
+		other := g.Client()
+		other.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", p))
+		t.Assert(other.GetContent("/get?k=key2"), "")
+	})
+}
@@ -0,0 +1,201 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/gogf/gf/database/gredis"
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/net/ghttp"
+	"github.com/gogf/gf/test/gtest"
+)
+
+// The redis broker of package gredis implements WebSocketBroker.
+var _ ghttp.WebSocketBroker = (*gredis.Broker)(nil)
+
+// memoryBroker is an in-process WebSocketBroker for testing.
+type memoryBroker struct {
+	mu       sync.RWMutex
+	handlers []func(payload []byte)
+}
+
+func (b *memoryBroker) Publish(ctx context.Context, payload []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(payload)
+	}
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(ctx context.Context, handler func(payload []byte)) error {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, handler)
+	b.mu.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+func newHubServer(hub *ghttp.WebSocketHub) (*ghttp.Server, int) {
+	p, _ := ports.PopRand()
+	s := g.Server(p)
+	s.BindHandler("/ws", hub.Serve)
+	s.SetPort(p)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	return s, p
+}
+
+func dialHub(port int) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://127.0.0.1:%d/ws", port), nil)
+	return conn, err
+}
+
+func Test_WebSocketHub(t *testing.T) {
+	var (
+		broker = &memoryBroker{}
+		hub1   = ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{Broker: broker})
+		hub2   = ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{Broker: broker})
+	)
+	defer hub1.Close()
+	defer hub2.Close()
+	for _, hub := range []*ghttp.WebSocketHub{hub1, hub2} {
+		hub.Handle("join", func(conn *ghttp.WebSocketConn, msg *ghttp.WebSocketMessage) {
+			conn.Join(msg.Data.GetString("room"))
+			conn.Send("joined", conn.Rooms())
+		})
+		hub.Handle("", func(conn *ghttp.WebSocketConn, msg *ghttp.WebSocketMessage) {
+			conn.SendRaw(websocket.TextMessage, msg.Raw)
+		})
+	}
+	s1, p1 := newHubServer(hub1)
+	defer s1.Shutdown()
+	s2, p2 := newHubServer(hub2)
+	defer s2.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var conns []*websocket.Conn
+		for _, port := range []int{p1, p2, p1} {
+			conn, err := dialHub(port)
+			t.Assert(err, nil)
+			defer conn.Close()
+			conns = append(conns, conn)
+		}
+		// Join room.
+		for _, conn := range conns[:2] {
+			t.Assert(conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"join","data":{"room":"r1"}}`)), nil)
+			_, data, err := conn.ReadMessage()
+			t.Assert(err, nil)
+			t.Assert(string(data), `{"type":"joined","data":["r1"]}`)
+		}
+		t.Assert(hub1.Count(), 2)
+		t.Assert(hub2.Count(), 1)
+		t.Assert(len(hub1.RoomConns("r1")), 1)
+
+		// Raw message.
+		t.Assert(conns[2].WriteMessage(websocket.TextMessage, []byte(`raw`)), nil)
+		_, data, err := conns[2].ReadMessage()
+		t.Assert(err, nil)
+		t.Assert(string(data), `raw`)
+
+		// Broadcast to room, which reaches connections on both hubs.
+		t.Assert(hub1.BroadcastToRoom("r1", "chat", g.Map{"text": "hi"}), nil)
+		for _, conn := range conns[:2] {
+			_, data, err := conn.ReadMessage()
+			t.Assert(err, nil)
+			t.Assert(string(data), `{"type":"chat","data":{"text":"hi"}}`)
+		}
+
+		// Broadcast to all.
+		t.Assert(hub2.Broadcast("notice", "all"), nil)
+		for _, conn := range conns {
+			_, data, err := conn.ReadMessage()
+			t.Assert(err, nil)
+			t.Assert(string(data), `{"type":"notice","data":"all"}`)
+		}
+
+		// Send to connection.
+		t.AssertNE(hub1.SendTo("none", "notice", "one"), nil)
+	})
+}
+
+func Test_WebSocketHub_Idle(t *testing.T) {
+	var (
+		disconnected = make(chan string, 2)
+		hub          = ghttp.NewWebSocketHub(ghttp.WebSocketHubConfig{
+			PingInterval: 50 * time.Millisecond,
+			IdleTimeout:  200 * time.Millisecond,
+		})
+	)
+	defer hub.Close()
+	hub.OnDisconnect(func(conn *ghttp.WebSocketConn) {
+		disconnected <- conn.Id()
+	})
+	s, p := newHubServer(hub)
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		// The client reading messages replies pong automatically.
+		alive, err := dialHub(p)
+		t.Assert(err, nil)
+		defer alive.Close()
+		go func() {
+			for {
+				if _, _, err := alive.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+		// The client not reading messages replies nothing.
+		idle, err := dialHub(p)
+		t.Assert(err, nil)
+		defer idle.Close()
+
+		select {
+		case <-disconnected:
+		case <-time.After(time.Second):
+			t.Error("idle connection not closed")
+		}
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(hub.Count(), 1)
+	})
+}
+
+func Test_WebSocketHub_Close(t *testing.T) {
+	hub := ghttp.NewWebSocketHub()
+	s, p := newHubServer(hub)
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := dialHub(p)
+		t.Assert(err, nil)
+		defer conn.Close()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(hub.Count(), 1)
+
+		hub.Close()
+		_, _, err = conn.ReadMessage()
+		closeErr, ok := err.(*websocket.CloseError)
+		t.Assert(ok, true)
+		t.Assert(closeErr.Code, websocket.CloseGoingAway)
+
+		_, err = dialHub(p)
+		t.Assert(err, nil)
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(hub.Count(), 0)
+	})
+}
@@ -0,0 +1,326 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package goai implements OpenAPI specification V3 generation from Go structs.
+//
+// The request and response structs are described by struct tags:
+// p/param/json : Parameter or property name, see gconv.StructTagPriority.
+// in           : Parameter location of request field: path, query, header, cookie.
+//                The field is in request body in default for methods having body, or else in query.
+// v            : Validation rules of package gvalid, which are converted to schema restrictions,
+//                like: required, min, max, between, length, in, email, url, regex.
+// d/default    : Default value.
+// dc           : Description.
+// eg/example   : Example value.
+//
+// The meta data of the request struct, which is defined by embedded gmeta.Meta, describes the operation:
+// summary      : Summary of the operation.
+// dc           : Description of the operation.
+// tags         : Tags of the operation, joined by char ','.
+// deprecated   : Marks the operation deprecated, like: deprecated:"true".
+package goai
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/internal/json"
+	"github.com/gogf/gf/text/gregex"
+	"github.com/gogf/gf/util/gconv"
+	"github.com/gogf/gf/util/gmeta"
+)
+
+// OpenApiV3 is the OpenAPI specification V3 document.
+type OpenApiV3 struct {
+	OpenAPI    string          `json:"openapi"`
+	Info       Info            `json:"info"`
+	Servers    []Server        `json:"servers,omitempty"`
+	Paths      map[string]Path `json:"paths"`
+	Components Components      `json:"components"`
+	Tags       []Tag           `json:"tags,omitempty"`
+
+	schemaNames map[schemaKey]string // Component schema names of struct types and request bodies.
+}
+
+// schemaKey identifies the source of a component schema.
+type schemaKey struct {
+	typ  reflect.Type // Named struct type.
+	body bool         // Whether it is the request body schema of the request struct type.
+}
+
+// Info is the metadata about the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is the server providing the API.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag is the tag for grouping operations.
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Path is the operations of a path by their lower case HTTP method names.
+type Path map[string]*Operation
+
+// Operation is a single API operation on a path.
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	OperationID string               `json:"operationId,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+}
+
+// Parameter is a single operation parameter.
+type Parameter struct {
+	Name        string      `json:"name"`
+	In          string      `json:"in"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Schema      *Schema     `json:"schema,omitempty"`
+	Example     interface{} `json:"example,omitempty"`
+}
+
+// RequestBody is the request body of an operation.
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// Response is a single response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a media type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable schemas.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// Schema is the data type definition.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Example              interface{}        `json:"example,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+}
+
+// AddInput is the input for adding an operation to the document.
+type AddInput struct {
+	Path     string      // Path in OpenAPI format, like: /user/{id}.
+	Method   string      // HTTP method, like: GET.
+	Request  interface{} // Request struct or its pointer, which can be nil.
+	Response interface{} // Response struct or its pointer, which can be nil.
+}
+
+const (
+	// Version is the OpenAPI specification version.
+	Version = "3.0.0"
+
+	ParameterInPath   = "path"
+	ParameterInQuery  = "query"
+	ParameterInHeader = "header"
+	ParameterInCookie = "cookie"
+
+	// TagNameIn is the struct tag name for parameter location.
+	TagNameIn = "in"
+	// TagNameDescription is the struct tag name for description.
+	TagNameDescription = "dc"
+
+	contentTypeJson = "application/json"
+	schemaRefPrefix = "#/components/schemas/"
+)
+
+var (
+	// methodsWithBody are the HTTP methods whose parameters are in request body in default.
+	methodsWithBody = map[string]bool{
+		http.MethodPost:  true,
+		http.MethodPut:   true,
+		http.MethodPatch: true,
+	}
+)
+
+// New creates and returns an empty OpenApiV3 document.
+func New() *OpenApiV3 {
+	return &OpenApiV3{
+		OpenAPI: Version,
+		Info: Info{
+			Title:   "API Reference",
+			Version: "1.0.0",
+		},
+		Paths: make(map[string]Path),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+		},
+	}
+}
+
+// Add adds an operation of `in.Path` and `in.Method` to the document, which is generated
+// from the request and response struct.
+func (oai *OpenApiV3) Add(in AddInput) error {
+	method := strings.ToUpper(in.Method)
+	if in.Path == "" || method == "" {
+		return gerror.New(`path and method should not be empty`)
+	}
+	operation := &Operation{
+		Responses: make(map[string]*Response),
+	}
+	if in.Request != nil {
+		if err := oai.addRequest(operation, in.Path, method, in.Request); err != nil {
+			return err
+		}
+	}
+	response := &Response{Description: http.StatusText(http.StatusOK)}
+	if in.Response != nil {
+		schema, err := oai.schemaOf(reflect.TypeOf(in.Response))
+		if err != nil {
+			return err
+		}
+		response.Content = map[string]MediaType{
+			contentTypeJson: {Schema: schema},
+		}
+	}
+	operation.Responses[fmt.Sprintf(`%d`, http.StatusOK)] = response
+
+	if _, ok := oai.Paths[in.Path]; !ok {
+		oai.Paths[in.Path] = make(Path)
+	}
+	oai.Paths[in.Path][strings.ToLower(method)] = operation
+	return nil
+}
+
+// addRequest fills the meta data, parameters and request body of `operation` with request struct `request`.
+func (oai *OpenApiV3) addRequest(operation *Operation, path, method string, request interface{}) error {
+	structType := indirectType(reflect.TypeOf(request))
+	if structType.Kind() != reflect.Struct {
+		return gerror.Newf(`request should be type of struct/*struct, but got %T`, request)
+	}
+	// Operation meta data.
+	meta := gmeta.Data(reflect.New(structType).Interface())
+	operation.Summary = gconv.String(meta["summary"])
+	operation.Description = gconv.String(meta[TagNameDescription])
+	operation.Deprecated = gconv.Bool(meta["deprecated"])
+	if tags := gconv.String(meta["tags"]); tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				operation.Tags = append(operation.Tags, tag)
+				oai.addTag(tag)
+			}
+		}
+	}
+	// Parameters and request body.
+	var (
+		pathNames = make(map[string]bool)
+		body      = &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	)
+	match, _ := gregex.MatchAllString(`\{([^}]+)\}`, path)
+	for _, m := range match {
+		pathNames[m[1]] = true
+	}
+	err := oai.walkFields(structType, func(field reflect.StructField, name string) error {
+		schema, err := oai.fieldSchema(field)
+		if err != nil {
+			return err
+		}
+		required := isRequired(field)
+		in := field.Tag.Get(TagNameIn)
+		if in == "" {
+			switch {
+			case pathNames[name]:
+				in = ParameterInPath
+			case !methodsWithBody[method]:
+				in = ParameterInQuery
+			}
+		}
+		if in == "" {
+			body.Properties[name] = schema
+			if required {
+				body.Required = append(body.Required, name)
+			}
+			return nil
+		}
+		parameter := &Parameter{
+			Name:        name,
+			In:          in,
+			Description: schema.Description,
+			Required:    required || in == ParameterInPath,
+			Schema:      schema,
+		}
+		schema.Description = ""
+		operation.Parameters = append(operation.Parameters, parameter)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(body.Properties) > 0 {
+		name := oai.schemaName(schemaKey{typ: structType, body: true})
+		oai.Components.Schemas[name] = body
+		operation.RequestBody = &RequestBody{
+			Required: len(body.Required) > 0,
+			Content: map[string]MediaType{
+				contentTypeJson: {Schema: &Schema{Ref: schemaRefPrefix + name}},
+			},
+		}
+	}
+	return nil
+}
+
+// addTag adds `name` to the tags of the document if it does not exist.
+func (oai *OpenApiV3) addTag(name string) {
+	for _, tag := range oai.Tags {
+		if tag.Name == name {
+			return
+		}
+	}
+	oai.Tags = append(oai.Tags, Tag{Name: name})
+	sort.Slice(oai.Tags, func(i, j int) bool {
+		return oai.Tags[i].Name < oai.Tags[j].Name
+	})
+}
+
+// String returns the document as JSON string.
+func (oai *OpenApiV3) String() string {
+	b, err := json.Marshal(oai)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gogf/gf/text/gregex"
+	"github.com/gogf/gf/util/gconv"
+)
+
+var (
+	// validationTags are the struct tag names of validation rules, see gvalid.
+	validationTags = []string{"gvalid", "valid", "v"}
+
+	// ruleFormats maps the validation rules to the schema formats.
+	ruleFormats = map[string]string{
+		"email":     "email",
+		"url":       "uri",
+		"domain":    "hostname",
+		"ip":        "ip",
+		"ipv4":      "ipv4",
+		"ipv6":      "ipv6",
+		"date":      "date",
+		"datetime":  "date-time",
+		"phone":     "phone",
+		"password":  "password",
+		"password2": "password",
+		"password3": "password",
+	}
+)
+
+// parseRules returns the validation rules of `field`, like: ["required", "length:6,16"].
+// The alias name and custom messages in tag are removed.
+func parseRules(field reflect.StructField) []string {
+	var tag string
+	for _, name := range validationTags {
+		if tag = field.Tag.Get(name); tag != "" {
+			break
+		}
+	}
+	if tag == "" {
+		return nil
+	}
+	// Sequence tag like: name@required|length:2,20#message.
+	if match, _ := gregex.MatchString(`^\s*\w+\s*@(.+)$`, tag); len(match) > 1 {
+		tag = match[1]
+	}
+	if pos := strings.Index(tag, "#"); pos != -1 {
+		tag = tag[:pos]
+	}
+	var (
+		rules = make([]string, 0)
+		items = strings.Split(tag, "|")
+	)
+	for i := 0; i < len(items); i++ {
+		rule := strings.TrimSpace(items[i])
+		// The regular expression may contain char '|'.
+		if strings.HasPrefix(rule, "regex:") {
+			rules = append(rules, strings.Join(items[i:], "|"))
+			break
+		}
+		if rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// isRequired checks whether `field` is required by its validation rules.
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range parseRules(field) {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRules applies the restrictions of validation rules `rules` to `schema`.
+func applyRules(schema *Schema, rules []string) {
+	for _, rule := range rules {
+		var (
+			name  = rule
+			value = ""
+		)
+		if pos := strings.Index(rule, ":"); pos != -1 {
+			name, value = rule[:pos], rule[pos+1:]
+		}
+		array := strings.Split(value, ",")
+		switch name {
+		case "min":
+			schema.Minimum = floatPointer(value)
+		case "max":
+			schema.Maximum = floatPointer(value)
+		case "between":
+			if len(array) == 2 {
+				schema.Minimum, schema.Maximum = floatPointer(array[0]), floatPointer(array[1])
+			}
+		case "min-length":
+			schema.MinLength = intPointer(value)
+		case "max-length":
+			schema.MaxLength = intPointer(value)
+		case "length":
+			if len(array) == 2 {
+				schema.MinLength, schema.MaxLength = intPointer(array[0]), intPointer(array[1])
+			}
+		case "size":
+			schema.MinLength, schema.MaxLength = intPointer(value), intPointer(value)
+		case "in":
+			schema.Enum = make([]interface{}, len(array))
+			for i, v := range array {
+				schema.Enum[i] = convertValue(schema, strings.TrimSpace(v))
+			}
+		case "regex":
+			schema.Pattern = value
+		case "integer":
+			schema.Type = "integer"
+		case "float":
+			schema.Type = "number"
+		case "boolean":
+			schema.Type = "boolean"
+		default:
+			if format, ok := ruleFormats[name]; ok && schema.Type == "string" {
+				schema.Format = format
+			}
+		}
+	}
+}
+
+func floatPointer(s string) *float64 {
+	v := gconv.Float64(strings.TrimSpace(s))
+	return &v
+}
+
+func intPointer(s string) *int {
+	v := gconv.Int(strings.TrimSpace(s))
+	return &v
+}
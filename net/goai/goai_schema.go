@@ -0,0 +1,227 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/util/gconv"
+	"github.com/gogf/gf/util/gmeta"
+)
+
+var (
+	// metaType is the type of embedded meta data, which is not a field of the schema.
+	metaType = reflect.TypeOf(gmeta.Meta{})
+
+	// timeTypes are the struct types described as date-time string.
+	timeTypes = map[reflect.Type]struct{}{
+		reflect.TypeOf(time.Time{}):  {},
+		reflect.TypeOf(gtime.Time{}): {},
+	}
+)
+
+// schemaOf returns the schema of type `t`. The schema of named struct type is added to the
+// components of the document, and its reference is returned.
+func (oai *OpenApiV3) schemaOf(t reflect.Type) (*Schema, error) {
+	t = indirectType(t)
+	if _, ok := timeTypes[t]; ok {
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}, nil
+
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}, nil
+
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}, nil
+
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}, nil
+
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := oai.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+
+	case reflect.Map:
+		values, err := oai.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+
+	case reflect.Struct:
+		if t.Name() == "" {
+			return oai.structSchema(t)
+		}
+		name := oai.schemaName(schemaKey{typ: t})
+		if _, ok := oai.Components.Schemas[name]; !ok {
+			// Placeholder for recursive struct types.
+			oai.Components.Schemas[name] = &Schema{Type: "object"}
+			schema, err := oai.structSchema(t)
+			if err != nil {
+				delete(oai.Components.Schemas, name)
+				return nil, err
+			}
+			oai.Components.Schemas[name] = schema
+		}
+		return &Schema{Ref: schemaRefPrefix + name}, nil
+	}
+	// Any type, like interface{}.
+	return &Schema{}, nil
+}
+
+// structSchema returns the object schema of struct type `t` with its fields as properties.
+func (oai *OpenApiV3) structSchema(t reflect.Type) (*Schema, error) {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+	err := oai.walkFields(t, func(field reflect.StructField, name string) error {
+		fieldSchema, err := oai.fieldSchema(field)
+		if err != nil {
+			return err
+		}
+		schema.Properties[name] = fieldSchema
+		if isRequired(field) {
+			schema.Required = append(schema.Required, name)
+		}
+		return nil
+	})
+	return schema, err
+}
+
+// fieldSchema returns the schema of struct field `field`, with the restrictions and
+// descriptions in its tags.
+func (oai *OpenApiV3) fieldSchema(field reflect.StructField) (*Schema, error) {
+	schema, err := oai.schemaOf(field.Type)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Ref != "" {
+		// The reference schema cannot have sibling properties.
+		return schema, nil
+	}
+	schema.Description = field.Tag.Get(TagNameDescription)
+	for _, tag := range []string{"d", "default"} {
+		if value, ok := field.Tag.Lookup(tag); ok {
+			schema.Default = convertValue(schema, value)
+			break
+		}
+	}
+	for _, tag := range []string{"eg", "example"} {
+		if value, ok := field.Tag.Lookup(tag); ok {
+			schema.Example = convertValue(schema, value)
+			break
+		}
+	}
+	applyRules(schema, parseRules(field))
+	return schema, nil
+}
+
+// walkFields calls `handler` with each field and its name of struct type `t`, including
+// the fields of embedded structs.
+func (oai *OpenApiV3) walkFields(t reflect.Type, handler func(field reflect.StructField, name string) error) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Type == metaType {
+			continue
+		}
+		name := fieldName(field)
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == field.Name && indirectType(field.Type).Kind() == reflect.Struct {
+			if err := oai.walkFields(indirectType(field.Type), handler); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := handler(field, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldName returns the parameter or property name of `field` by the tags in gconv.StructTagPriority.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range gconv.StructTagPriority {
+		if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// schemaName returns the component name of schema `key`, which is the type name with its
+// package name like: main.UserReq. If the name is used by another type or the request body of
+// the same type, it uses the type name with full package path like: github.com.user.api.UserReq,
+// and appends an ordinal number if it is still used.
+func (oai *OpenApiV3) schemaName(key schemaKey) string {
+	if name, ok := oai.schemaNames[key]; ok {
+		return name
+	}
+	if oai.schemaNames == nil {
+		oai.schemaNames = make(map[schemaKey]string)
+	}
+	var (
+		name      = key.typ.String()
+		fullName  = strings.Replace(key.typ.PkgPath(), "/", ".", -1) + "." + key.typ.Name()
+		nameTaken = func(name string) bool {
+			_, ok := oai.Components.Schemas[name]
+			return ok
+		}
+	)
+	if nameTaken(name) {
+		name = fullName
+		for i := 2; nameTaken(name); i++ {
+			name = fmt.Sprintf(`%s%d`, fullName, i)
+		}
+	}
+	oai.schemaNames[key] = name
+	return name
+}
+
+// indirectType returns the type that `t` points to, if `t` is a pointer type.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// convertValue converts string `value` in tag to the type of `schema`.
+func convertValue(schema *Schema, value string) interface{} {
+	switch schema.Type {
+	case "integer":
+		return gconv.Int64(value)
+	case "number":
+		return gconv.Float64(value)
+	case "boolean":
+		return gconv.Bool(value)
+	}
+	return value
+}
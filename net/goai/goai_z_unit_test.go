@@ -0,0 +1,148 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/net/goai"
+	v1 "github.com/gogf/gf/net/goai/testdata/v1/api"
+	v2 "github.com/gogf/gf/net/goai/testdata/v2/api"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/util/gmeta"
+)
+
+type Address struct {
+	City string `json:"city" dc:"city name" v:"required"`
+}
+
+type UserCreateReq struct {
+	gmeta.Meta `summary:"create user" dc:"create a new user" tags:"user,admin"`
+	Token      string   `in:"header" p:"X-Token" v:"required"`
+	Name       string   `p:"name" v:"required|length:6,16#name is required|name length should be 6-16" dc:"user name"`
+	Age        int      `p:"age" v:"between:18,60" d:"18"`
+	Gender     string   `p:"gender" v:"in:male,female"`
+	Email      string   `p:"email" v:"email"`
+	Address    *Address `p:"address"`
+	Tags       []string `p:"tags"`
+}
+
+type UserCreateRes struct {
+	Id      int64             `json:"id"`
+	Name    string            `json:"name"`
+	Extra   map[string]string `json:"extra"`
+	Friends []*UserCreateRes  `json:"friends"`
+}
+
+type UserGetReq struct {
+	Id     int    `p:"id" v:"min:1"`
+	Fields string `p:"fields" eg:"id,name"`
+}
+
+func Test_Add(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		t.Assert(oai.Add(goai.AddInput{
+			Path:     "/user",
+			Method:   "POST",
+			Request:  UserCreateReq{},
+			Response: &UserCreateRes{},
+		}), nil)
+		t.Assert(oai.Add(goai.AddInput{
+			Path:     "/user/{id}",
+			Method:   "get",
+			Request:  &UserGetReq{},
+			Response: UserCreateRes{},
+		}), nil)
+		t.AssertNE(oai.Add(goai.AddInput{Path: "/user", Method: "GET", Request: 1}), nil)
+		t.AssertNE(oai.Add(goai.AddInput{Method: "GET"}), nil)
+
+		// Operation with request body.
+		operation := oai.Paths["/user"]["post"]
+		t.Assert(operation.Summary, "create user")
+		t.Assert(operation.Description, "create a new user")
+		t.Assert(operation.Tags, []string{"user", "admin"})
+		t.Assert(len(oai.Tags), 2)
+		t.Assert(len(operation.Parameters), 1)
+		t.Assert(operation.Parameters[0].Name, "X-Token")
+		t.Assert(operation.Parameters[0].In, "header")
+		t.Assert(operation.Parameters[0].Required, true)
+		t.Assert(operation.RequestBody.Content["application/json"].Schema.Ref, "#/components/schemas/goai_test.UserCreateReq")
+		t.Assert(operation.Responses["200"].Content["application/json"].Schema.Ref, "#/components/schemas/goai_test.UserCreateRes")
+
+		body := oai.Components.Schemas["goai_test.UserCreateReq"]
+		t.Assert(body.Required, []string{"name"})
+		t.Assert(body.Properties["name"].Type, "string")
+		t.Assert(body.Properties["name"].Description, "user name")
+		t.Assert(*body.Properties["name"].MinLength, 6)
+		t.Assert(*body.Properties["name"].MaxLength, 16)
+		t.Assert(body.Properties["age"].Type, "integer")
+		t.Assert(body.Properties["age"].Default, 18)
+		t.Assert(*body.Properties["age"].Minimum, 18)
+		t.Assert(*body.Properties["age"].Maximum, 60)
+		t.Assert(body.Properties["gender"].Enum, []interface{}{"male", "female"})
+		t.Assert(body.Properties["email"].Format, "email")
+		t.Assert(body.Properties["address"].Ref, "#/components/schemas/goai_test.Address")
+		t.Assert(body.Properties["tags"].Items.Type, "string")
+		t.Assert(oai.Components.Schemas["goai_test.Address"].Required, []string{"city"})
+
+		res := oai.Components.Schemas["goai_test.UserCreateRes"]
+		t.Assert(res.Properties["id"].Format, "int64")
+		t.Assert(res.Properties["extra"].AdditionalProperties.Type, "string")
+		t.Assert(res.Properties["friends"].Items.Ref, "#/components/schemas/goai_test.UserCreateRes")
+
+		// Operation with parameters only.
+		operation = oai.Paths["/user/{id}"]["get"]
+		t.Assert(operation.RequestBody, nil)
+		t.Assert(len(operation.Parameters), 2)
+		t.Assert(operation.Parameters[0].In, "path")
+		t.Assert(operation.Parameters[0].Required, true)
+		t.Assert(*operation.Parameters[0].Schema.Minimum, 1)
+		t.Assert(operation.Parameters[1].In, "query")
+		t.Assert(operation.Parameters[1].Schema.Example, "id,name")
+	})
+}
+
+func Test_SchemaName_Collision(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		t.Assert(oai.Add(goai.AddInput{Path: "/v1/user", Method: "GET", Response: v1.User{}}), nil)
+		t.Assert(oai.Add(goai.AddInput{Path: "/v2/user", Method: "GET", Response: &v2.User{}}), nil)
+		t.Assert(oai.Add(goai.AddInput{Path: "/v1/users", Method: "GET", Response: []v1.User{}}), nil)
+
+		// The types of the same package name and type name have different names.
+		t.Assert(
+			oai.Paths["/v1/user"]["get"].Responses["200"].Content["application/json"].Schema.Ref,
+			"#/components/schemas/api.User",
+		)
+		t.Assert(
+			oai.Paths["/v2/user"]["get"].Responses["200"].Content["application/json"].Schema.Ref,
+			"#/components/schemas/github.com.gogf.gf.net.goai.testdata.v2.api.User",
+		)
+		t.Assert(
+			oai.Paths["/v1/users"]["get"].Responses["200"].Content["application/json"].Schema.Items.Ref,
+			"#/components/schemas/api.User",
+		)
+		t.Assert(len(oai.Components.Schemas), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// The request body does not replace the schema of the request struct itself.
+		oai := goai.New()
+		t.Assert(oai.Add(goai.AddInput{Path: "/user", Method: "GET", Response: UserCreateReq{}}), nil)
+		t.Assert(oai.Add(goai.AddInput{Path: "/user", Method: "POST", Request: UserCreateReq{}}), nil)
+		t.Assert(
+			oai.Paths["/user"]["get"].Responses["200"].Content["application/json"].Schema.Ref,
+			"#/components/schemas/goai_test.UserCreateReq",
+		)
+		t.Assert(
+			oai.Paths["/user"]["post"].RequestBody.Content["application/json"].Schema.Ref,
+			"#/components/schemas/github.com.gogf.gf.net.goai_test.UserCreateReq",
+		)
+		t.AssertNE(oai.Components.Schemas["goai_test.UserCreateReq"].Properties["X-Token"], nil)
+		t.Assert(oai.Components.Schemas["github.com.gogf.gf.net.goai_test.UserCreateReq"].Properties["X-Token"], nil)
+	})
+}
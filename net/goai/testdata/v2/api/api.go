@@ -0,0 +1,14 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package api is the v2 api for testing schema names of the same package and type name.
+package api
+
+// User is the user of v2 api.
+type User struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
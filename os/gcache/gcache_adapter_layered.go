@@ -0,0 +1,423 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/container/gtype"
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/os/gtimer"
+	"github.com/gogf/gf/util/gconv"
+	"github.com/gogf/gf/util/guid"
+)
+
+// AdapterLayered is a two-level cache adapter, which serves hot keys from a process memory
+// cache (L1) in front of another adapter (L2), commonly a remote one like AdapterRedis.
+//
+// L1 entries are populated on L2 hits and writes with a TTL no longer than L1TTL, and they
+// are dropped on all nodes by the invalidation messages broadcast through the Bus on
+// Set/Remove/Update operations. Note that the keys of L1 are converted to string, as they
+// are transferred among nodes.
+//
+// L1 values are the Serializer round-trip form of the values, which are the same as the
+// values decoded from L2 on other nodes, so that Get returns values of the same types no
+// matter which node wrote the value.
+type AdapterLayered struct {
+	local      *adapterMemory        // L1 memory cache.
+	remote     Adapter               // L2 cache.
+	bus        Bus                   // Bus for invalidation messages, which is optional.
+	node       string                // Unique id of current node.
+	generation *gtype.Int64          // Generation increased on each L1 invalidation.
+	options    AdapterLayeredOptions // Options of the adapter.
+}
+
+// AdapterLayeredOptions is the options for AdapterLayered.
+type AdapterLayeredOptions struct {
+	L1Cap int           // LRU capacity of L1 memory cache, 0 means no limits.
+	L1TTL time.Duration // Maximum TTL of L1 entries (default is 30 seconds).
+	Bus   Bus           // (Optional) Bus for broadcasting invalidations among nodes.
+
+	// (Optional) Serializer for normalizing L1 values, which should be the same as the
+	// Serializer of L2 adapter (default is SerializerJson).
+	Serializer Serializer
+}
+
+const (
+	defaultAdapterLayeredL1TTL = 30 * time.Second
+)
+
+// NewAdapterLayered creates and returns a two-level adapter with <remote> as its L2.
+// It subscribes the Bus of <options> if given, and returns error if the subscribing fails.
+func NewAdapterLayered(remote Adapter, options ...AdapterLayeredOptions) (*AdapterLayered, error) {
+	a := &AdapterLayered{
+		remote:     remote,
+		node:       guid.S(),
+		generation: gtype.NewInt64(),
+	}
+	if len(options) > 0 {
+		a.options = options[0]
+	}
+	if a.options.Serializer == nil {
+		a.options.Serializer = SerializerJson
+	}
+	if a.options.L1TTL <= 0 {
+		a.options.L1TTL = defaultAdapterLayeredL1TTL
+	}
+	if a.options.L1Cap > 0 {
+		a.local = newAdapterMemory(AdapterMemoryOptions{Cap: a.options.L1Cap})
+	} else {
+		a.local = newAdapterMemory()
+	}
+	gtimer.AddSingleton(time.Second, a.local.syncEventAndClearExpired)
+	if a.options.Bus != nil {
+		a.bus = a.options.Bus
+		if err := a.bus.Subscribe(a.handleBusMessage); err != nil {
+			a.local.Close(context.Background())
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Set sets cache with <key>-<value> pair, which is expired after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0.
+func (a *AdapterLayered) Set(ctx context.Context, key interface{}, value interface{}, duration time.Duration) error {
+	if err := a.remote.Set(ctx, key, value, duration); err != nil {
+		return err
+	}
+	a.setLocal(ctx, key, value, duration)
+	a.publish(ctx, key)
+	return nil
+}
+
+// Sets batch sets cache with key-value pairs by <data>, which is expired after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the keys of <data> if <duration> < 0 or given <value> is nil.
+func (a *AdapterLayered) Sets(ctx context.Context, data map[interface{}]interface{}, duration time.Duration) error {
+	if err := a.remote.Sets(ctx, data, duration); err != nil {
+		return err
+	}
+	keys := make([]interface{}, 0, len(data))
+	for k, v := range data {
+		a.setLocal(ctx, k, v, duration)
+		keys = append(keys, k)
+	}
+	a.publish(ctx, keys...)
+	return nil
+}
+
+// SetIfNotExist sets cache with <key>-<value> pair which is expired after <duration>
+// if <key> does not exist in the cache. It returns true the <key> dose not exist in the
+// cache and it sets <value> successfully to the cache, or else it returns false.
+//
+// The parameter <value> can be type of <func() interface{}>, but it dose nothing if its
+// result is nil.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0 or given <value> is nil.
+func (a *AdapterLayered) SetIfNotExist(ctx context.Context, key interface{}, value interface{}, duration time.Duration) (bool, error) {
+	ok, err := a.remote.SetIfNotExist(ctx, key, value, duration)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		a.removeLocal(ctx, key)
+		a.publish(ctx, key)
+	}
+	return ok, nil
+}
+
+// Get retrieves and returns the associated value of given <key> from L1, or from L2
+// which populates L1 if found.
+// It returns nil if it does not exist, its value is nil or it's expired.
+func (a *AdapterLayered) Get(ctx context.Context, key interface{}) (interface{}, error) {
+	v, err := a.local.Get(ctx, a.localKey(key))
+	if err != nil || v != nil {
+		return v, err
+	}
+	generation := a.generation.Val()
+	if v, err = a.remote.Get(ctx, key); err != nil || v == nil {
+		return v, err
+	}
+	expire, err := a.remote.GetExpire(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if expire < 0 {
+		return v, nil
+	}
+	return a.populateLocal(ctx, key, v, expire, generation), nil
+}
+
+// GetOrSet retrieves and returns the value of <key>, or sets <key>-<value> pair and
+// returns <value> if <key> does not exist in the cache. The key-value pair expires
+// after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0 or given <value> is nil, but it does nothing
+// if <value> is a function and the function result is nil.
+func (a *AdapterLayered) GetOrSet(ctx context.Context, key interface{}, value interface{}, duration time.Duration) (interface{}, error) {
+	v, err := a.Get(ctx, key)
+	if err != nil || v != nil {
+		return v, err
+	}
+	generation := a.generation.Val()
+	if v, err = a.remote.GetOrSet(ctx, key, value, duration); err != nil {
+		return nil, err
+	}
+	return a.populateLocal(ctx, key, v, duration, generation), nil
+}
+
+// GetOrSetFunc retrieves and returns the value of <key>, or sets <key> with result of
+// function <f> and returns its result if <key> does not exist in the cache. The key-value
+// pair expires after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0 or given <value> is nil, but it does nothing
+// if <value> is a function and the function result is nil.
+func (a *AdapterLayered) GetOrSetFunc(ctx context.Context, key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
+	v, err := a.Get(ctx, key)
+	if err != nil || v != nil {
+		return v, err
+	}
+	generation := a.generation.Val()
+	if v, err = a.remote.GetOrSetFunc(ctx, key, f, duration); err != nil {
+		return nil, err
+	}
+	return a.populateLocal(ctx, key, v, duration, generation), nil
+}
+
+// GetOrSetFuncLock retrieves and returns the value of <key>, or sets <key> with result of
+// function <f> and returns its result if <key> does not exist in the cache. The key-value
+// pair expires after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It does nothing if function <f> returns nil.
+//
+// Note that the function <f> is executed within the lock of L2 adapter.
+func (a *AdapterLayered) GetOrSetFuncLock(ctx context.Context, key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
+	v, err := a.Get(ctx, key)
+	if err != nil || v != nil {
+		return v, err
+	}
+	generation := a.generation.Val()
+	if v, err = a.remote.GetOrSetFuncLock(ctx, key, f, duration); err != nil {
+		return nil, err
+	}
+	return a.populateLocal(ctx, key, v, duration, generation), nil
+}
+
+// Contains returns true if <key> exists in the cache, or else returns false.
+func (a *AdapterLayered) Contains(ctx context.Context, key interface{}) (bool, error) {
+	if ok, _ := a.local.Contains(ctx, a.localKey(key)); ok {
+		return true, nil
+	}
+	return a.remote.Contains(ctx, key)
+}
+
+// GetExpire retrieves and returns the expiration of <key> in L2.
+//
+// It returns 0 if the <key> does not expire.
+// It returns -1 if the <key> does not exist in the cache.
+func (a *AdapterLayered) GetExpire(ctx context.Context, key interface{}) (time.Duration, error) {
+	return a.remote.GetExpire(ctx, key)
+}
+
+// Remove deletes one or more keys from cache, and returns its value.
+// If multiple keys are given, it returns the value of the last deleted item.
+func (a *AdapterLayered) Remove(ctx context.Context, keys ...interface{}) (value interface{}, err error) {
+	if value, err = a.remote.Remove(ctx, keys...); err != nil {
+		return nil, err
+	}
+	a.removeLocal(ctx, keys...)
+	a.publish(ctx, keys...)
+	return value, nil
+}
+
+// Update updates the value of <key> without changing its expiration and returns the old value.
+// The returned value <exist> is false if the <key> does not exist in the cache.
+//
+// It deletes the <key> if given <value> is nil.
+// It does nothing if <key> does not exist in the cache.
+func (a *AdapterLayered) Update(ctx context.Context, key interface{}, value interface{}) (oldValue interface{}, exist bool, err error) {
+	if oldValue, exist, err = a.remote.Update(ctx, key, value); err != nil {
+		return nil, false, err
+	}
+	if exist {
+		a.removeLocal(ctx, key)
+		a.publish(ctx, key)
+	}
+	return
+}
+
+// UpdateExpire updates the expiration of <key> and returns the old expiration duration value.
+//
+// It returns -1 and does nothing if the <key> does not exist in the cache.
+// It deletes the <key> if <duration> < 0.
+func (a *AdapterLayered) UpdateExpire(ctx context.Context, key interface{}, duration time.Duration) (oldDuration time.Duration, err error) {
+	if oldDuration, err = a.remote.UpdateExpire(ctx, key, duration); err != nil {
+		return -1, err
+	}
+	if oldDuration != -1 {
+		a.removeLocal(ctx, key)
+		a.publish(ctx, key)
+	}
+	return
+}
+
+// Size returns the number of items in L2.
+func (a *AdapterLayered) Size(ctx context.Context) (size int, err error) {
+	return a.remote.Size(ctx)
+}
+
+// Data returns a copy of all key-value pairs in L2 as map type.
+func (a *AdapterLayered) Data(ctx context.Context) (map[interface{}]interface{}, error) {
+	return a.remote.Data(ctx)
+}
+
+// Keys returns all keys in L2 as slice.
+func (a *AdapterLayered) Keys(ctx context.Context) ([]interface{}, error) {
+	return a.remote.Keys(ctx)
+}
+
+// Values returns all values in L2 as slice.
+func (a *AdapterLayered) Values(ctx context.Context) ([]interface{}, error) {
+	return a.remote.Values(ctx)
+}
+
+// Clear clears all data of both L1 and L2, and L1 of all other nodes.
+// Note that this function is sensitive and should be carefully used.
+func (a *AdapterLayered) Clear(ctx context.Context) error {
+	if err := a.remote.Clear(ctx); err != nil {
+		return err
+	}
+	a.clearLocal(ctx)
+	if a.bus != nil {
+		if err := a.bus.Publish(ctx, &BusMessage{Node: a.node, Clear: true}); err != nil {
+			intlog.Error(err)
+		}
+	}
+	return nil
+}
+
+// Close closes L1, L2 and the Bus.
+func (a *AdapterLayered) Close(ctx context.Context) error {
+	a.local.Close(ctx)
+	if a.bus != nil {
+		if err := a.bus.Close(); err != nil {
+			intlog.Error(err)
+		}
+	}
+	return a.remote.Close(ctx)
+}
+
+// setLocal sets <key>-<value> pair to L1 with a TTL no longer than L1TTL,
+// and returns the value stored in L1.
+func (a *AdapterLayered) setLocal(ctx context.Context, key interface{}, value interface{}, duration time.Duration) interface{} {
+	if value == nil || duration < 0 {
+		a.removeLocal(ctx, key)
+		return value
+	}
+	// Function value is not evaluated here, leave it to next Get from L2.
+	if _, ok := value.(func() (interface{}, error)); ok {
+		a.removeLocal(ctx, key)
+		return value
+	}
+	localValue, err := a.normalize(value)
+	if err != nil {
+		intlog.Error(err)
+		a.removeLocal(ctx, key)
+		return value
+	}
+	if duration == 0 || duration > a.options.L1TTL {
+		duration = a.options.L1TTL
+	}
+	a.local.Set(ctx, a.localKey(key), localValue, duration)
+	return localValue
+}
+
+// populateLocal sets <key>-<value> pair retrieved from L2 to L1 and returns the value
+// stored in L1. It does not populate L1 if any invalidation happened after <generation>,
+// as <value> might be retrieved before the invalidation.
+func (a *AdapterLayered) populateLocal(ctx context.Context, key interface{}, value interface{}, duration time.Duration, generation int64) interface{} {
+	if a.generation.Val() != generation {
+		if localValue, err := a.normalize(value); err == nil {
+			return localValue
+		}
+		return value
+	}
+	localValue := a.setLocal(ctx, key, value, duration)
+	// Drop it if an invalidation happened during the populating.
+	if a.generation.Val() != generation {
+		a.local.Remove(ctx, a.localKey(key))
+	}
+	return localValue
+}
+
+// normalize returns the Serializer round-trip form of <value>.
+func (a *AdapterLayered) normalize(value interface{}) (interface{}, error) {
+	data, err := a.options.Serializer.Serialize(value)
+	if err != nil {
+		return nil, err
+	}
+	return a.options.Serializer.Deserialize(data)
+}
+
+// removeLocal deletes <keys> from L1.
+func (a *AdapterLayered) removeLocal(ctx context.Context, keys ...interface{}) {
+	localKeys := make([]interface{}, len(keys))
+	for i, key := range keys {
+		localKeys[i] = a.localKey(key)
+	}
+	a.generation.Add(1)
+	a.local.Remove(ctx, localKeys...)
+}
+
+// clearLocal deletes all keys from L1.
+func (a *AdapterLayered) clearLocal(ctx context.Context) {
+	a.generation.Add(1)
+	a.local.Clear(ctx)
+}
+
+// publish broadcasts invalidation of <keys> to other nodes.
+// Note that failure of publishing does not fail the writing operation, which leads
+// other nodes serving stale values at most L1TTL.
+func (a *AdapterLayered) publish(ctx context.Context, keys ...interface{}) {
+	if a.bus == nil || len(keys) == 0 {
+		return
+	}
+	message := &BusMessage{
+		Node: a.node,
+		Keys: gconv.Strings(keys),
+	}
+	if err := a.bus.Publish(ctx, message); err != nil {
+		intlog.Error(err)
+	}
+}
+
+// handleBusMessage drops L1 entries according to invalidation <message> from other nodes.
+func (a *AdapterLayered) handleBusMessage(message *BusMessage) {
+	if message.Node == a.node {
+		return
+	}
+	ctx := context.Background()
+	if message.Clear {
+		a.clearLocal(ctx)
+		return
+	}
+	a.removeLocal(ctx, gconv.Interfaces(message.Keys)...)
+}
+
+// localKey converts <key> to the key of L1.
+func (a *AdapterLayered) localKey(key interface{}) interface{} {
+	return gconv.String(key)
+}
@@ -22,22 +22,45 @@ import (
 type adapterMemory struct {
 	// cap limits the size of the cache pool.
 	// If the size of the cache exceeds the cap,
-	// the cache expiration process performs according to the LRU algorithm.
+	// the cache expiration process performs according to the eviction policy.
 	// It is 0 in default which means no limits.
 	cap         int
-	data        *adapterMemoryData        // data is the underlying cache data which is stored in a hash table.
-	expireTimes *adapterMemoryExpireTimes // expireTimes is the expiring key to its timestamp mapping, which is used for quick indexing and deleting.
-	expireSets  *adapterMemoryExpireSets  // expireSets is the expiring timestamp to its key set mapping, which is used for quick indexing and deleting.
-	lru         *adapterMemoryLru         // lru is the LRU manager, which is enabled when attribute cap > 0.
-	lruGetList  *glist.List               // lruGetList is the LRU history according with Get function.
-	eventList   *glist.List               // eventList is the asynchronous event list for internal data synchronization.
-	closed      *gtype.Bool               // closed controls the cache closed or not.
+	maxBytes    int64                                               // maxBytes limits the total size of items calculated by the size function, 0 means no limits.
+	data        *adapterMemoryData                                  // data is the underlying cache data which is stored in a hash table.
+	expireTimes *adapterMemoryExpireTimes                           // expireTimes is the expiring key to its timestamp mapping, which is used for quick indexing and deleting.
+	expireSets  *adapterMemoryExpireSets                            // expireSets is the expiring timestamp to its key set mapping, which is used for quick indexing and deleting.
+	policy      adapterMemoryPolicy                                 // policy is the eviction policy, which is enabled when attribute cap > 0 or maxBytes > 0.
+	getList     *glist.List                                         // getList is the reading history according with Get function for eviction policy.
+	eventList   *glist.List                                         // eventList is the asynchronous event list for internal data synchronization.
+	onEvicted   func(key, value interface{}, reason EvictionReason) // onEvicted is the callback for deleted items.
+	closed      *gtype.Bool                                         // closed controls the cache closed or not.
+}
+
+// AdapterMemoryOptions is the options for memory adapter.
+type AdapterMemoryOptions struct {
+	// Cap limits the count of items, 0 means no limits.
+	Cap int
+
+	// MaxBytes limits the total size of items calculated by SizeFunc, 0 means no limits.
+	MaxBytes int64
+
+	// SizeFunc calculates the size of an item, which is required if MaxBytes > 0.
+	SizeFunc func(key, value interface{}) int64
+
+	// Policy specifies the eviction policy when Cap or MaxBytes is exceeded, which is LRU in default.
+	Policy EvictionPolicy
+
+	// OnEvicted is called after an item is deleted from the cache with the reason.
+	// Note that it is called asynchronously in the cleaning up goroutine for expired and
+	// evicted items, and synchronously in the calling goroutine for removed items.
+	OnEvicted func(key, value interface{}, reason EvictionReason)
 }
 
 // Internal cache item.
 type adapterMemoryItem struct {
 	v interface{} // Value.
 	e int64       // Expire timestamp in milliseconds.
+	s int64       // Size calculated by the size function.
 }
 
 // Internal event item.
@@ -52,19 +75,53 @@ const (
 	defaultMaxExpire = 9223372036854
 )
 
+// NewAdapterMemory creates and returns a new memory cache adapter, which can be composed
+// with other adapters. The optional parameter <lruCap> enables the LRU feature with given capacity.
+// Its asynchronous expiration and LRU task stops running when the adapter is closed.
+func NewAdapterMemory(lruCap ...int) Adapter {
+	options := AdapterMemoryOptions{}
+	if len(lruCap) > 0 {
+		options.Cap = lruCap[0]
+	}
+	return NewAdapterMemoryWithOptions(options)
+}
+
+// NewAdapterMemoryWithOptions creates and returns a new memory cache adapter with given <options>.
+// Its asynchronous expiration and eviction task stops running when the adapter is closed.
+//
+// It panics if <options.MaxBytes> is set without <options.SizeFunc>.
+func NewAdapterMemoryWithOptions(options AdapterMemoryOptions) Adapter {
+	memAdapter := newAdapterMemory(options)
+	gtimer.AddSingleton(time.Second, memAdapter.syncEventAndClearExpired)
+	return memAdapter
+}
+
 // newAdapterMemory creates and returns a new memory cache object.
-func newAdapterMemory(lruCap ...int) *adapterMemory {
+func newAdapterMemory(options ...AdapterMemoryOptions) *adapterMemory {
+	var option AdapterMemoryOptions
+	if len(options) > 0 {
+		option = options[0]
+	}
+	if option.MaxBytes > 0 && option.SizeFunc == nil {
+		panic("gcache: SizeFunc is required for MaxBytes of memory adapter")
+	}
 	c := &adapterMemory{
-		data:        newAdapterMemoryData(),
-		lruGetList:  glist.New(true),
+		cap:         option.Cap,
+		maxBytes:    option.MaxBytes,
+		getList:     glist.New(true),
 		expireTimes: newAdapterMemoryExpireTimes(),
 		expireSets:  newAdapterMemoryExpireSets(),
 		eventList:   glist.New(true),
+		onEvicted:   option.OnEvicted,
 		closed:      gtype.NewBool(),
 	}
-	if len(lruCap) > 0 {
-		c.cap = lruCap[0]
-		c.lru = newMemCacheLru(c)
+	if c.maxBytes > 0 {
+		c.data = newAdapterMemoryData(option.SizeFunc)
+	} else {
+		c.data = newAdapterMemoryData()
+	}
+	if c.cap > 0 || c.maxBytes > 0 {
+		c.policy = newAdapterMemoryPolicy(option.Policy, c.cap)
 	}
 	return c
 }
@@ -174,9 +231,9 @@ func (c *adapterMemory) Sets(ctx context.Context, data map[interface{}]interface
 func (c *adapterMemory) Get(ctx context.Context, key interface{}) (interface{}, error) {
 	item, ok := c.data.Get(key)
 	if ok && !item.IsExpired() {
-		// Adding to LRU history if LRU feature is enabled.
-		if c.cap > 0 {
-			c.lruGetList.PushBack(key)
+		// Adding to reading history if eviction feature is enabled.
+		if c.policy != nil {
+			c.getList.PushBack(key)
 		}
 		return item.v, nil
 	}
@@ -261,16 +318,19 @@ func (c *adapterMemory) Contains(ctx context.Context, key interface{}) (bool, er
 // Remove deletes the one or more keys from cache, and returns its value.
 // If multiple keys are given, it returns the value of the deleted last item.
 func (c *adapterMemory) Remove(ctx context.Context, keys ...interface{}) (value interface{}, err error) {
-	var removedKeys []interface{}
-	removedKeys, value, err = c.data.Remove(keys...)
+	removedKeys, removedItems, err := c.data.Remove(keys...)
 	if err != nil {
 		return
 	}
-	for _, key := range removedKeys {
+	for i, key := range removedKeys {
+		value = removedItems[i].v
 		c.eventList.PushBack(&adapterMemoryEvent{
 			k: key,
 			e: gtime.TimestampMilli() - 1000000,
 		})
+		if c.onEvicted != nil {
+			c.onEvicted(key, value, EvictionReasonRemoved)
+		}
 	}
 	return
 }
@@ -298,14 +358,24 @@ func (c *adapterMemory) Size(ctx context.Context) (size int, err error) {
 // Clear clears all data of the cache.
 // Note that this function is sensitive and should be carefully used.
 func (c *adapterMemory) Clear(ctx context.Context) error {
-	return c.data.Clear()
+	data, err := c.data.Clear()
+	if err != nil {
+		return err
+	}
+	for key, item := range data {
+		c.eventList.PushBack(&adapterMemoryEvent{
+			k: key,
+			e: gtime.TimestampMilli() - 1000000,
+		})
+		if c.onEvicted != nil {
+			c.onEvicted(key, item.v, EvictionReasonRemoved)
+		}
+	}
+	return nil
 }
 
 // Close closes the cache.
 func (c *adapterMemory) Close(ctx context.Context) error {
-	if c.cap > 0 {
-		c.lru.Close()
-	}
 	c.closed.Set(true)
 	return nil
 }
@@ -341,9 +411,11 @@ func (c *adapterMemory) makeExpireKey(expire int64) int64 {
 }
 
 // syncEventAndClearExpired does the asynchronous task loop:
-// 1. Asynchronously process the data in the event list,
-//    and synchronize the results to the <expireTimes> and <expireSets> properties.
-// 2. Clean up the expired key-value pair data.
+//  1. Asynchronously process the data in the event list,
+//     and synchronize the results to the <expireTimes>, <expireSets> and <policy> properties.
+//  2. Clean up the expired key-value pair data.
+//  3. Evict the key-value pair data according to the eviction policy if the cache exceeds
+//     its capacity or bytes limit.
 func (c *adapterMemory) syncEventAndClearExpired() {
 	if c.closed.Val() {
 		gtimer.Exit()
@@ -365,6 +437,20 @@ func (c *adapterMemory) syncEventAndClearExpired() {
 		event = v.(*adapterMemoryEvent)
 		// Fetching the old expire set.
 		oldExpireTime = c.expireTimes.Get(event.k)
+		// The key is already deleted, removing its indexes.
+		item, ok := c.data.Get(event.k)
+		if !ok {
+			if oldExpireTime != 0 {
+				c.expireSets.GetOrNew(oldExpireTime).Remove(event.k)
+				c.expireTimes.Delete(event.k)
+			}
+			if c.policy != nil {
+				c.policy.Remove(event.k)
+			}
+			continue
+		}
+		// Using the expire time of current item, as the events may be out of order.
+		event.e = item.e
 		// Calculating the new expire set.
 		newExpireTime = c.makeExpireKey(event.e)
 		if newExpireTime != oldExpireTime {
@@ -375,16 +461,16 @@ func (c *adapterMemory) syncEventAndClearExpired() {
 			// Updating the expire time for <event.k>.
 			c.expireTimes.Set(event.k, newExpireTime)
 		}
-		// Adding the key the LRU history by writing operations.
-		if c.cap > 0 {
-			c.lru.Push(event.k)
+		// Adding the key to the eviction policy by writing operations.
+		if c.policy != nil {
+			c.policy.Add(event.k)
 		}
 	}
-	// Processing expired keys from LRU.
-	if c.cap > 0 && c.lruGetList.Len() > 0 {
+	// Processing reading history for eviction policy.
+	if c.policy != nil && c.getList.Len() > 0 {
 		for {
-			if v := c.lruGetList.PopFront(); v != nil {
-				c.lru.Push(v)
+			if v := c.getList.PopFront(); v != nil {
+				c.policy.Touch(v)
 			} else {
 				break
 			}
@@ -402,26 +488,53 @@ func (c *adapterMemory) syncEventAndClearExpired() {
 		if expireSet = c.expireSets.Get(expireTime); expireSet != nil {
 			// Iterating the set to delete all keys in it.
 			expireSet.Iterator(func(key interface{}) bool {
-				c.clearByKey(key)
+				c.clearByKey(key, EvictionReasonExpired)
 				return true
 			})
 			// Deleting the set after all of its keys are deleted.
 			c.expireSets.Delete(expireTime)
 		}
 	}
+	// ========================
+	// Data Evicting.
+	// ========================
+	if c.policy == nil {
+		return
+	}
+	for (c.cap > 0 && c.policy.Len() > c.cap) || (c.maxBytes > 0 && c.data.Bytes() > c.maxBytes) {
+		key, ok := c.policy.Victim()
+		if !ok {
+			break
+		}
+		c.clearByKey(key, EvictionReasonEvicted)
+	}
 }
 
-// clearByKey deletes the key-value pair with given <key>.
-// The parameter <force> specifies whether doing this deleting forcibly.
-func (c *adapterMemory) clearByKey(key interface{}, force ...bool) {
+// clearByKey deletes the key-value pair with given <key> for <reason>.
+// The expired items are doubly checked before deleting, and the evicted ones are deleted forcibly.
+func (c *adapterMemory) clearByKey(key interface{}, reason EvictionReason) {
 	// Doubly check before really deleting it from cache.
-	c.data.DeleteWithDoubleCheck(key, force...)
-
-	// Deleting its expire time from <expireTimes>.
+	item, ok := c.data.DeleteWithDoubleCheck(key, reason == EvictionReasonEvicted)
+	if !ok && reason == EvictionReasonExpired {
+		// It is refreshed with new expiration or already deleted.
+		return
+	}
+	// Deleting its expire time from <expireTimes>, and from <expireSets> for evicted one,
+	// as the expire set of expired one is deleted after iterating.
+	if reason == EvictionReasonEvicted {
+		if expireTime := c.expireTimes.Get(key); expireTime != 0 {
+			if set := c.expireSets.Get(expireTime); set != nil {
+				set.Remove(key)
+			}
+		}
+	}
 	c.expireTimes.Delete(key)
 
-	// Deleting it from LRU.
-	if c.cap > 0 {
-		c.lru.Remove(key)
+	// Deleting it from eviction policy.
+	if c.policy != nil {
+		c.policy.Remove(key)
+	}
+	if ok && c.onEvicted != nil {
+		c.onEvicted(key, item.v, reason)
 	}
 }
@@ -0,0 +1,124 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+// ARC (Adaptive Replacement Cache) eviction policy.
+//
+// Keys seen once are kept in <t1> and keys seen at least twice in <t2>. The evicted keys
+// are remembered in the ghost lists <b1> and <b2>, hits on which adapt the target size <p>
+// of <t1>, so that it balances between recency and frequency according to the workload.
+type adapterMemoryArc struct {
+	cap int               // Capacity by count, 0 means using current size.
+	p   int               // Target size of t1.
+	t1  *adapterMemoryLru // Recent keys seen once.
+	t2  *adapterMemoryLru // Frequent keys seen at least twice.
+	b1  *adapterMemoryLru // Ghost keys evicted from t1.
+	b2  *adapterMemoryLru // Ghost keys evicted from t2.
+}
+
+// newAdapterMemoryArc creates and returns a new ARC policy.
+func newAdapterMemoryArc(cap int) *adapterMemoryArc {
+	return &adapterMemoryArc{
+		cap: cap,
+		t1:  newAdapterMemoryLru(),
+		t2:  newAdapterMemoryLru(),
+		b1:  newAdapterMemoryLru(),
+		b2:  newAdapterMemoryLru(),
+	}
+}
+
+// Add records <key> being written, adapting the target size if it hits the ghost lists.
+func (arc *adapterMemoryArc) Add(key interface{}) {
+	switch {
+	case arc.t1.Contains(key), arc.t2.Contains(key):
+		arc.Touch(key)
+		return
+
+	case arc.b1.Contains(key):
+		delta := 1
+		if arc.b1.Len() < arc.b2.Len() {
+			delta = arc.b2.Len() / arc.b1.Len()
+		}
+		if arc.p += delta; arc.p > arc.capacity() {
+			arc.p = arc.capacity()
+		}
+		arc.b1.Remove(key)
+		arc.t2.Add(key)
+
+	case arc.b2.Contains(key):
+		delta := 1
+		if arc.b2.Len() < arc.b1.Len() {
+			delta = arc.b1.Len() / arc.b2.Len()
+		}
+		if arc.p -= delta; arc.p < 0 {
+			arc.p = 0
+		}
+		arc.b2.Remove(key)
+		arc.t2.Add(key)
+
+	default:
+		arc.t1.Add(key)
+	}
+	arc.trimGhosts()
+}
+
+// Touch moves <key> to the head of t2 if it exists.
+func (arc *adapterMemoryArc) Touch(key interface{}) {
+	if arc.t1.Contains(key) {
+		arc.t1.Remove(key)
+		arc.t2.Add(key)
+		return
+	}
+	arc.t2.Touch(key)
+}
+
+// Remove deletes <key> from the policy.
+func (arc *adapterMemoryArc) Remove(key interface{}) {
+	arc.t1.Remove(key)
+	arc.t2.Remove(key)
+}
+
+// Victim deletes and returns the key that should be evicted, remembering it in the ghost list.
+func (arc *adapterMemoryArc) Victim() (key interface{}, ok bool) {
+	if arc.t1.Len() > 0 && (arc.t1.Len() > arc.p || arc.t2.Len() == 0) {
+		if key, ok = arc.t1.Pop(); ok {
+			arc.b1.Add(key)
+		}
+	} else if key, ok = arc.t2.Pop(); ok {
+		arc.b2.Add(key)
+	}
+	arc.trimGhosts()
+	return
+}
+
+// Len returns the number of keys in the policy.
+func (arc *adapterMemoryArc) Len() int {
+	return arc.t1.Len() + arc.t2.Len()
+}
+
+// capacity returns the capacity for adapting.
+func (arc *adapterMemoryArc) capacity() int {
+	if arc.cap > 0 {
+		return arc.cap
+	}
+	if n := arc.Len(); n > 1 {
+		return n
+	}
+	return 1
+}
+
+// trimGhosts limits the sizes of ghost lists, which keeps |t1|+|b1| <= c and
+// |t1|+|t2|+|b1|+|b2| <= 2c.
+func (arc *adapterMemoryArc) trimGhosts() {
+	c := arc.capacity()
+	for arc.b1.Len() > 0 && arc.t1.Len()+arc.b1.Len() > c {
+		arc.b1.Pop()
+	}
+	for arc.b2.Len() > 0 && arc.Len()+arc.b1.Len()+arc.b2.Len() > 2*c {
+		arc.b2.Pop()
+	}
+}
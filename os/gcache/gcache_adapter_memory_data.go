@@ -13,14 +13,20 @@ import (
 )
 
 type adapterMemoryData struct {
-	mu   sync.RWMutex                      // dataMu ensures the concurrent safety of underlying data map.
-	data map[interface{}]adapterMemoryItem // data is the underlying cache data which is stored in a hash table.
+	mu       sync.RWMutex                       // dataMu ensures the concurrent safety of underlying data map.
+	data     map[interface{}]adapterMemoryItem  // data is the underlying cache data which is stored in a hash table.
+	bytes    int64                              // bytes is the total size of items calculated by sizeFunc.
+	sizeFunc func(key, value interface{}) int64 // sizeFunc calculates the size of item, it's nil if bytes limit is disabled.
 }
 
-func newAdapterMemoryData() *adapterMemoryData {
-	return &adapterMemoryData{
+func newAdapterMemoryData(sizeFunc ...func(key, value interface{}) int64) *adapterMemoryData {
+	d := &adapterMemoryData{
 		data: make(map[interface{}]adapterMemoryItem),
 	}
+	if len(sizeFunc) > 0 {
+		d.sizeFunc = sizeFunc[0]
+	}
+	return d
 }
 
 // Update updates the value of <key> without changing its expiration and returns the old value.
@@ -32,10 +38,10 @@ func (d *adapterMemoryData) Update(key interface{}, value interface{}) (oldValue
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	if item, ok := d.data[key]; ok {
-		d.data[key] = adapterMemoryItem{
+		d.doSet(key, adapterMemoryItem{
 			v: value,
 			e: item.e,
-		}
+		})
 		return item.v, true, nil
 	}
 	return nil, false, nil
@@ -52,27 +58,28 @@ func (d *adapterMemoryData) UpdateExpire(key interface{}, expireTime int64) (old
 		d.data[key] = adapterMemoryItem{
 			v: item.v,
 			e: expireTime,
+			s: item.s,
 		}
 		return time.Duration(item.e-gtime.TimestampMilli()) * time.Millisecond, nil
 	}
 	return -1, nil
 }
 
-// Remove deletes the one or more keys from cache, and returns its value.
-// If multiple keys are given, it returns the value of the deleted last item.
-func (d *adapterMemoryData) Remove(keys ...interface{}) (removedKeys []interface{}, value interface{}, err error) {
+// Remove deletes the one or more keys from cache, and returns the deleted items.
+func (d *adapterMemoryData) Remove(keys ...interface{}) (removedKeys []interface{}, removedItems []adapterMemoryItem, err error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	removedKeys = make([]interface{}, 0)
+	removedItems = make([]adapterMemoryItem, 0)
 	for _, key := range keys {
 		item, ok := d.data[key]
 		if ok {
-			value = item.v
-			delete(d.data, key)
+			d.doDelete(key, item)
 			removedKeys = append(removedKeys, key)
+			removedItems = append(removedItems, item)
 		}
 	}
-	return removedKeys, value, nil
+	return removedKeys, removedItems, nil
 }
 
 // Data returns a copy of all key-value pairs in the cache as map type.
@@ -130,13 +137,23 @@ func (d *adapterMemoryData) Size() (size int, err error) {
 	return size, nil
 }
 
-// Clear clears all data of the cache.
+// Bytes returns the total size of items calculated by the size function.
+func (d *adapterMemoryData) Bytes() int64 {
+	d.mu.RLock()
+	bytes := d.bytes
+	d.mu.RUnlock()
+	return bytes
+}
+
+// Clear clears all data of the cache and returns the cleared data.
 // Note that this function is sensitive and should be carefully used.
-func (d *adapterMemoryData) Clear() error {
+func (d *adapterMemoryData) Clear() (map[interface{}]adapterMemoryItem, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	data := d.data
 	d.data = make(map[interface{}]adapterMemoryItem)
-	return nil
+	d.bytes = 0
+	return data, nil
 }
 
 func (d *adapterMemoryData) Get(key interface{}) (item adapterMemoryItem, ok bool) {
@@ -148,7 +165,7 @@ func (d *adapterMemoryData) Get(key interface{}) (item adapterMemoryItem, ok boo
 
 func (d *adapterMemoryData) Set(key interface{}, value adapterMemoryItem) {
 	d.mu.Lock()
-	d.data[key] = value
+	d.doSet(key, value)
 	d.mu.Unlock()
 }
 
@@ -159,10 +176,10 @@ func (d *adapterMemoryData) Set(key interface{}, value adapterMemoryItem) {
 func (d *adapterMemoryData) Sets(data map[interface{}]interface{}, expireTime int64) error {
 	d.mu.Lock()
 	for k, v := range data {
-		d.data[k] = adapterMemoryItem{
+		d.doSet(k, adapterMemoryItem{
 			v: v,
 			e: expireTime,
-		}
+		})
 	}
 	d.mu.Unlock()
 	return nil
@@ -185,15 +202,37 @@ func (d *adapterMemoryData) SetWithLock(key interface{}, value interface{}, expi
 			value = v
 		}
 	}
-	d.data[key] = adapterMemoryItem{v: value, e: expireTimestamp}
+	d.doSet(key, adapterMemoryItem{v: value, e: expireTimestamp})
 	return value, nil
 }
 
-func (d *adapterMemoryData) DeleteWithDoubleCheck(key interface{}, force ...bool) {
+// DeleteWithDoubleCheck deletes <key> if it is expired, or forcibly if <force> is true.
+// It returns the deleted item and true if the <key> is deleted.
+func (d *adapterMemoryData) DeleteWithDoubleCheck(key interface{}, force ...bool) (adapterMemoryItem, bool) {
 	d.mu.Lock()
+	defer d.mu.Unlock()
 	// Doubly check before really deleting it from cache.
-	if item, ok := d.data[key]; (ok && item.IsExpired()) || (len(force) > 0 && force[0]) {
-		delete(d.data, key)
+	if item, ok := d.data[key]; ok && (item.IsExpired() || (len(force) > 0 && force[0])) {
+		d.doDelete(key, item)
+		return item, true
 	}
-	d.mu.Unlock()
+	return adapterMemoryItem{}, false
+}
+
+// doSet sets <item> for <key> and updates the total bytes, which should be called within writing lock.
+func (d *adapterMemoryData) doSet(key interface{}, item adapterMemoryItem) {
+	if d.sizeFunc != nil {
+		item.s = d.sizeFunc(key, item.v)
+		if old, ok := d.data[key]; ok {
+			d.bytes -= old.s
+		}
+		d.bytes += item.s
+	}
+	d.data[key] = item
+}
+
+// doDelete deletes <key> and updates the total bytes, which should be called within writing lock.
+func (d *adapterMemoryData) doDelete(key interface{}, item adapterMemoryItem) {
+	d.bytes -= item.s
+	delete(d.data, key)
 }
@@ -0,0 +1,121 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"container/heap"
+)
+
+// LFU eviction policy.
+// It uses a min-heap ordered by access frequency, and by access sequence for keys of
+// the same frequency, which evicts the least recently used one among them.
+type adapterMemoryLfu struct {
+	data     map[interface{}]*adapterMemoryLfuItem // Key mapping to the item of the heap.
+	items    adapterMemoryLfuHeap                  // Min-heap of items.
+	sequence uint64                                // Increasing access sequence.
+}
+
+// adapterMemoryLfuItem is the heap item of LFU policy.
+type adapterMemoryLfuItem struct {
+	key      interface{} // Key.
+	freq     uint64      // Access frequency.
+	sequence uint64      // Last access sequence.
+	index    int         // Index in the heap.
+}
+
+// adapterMemoryLfuHeap implements heap.Interface for LFU items.
+type adapterMemoryLfuHeap []*adapterMemoryLfuItem
+
+// newAdapterMemoryLfu creates and returns a new LFU policy.
+func newAdapterMemoryLfu() *adapterMemoryLfu {
+	return &adapterMemoryLfu{
+		data:  make(map[interface{}]*adapterMemoryLfuItem),
+		items: make(adapterMemoryLfuHeap, 0),
+	}
+}
+
+// Add records an access of <key>, adding it if it does not exist.
+func (lfu *adapterMemoryLfu) Add(key interface{}) {
+	lfu.sequence++
+	if item, ok := lfu.data[key]; ok {
+		item.freq++
+		item.sequence = lfu.sequence
+		heap.Fix(&lfu.items, item.index)
+		return
+	}
+	item := &adapterMemoryLfuItem{
+		key:      key,
+		freq:     1,
+		sequence: lfu.sequence,
+	}
+	lfu.data[key] = item
+	heap.Push(&lfu.items, item)
+}
+
+// Touch records an access of <key> if it exists.
+func (lfu *adapterMemoryLfu) Touch(key interface{}) {
+	if _, ok := lfu.data[key]; ok {
+		lfu.Add(key)
+	}
+}
+
+// Remove deletes <key> from <lfu>.
+func (lfu *adapterMemoryLfu) Remove(key interface{}) {
+	if item, ok := lfu.data[key]; ok {
+		heap.Remove(&lfu.items, item.index)
+		delete(lfu.data, key)
+	}
+}
+
+// Victim deletes and returns the least frequently used key.
+func (lfu *adapterMemoryLfu) Victim() (interface{}, bool) {
+	if len(lfu.items) == 0 {
+		return nil, false
+	}
+	item := heap.Pop(&lfu.items).(*adapterMemoryLfuItem)
+	delete(lfu.data, item.key)
+	return item.key, true
+}
+
+// Len returns the size of <lfu>.
+func (lfu *adapterMemoryLfu) Len() int {
+	return len(lfu.data)
+}
+
+func (h adapterMemoryLfuHeap) Len() int {
+	return len(h)
+}
+
+func (h adapterMemoryLfuHeap) Less(i, j int) bool {
+	if h[i].freq == h[j].freq {
+		return h[i].sequence < h[j].sequence
+	}
+	return h[i].freq < h[j].freq
+}
+
+func (h adapterMemoryLfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *adapterMemoryLfuHeap) Push(x interface{}) {
+	item := x.(*adapterMemoryLfuItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *adapterMemoryLfuHeap) Pop() interface{} {
+	var (
+		old  = *h
+		n    = len(old)
+		item = old[n-1]
+	)
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
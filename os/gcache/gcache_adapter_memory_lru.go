@@ -7,102 +7,87 @@
 package gcache
 
 import (
-	"time"
-
-	"github.com/gogf/gf/container/glist"
-	"github.com/gogf/gf/container/gmap"
-	"github.com/gogf/gf/container/gtype"
-	"github.com/gogf/gf/os/gtimer"
+	"container/list"
 )
 
-// LRU cache object.
-// It uses list.List from stdlib for its underlying doubly linked list.
+// LRU eviction policy.
+// It uses list.List from stdlib for its underlying doubly linked list, the front of which
+// is the most recently used key.
 type adapterMemoryLru struct {
-	cache   *adapterMemory // Parent cache object.
-	data    *gmap.Map      // Key mapping to the item of the list.
-	list    *glist.List    // Key list.
-	rawList *glist.List    // History for key adding.
-	closed  *gtype.Bool    // Closed or not.
+	data map[interface{}]*list.Element // Key mapping to the item of the list.
+	list *list.List                    // Key list.
 }
 
-// newMemCacheLru creates and returns a new LRU object.
-func newMemCacheLru(cache *adapterMemory) *adapterMemoryLru {
-	lru := &adapterMemoryLru{
-		cache:   cache,
-		data:    gmap.New(true),
-		list:    glist.New(true),
-		rawList: glist.New(true),
-		closed:  gtype.NewBool(),
+// newAdapterMemoryLru creates and returns a new LRU policy.
+func newAdapterMemoryLru() *adapterMemoryLru {
+	return &adapterMemoryLru{
+		data: make(map[interface{}]*list.Element),
+		list: list.New(),
 	}
-	gtimer.AddSingleton(time.Second, lru.SyncAndClear)
-	return lru
 }
 
-// Close closes the LRU object.
-func (lru *adapterMemoryLru) Close() {
-	lru.closed.Set(true)
+// Add pushes <key> to the head of <lru>.
+func (lru *adapterMemoryLru) Add(key interface{}) {
+	if e, ok := lru.data[key]; ok {
+		lru.list.MoveToFront(e)
+		return
+	}
+	lru.data[key] = lru.list.PushFront(key)
 }
 
-// Remove deletes the <key> FROM <lru>.
-func (lru *adapterMemoryLru) Remove(key interface{}) {
-	if v := lru.data.Get(key); v != nil {
-		lru.data.Remove(key)
-		lru.list.Remove(v.(*glist.Element))
+// Touch moves <key> to the head of <lru> if it exists.
+func (lru *adapterMemoryLru) Touch(key interface{}) {
+	if e, ok := lru.data[key]; ok {
+		lru.list.MoveToFront(e)
 	}
 }
 
-// Size returns the size of <lru>.
-func (lru *adapterMemoryLru) Size() int {
-	return lru.data.Size()
+// Remove deletes the <key> from <lru>.
+func (lru *adapterMemoryLru) Remove(key interface{}) {
+	if e, ok := lru.data[key]; ok {
+		delete(lru.data, key)
+		lru.list.Remove(e)
+	}
 }
 
-// Push pushes <key> to the tail of <lru>.
-func (lru *adapterMemoryLru) Push(key interface{}) {
-	lru.rawList.PushBack(key)
+// Victim deletes and returns the key from tail of <lru>.
+func (lru *adapterMemoryLru) Victim() (interface{}, bool) {
+	return lru.Pop()
 }
 
 // Pop deletes and returns the key from tail of <lru>.
-func (lru *adapterMemoryLru) Pop() interface{} {
-	if v := lru.list.PopBack(); v != nil {
-		lru.data.Remove(v)
-		return v
+func (lru *adapterMemoryLru) Pop() (interface{}, bool) {
+	if e := lru.list.Back(); e != nil {
+		lru.list.Remove(e)
+		delete(lru.data, e.Value)
+		return e.Value, true
 	}
-	return nil
+	return nil, false
 }
 
-// Print is used for test only.
-//func (lru *adapterMemoryLru) Print() {
-//    for _, v := range lru.list.FrontAll() {
-//        fmt.Printf("%v ", v)
-//    }
-//    fmt.Println()
-//}
-
-// SyncAndClear synchronizes the keys from <rawList> to <list> and <data>
-// using Least Recently Used algorithm.
-func (lru *adapterMemoryLru) SyncAndClear() {
-	if lru.closed.Val() {
-		gtimer.Exit()
-		return
+// Front returns the key from head of <lru> without deleting it.
+func (lru *adapterMemoryLru) Front() (interface{}, bool) {
+	if e := lru.list.Front(); e != nil {
+		return e.Value, true
 	}
-	// Data synchronization.
-	for {
-		if v := lru.rawList.PopFront(); v != nil {
-			// Deleting the key from list.
-			if v := lru.data.Get(v); v != nil {
-				lru.list.Remove(v.(*glist.Element))
-			}
-			// Pushing key to the head of the list
-			// and setting its list item to hash table for quick indexing.
-			lru.data.Set(v, lru.list.PushFront(v))
-		} else {
-			break
-		}
-	}
-	// Data cleaning up.
-	for i := lru.Size() - lru.cache.cap; i > 0; i-- {
-		if s := lru.Pop(); s != nil {
-			lru.cache.clearByKey(s, true)
-		}
+	return nil, false
+}
+
+// Back returns the key from tail of <lru> without deleting it.
+func (lru *adapterMemoryLru) Back() (interface{}, bool) {
+	if e := lru.list.Back(); e != nil {
+		return e.Value, true
 	}
+	return nil, false
+}
+
+// Contains checks whether <key> exists in <lru>.
+func (lru *adapterMemoryLru) Contains(key interface{}) bool {
+	_, ok := lru.data[key]
+	return ok
+}
+
+// Len returns the size of <lru>.
+func (lru *adapterMemoryLru) Len() int {
+	return len(lru.data)
 }
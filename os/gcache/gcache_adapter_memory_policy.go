@@ -0,0 +1,76 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+// EvictionPolicy specifies the algorithm choosing items to evict when the memory cache
+// exceeds its capacity or bytes limit.
+type EvictionPolicy int
+
+// EvictionReason is the reason why an item is deleted from the memory cache.
+type EvictionReason int
+
+const (
+	EvictionLRU     EvictionPolicy = iota // Least Recently Used, which is the default policy.
+	EvictionLFU                           // Least Frequently Used, with LRU for items of the same frequency.
+	EvictionTinyLFU                       // Window TinyLFU, which admits items into main space by frequency sketch, resisting scans.
+	EvictionARC                           // Adaptive Replacement Cache, which balances recency and frequency adaptively.
+)
+
+const (
+	EvictionReasonExpired EvictionReason = iota + 1 // The item is expired.
+	EvictionReasonEvicted                           // The item is evicted by the eviction policy.
+	EvictionReasonRemoved                           // The item is removed manually.
+)
+
+// adapterMemoryPolicy is the interface for eviction policies of memory adapter.
+//
+// Note that the policies are not concurrent-safe, as they are only operated in the
+// asynchronous synchronization task of the memory adapter.
+type adapterMemoryPolicy interface {
+	// Add records <key> being written, which may be a new key or an existing one.
+	Add(key interface{})
+
+	// Touch records <key> being read.
+	Touch(key interface{})
+
+	// Remove deletes <key> from the policy.
+	Remove(key interface{})
+
+	// Victim deletes and returns the key that should be evicted.
+	Victim() (key interface{}, ok bool)
+
+	// Len returns the number of keys in the policy.
+	Len() int
+}
+
+// String returns the readable name of the reason.
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictionReasonExpired:
+		return "expired"
+	case EvictionReasonEvicted:
+		return "evicted"
+	case EvictionReasonRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// newAdapterMemoryPolicy creates and returns the eviction policy of <policy> for
+// capacity <cap>, in which 0 means the capacity is not limited by count.
+func newAdapterMemoryPolicy(policy EvictionPolicy, cap int) adapterMemoryPolicy {
+	switch policy {
+	case EvictionLFU:
+		return newAdapterMemoryLfu()
+	case EvictionTinyLFU:
+		return newAdapterMemoryTinyLfu(cap)
+	case EvictionARC:
+		return newAdapterMemoryArc(cap)
+	default:
+		return newAdapterMemoryLru()
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"github.com/gogf/gf/encoding/ghash"
+	"github.com/gogf/gf/util/gconv"
+)
+
+// adapterMemorySketch is a Count-Min sketch estimating the access frequency of keys
+// with small memory usage, which is used as the admission filter of TinyLFU.
+//
+// Its counters are halved after every <sampleSize> increments, which makes the
+// frequency of keys decays over time.
+type adapterMemorySketch struct {
+	rows       [adapterMemorySketchDepth][]uint8 // Counter rows.
+	mask       uint64                            // Mask for counter index, which is width - 1.
+	additions  int                               // Increments since last reset.
+	sampleSize int                               // Increments triggering the counters halving.
+}
+
+const (
+	adapterMemorySketchDepth    = 4    // Number of hash rows.
+	adapterMemorySketchMaxCount = 15   // Maximum value of a counter.
+	adapterMemorySketchMinWidth = 1024 // Minimum width of the rows.
+)
+
+// newAdapterMemorySketch creates and returns a sketch for about <size> keys.
+func newAdapterMemorySketch(size int) *adapterMemorySketch {
+	width := adapterMemorySketchMinWidth
+	for width < size {
+		width <<= 1
+	}
+	s := &adapterMemorySketch{
+		mask:       uint64(width - 1),
+		sampleSize: width * 10,
+	}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// Increment increases the frequency of <key>.
+func (s *adapterMemorySketch) Increment(key interface{}) {
+	h1, h2 := s.hash(key)
+	for i := range s.rows {
+		index := (h1 + uint64(i)*h2) & s.mask
+		if s.rows[i][index] < adapterMemorySketchMaxCount {
+			s.rows[i][index]++
+		}
+	}
+	s.additions++
+	if s.additions >= s.sampleSize {
+		s.reset()
+	}
+}
+
+// Estimate returns the estimated frequency of <key>.
+func (s *adapterMemorySketch) Estimate(key interface{}) uint8 {
+	var (
+		h1, h2 = s.hash(key)
+		min    = uint8(adapterMemorySketchMaxCount)
+	)
+	for i := range s.rows {
+		if v := s.rows[i][(h1+uint64(i)*h2)&s.mask]; v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// reset halves all the counters.
+func (s *adapterMemorySketch) reset() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
+
+// hash returns two hash values of <key> for double hashing.
+func (s *adapterMemorySketch) hash(key interface{}) (uint64, uint64) {
+	h := ghash.BKDRHash64([]byte(gconv.String(key)))
+	// Mixing the bits using the finalizer of splitmix64.
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h, (h >> 32) | 1
+}
@@ -0,0 +1,142 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+// Window TinyLFU eviction policy.
+//
+// New keys are added to a small LRU window, which overflows into the probation segment of
+// the main space. Keys accessed in probation are promoted to the protected segment. When
+// evicting, the newest key of probation (the candidate admitted from the window) competes
+// with the oldest one (the victim) by their estimated frequencies, and the loser is evicted,
+// which keeps one-hit keys of scans from flushing the frequently used keys.
+type adapterMemoryTinyLfu struct {
+	cap       int                  // Capacity by count, 0 means sizing segments by current size.
+	sketch    *adapterMemorySketch // Frequency sketch.
+	window    *adapterMemoryLru    // Admission window.
+	probation *adapterMemoryLru    // Probation segment of main space.
+	protected *adapterMemoryLru    // Protected segment of main space.
+}
+
+const (
+	adapterMemoryTinyLfuWindowPercent    = 1  // Percentage of window size in total.
+	adapterMemoryTinyLfuProtectedPercent = 80 // Percentage of protected size in main space.
+	adapterMemoryTinyLfuDefaultSize      = 1 << 16
+)
+
+// newAdapterMemoryTinyLfu creates and returns a new W-TinyLFU policy.
+func newAdapterMemoryTinyLfu(cap int) *adapterMemoryTinyLfu {
+	sketchSize := cap
+	if sketchSize <= 0 {
+		sketchSize = adapterMemoryTinyLfuDefaultSize
+	}
+	return &adapterMemoryTinyLfu{
+		cap:       cap,
+		sketch:    newAdapterMemorySketch(sketchSize),
+		window:    newAdapterMemoryLru(),
+		probation: newAdapterMemoryLru(),
+		protected: newAdapterMemoryLru(),
+	}
+}
+
+// Add records <key> being written, adding it to the window if it does not exist.
+func (p *adapterMemoryTinyLfu) Add(key interface{}) {
+	p.sketch.Increment(key)
+	if !p.access(key) {
+		p.window.Add(key)
+		for p.window.Len() > p.windowCap() {
+			k, _ := p.window.Pop()
+			p.probation.Add(k)
+		}
+	}
+}
+
+// Touch records <key> being read.
+func (p *adapterMemoryTinyLfu) Touch(key interface{}) {
+	p.sketch.Increment(key)
+	p.access(key)
+}
+
+// Remove deletes <key> from the policy.
+func (p *adapterMemoryTinyLfu) Remove(key interface{}) {
+	p.window.Remove(key)
+	p.probation.Remove(key)
+	p.protected.Remove(key)
+}
+
+// Victim deletes and returns the key that should be evicted.
+func (p *adapterMemoryTinyLfu) Victim() (interface{}, bool) {
+	if p.probation.Len() > 1 {
+		candidate, _ := p.probation.Front()
+		victim, _ := p.probation.Back()
+		if p.sketch.Estimate(candidate) > p.sketch.Estimate(victim) {
+			p.probation.Remove(victim)
+			return victim, true
+		}
+		p.probation.Remove(candidate)
+		return candidate, true
+	}
+	if key, ok := p.probation.Pop(); ok {
+		return key, true
+	}
+	if key, ok := p.protected.Pop(); ok {
+		return key, true
+	}
+	return p.window.Pop()
+}
+
+// Len returns the number of keys in the policy.
+func (p *adapterMemoryTinyLfu) Len() int {
+	return p.window.Len() + p.probation.Len() + p.protected.Len()
+}
+
+// access updates the position of existing <key>, it returns false if <key> does not exist.
+func (p *adapterMemoryTinyLfu) access(key interface{}) bool {
+	switch {
+	case p.window.Contains(key):
+		p.window.Touch(key)
+
+	case p.probation.Contains(key):
+		// Promoting the key to protected segment.
+		p.probation.Remove(key)
+		p.protected.Add(key)
+		for p.protected.Len() > p.protectedCap() {
+			k, _ := p.protected.Pop()
+			p.probation.Add(k)
+		}
+
+	case p.protected.Contains(key):
+		p.protected.Touch(key)
+
+	default:
+		return false
+	}
+	return true
+}
+
+// windowCap returns the capacity of the window.
+func (p *adapterMemoryTinyLfu) windowCap() int {
+	size := p.cap
+	if size <= 0 {
+		size = p.Len()
+	}
+	if n := size * adapterMemoryTinyLfuWindowPercent / 100; n > 1 {
+		return n
+	}
+	return 1
+}
+
+// protectedCap returns the capacity of the protected segment.
+func (p *adapterMemoryTinyLfu) protectedCap() int {
+	size := p.cap - p.windowCap()
+	if p.cap <= 0 {
+		size = p.probation.Len() + p.protected.Len()
+	}
+	if n := size * adapterMemoryTinyLfuProtectedPercent / 100; n > 1 {
+		return n
+	}
+	return 1
+}
@@ -0,0 +1,569 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/database/gredis"
+	"github.com/gogf/gf/util/gconv"
+	"github.com/gomodule/redigo/redis"
+)
+
+// AdapterRedis is the gcache adapter implements using Redis server.
+//
+// All keys are stored with a namespace prefix, so that multiple caches can share one
+// redis database, and the Keys/Data/Size/Clear operations only act on keys of the prefix.
+// Values are encoded with a Serializer, which is JSON in default. String keys are stored
+// as they are, and keys of other types are stored with a type tag, so that Keys/Data return
+// keys of their original types like the memory adapter does.
+type AdapterRedis struct {
+	redis      *gredis.Redis // Redis client.
+	prefix     string        // Namespace prefix for all keys.
+	serializer Serializer    // Serializer for values.
+	lockTTL    time.Duration // Lease time of the distributed lock for GetOrSetFuncLock.
+}
+
+const (
+	defaultAdapterRedisLockTTL    = 10 * time.Second
+	defaultAdapterRedisScanCount  = 1000
+	adapterRedisLockKeySuffix     = ":gcache-lock"
+	adapterRedisMatchSpecialChars = `*?[]\`
+)
+
+var (
+	// adapterRedisUpdateScript updates the value of KEYS[1] keeping its TTL,
+	// and returns the old value, or nil if it does not exist.
+	adapterRedisUpdateScript = redis.NewScript(1, `
+local old = redis.call("GET", KEYS[1])
+if old == false then
+	return nil
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl > 0 then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ttl)
+else
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+return old
+`)
+
+	// adapterRedisUpdateExpireScript updates the TTL of KEYS[1] with ARGV[1] in milliseconds,
+	// and returns its old TTL. ARGV[1] < 0 deletes the key, and ARGV[1] == 0 persists it.
+	adapterRedisUpdateExpireScript = redis.NewScript(1, `
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl == -2 then
+	return ttl
+end
+local expire = tonumber(ARGV[1])
+if expire < 0 then
+	redis.call("DEL", KEYS[1])
+elseif expire == 0 then
+	redis.call("PERSIST", KEYS[1])
+else
+	redis.call("PEXPIRE", KEYS[1], expire)
+end
+return ttl
+`)
+
+	// adapterRedisRemoveScript deletes all KEYS and returns the value of the last deleted key.
+	adapterRedisRemoveScript = redis.NewScript(-1, `
+local value = nil
+for _, key in ipairs(KEYS) do
+	local v = redis.call("GET", key)
+	if v ~= false then
+		value = v
+		redis.call("DEL", key)
+	end
+end
+return value
+`)
+)
+
+// NewAdapterRedis creates and returns a new redis adapter for cache using <redis> client.
+// The optional parameter <prefix> specifies the namespace prefix for all keys of the cache.
+//
+// Example:
+// cache := gcache.New()
+// cache.SetAdapter(gcache.NewAdapterRedis(g.Redis(), "cache:"))
+func NewAdapterRedis(redis *gredis.Redis, prefix ...string) *AdapterRedis {
+	a := &AdapterRedis{
+		redis:      redis,
+		serializer: SerializerJson,
+		lockTTL:    defaultAdapterRedisLockTTL,
+	}
+	if len(prefix) > 0 {
+		a.prefix = prefix[0]
+	}
+	return a
+}
+
+// SetSerializer sets the value serializer for the adapter, which is SerializerJson in default.
+func (a *AdapterRedis) SetSerializer(serializer Serializer) {
+	a.serializer = serializer
+}
+
+// SetLockTTL sets the lease time of the distributed lock used by GetOrSetFuncLock,
+// which should be longer than the execution time of the value function.
+// The lock is renewed automatically during the function executing.
+func (a *AdapterRedis) SetLockTTL(ttl time.Duration) {
+	a.lockTTL = ttl
+}
+
+// Set sets cache with <key>-<value> pair, which is expired after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0 or given <value> is nil.
+func (a *AdapterRedis) Set(ctx context.Context, key interface{}, value interface{}, duration time.Duration) error {
+	if value == nil || duration < 0 {
+		_, err := a.redis.Ctx(ctx).Do("DEL", a.redisKey(key))
+		return err
+	}
+	data, err := a.serializer.Serialize(value)
+	if err != nil {
+		return err
+	}
+	if duration == 0 {
+		_, err = a.redis.Ctx(ctx).Do("SET", a.redisKey(key), data)
+	} else {
+		_, err = a.redis.Ctx(ctx).Do("SET", a.redisKey(key), data, "PX", a.durationMilli(duration))
+	}
+	return err
+}
+
+// Sets batch sets cache with key-value pairs by <data>, which is expired after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the keys of <data> if <duration> < 0 or given <value> is nil.
+func (a *AdapterRedis) Sets(ctx context.Context, data map[interface{}]interface{}, duration time.Duration) error {
+	if len(data) == 0 {
+		return nil
+	}
+	conn := a.redis.Ctx(ctx).Conn()
+	defer conn.Close()
+	if err := conn.Send("MULTI"); err != nil {
+		return err
+	}
+	for k, v := range data {
+		var err error
+		if v == nil || duration < 0 {
+			err = conn.Send("DEL", a.redisKey(k))
+		} else {
+			var value []byte
+			if value, err = a.serializer.Serialize(v); err != nil {
+				conn.Do("DISCARD")
+				return err
+			}
+			if duration == 0 {
+				err = conn.Send("SET", a.redisKey(k), value)
+			} else {
+				err = conn.Send("SET", a.redisKey(k), value, "PX", a.durationMilli(duration))
+			}
+		}
+		if err != nil {
+			conn.Do("DISCARD")
+			return err
+		}
+	}
+	_, err := conn.Do("EXEC")
+	return err
+}
+
+// SetIfNotExist sets cache with <key>-<value> pair which is expired after <duration>
+// if <key> does not exist in the cache. It returns true the <key> dose not exist in the
+// cache and it sets <value> successfully to the cache, or else it returns false.
+//
+// The parameter <value> can be type of <func() (interface{}, error)>, but it dose nothing
+// if its result is nil.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0 or given <value> is nil.
+func (a *AdapterRedis) SetIfNotExist(ctx context.Context, key interface{}, value interface{}, duration time.Duration) (bool, error) {
+	if f, ok := value.(func() (interface{}, error)); ok {
+		v, err := f()
+		if err != nil {
+			return false, err
+		}
+		if v == nil {
+			return false, nil
+		}
+		value = v
+	}
+	if value == nil || duration < 0 {
+		_, err := a.redis.Ctx(ctx).Do("DEL", a.redisKey(key))
+		return false, err
+	}
+	data, err := a.serializer.Serialize(value)
+	if err != nil {
+		return false, err
+	}
+	var reply interface{}
+	if duration == 0 {
+		reply, err = a.redis.Ctx(ctx).Do("SET", a.redisKey(key), data, "NX")
+	} else {
+		reply, err = a.redis.Ctx(ctx).Do("SET", a.redisKey(key), data, "PX", a.durationMilli(duration), "NX")
+	}
+	if err != nil {
+		return false, err
+	}
+	return reply != nil, nil
+}
+
+// Get retrieves and returns the associated value of given <key>.
+// It returns nil if it does not exist or it's expired.
+func (a *AdapterRedis) Get(ctx context.Context, key interface{}) (interface{}, error) {
+	reply, err := a.redis.Ctx(ctx).Do("GET", a.redisKey(key))
+	if err != nil || reply == nil {
+		return nil, err
+	}
+	return a.serializer.Deserialize(gconv.Bytes(reply))
+}
+
+// GetOrSet retrieves and returns the value of <key>, or sets <key>-<value> pair and
+// returns <value> if <key> does not exist in the cache. The key-value pair expires
+// after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0 or given <value> is nil, but it does nothing
+// if <value> is a function and the function result is nil.
+func (a *AdapterRedis) GetOrSet(ctx context.Context, key interface{}, value interface{}, duration time.Duration) (interface{}, error) {
+	v, err := a.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if v != nil {
+		return v, nil
+	}
+	if f, ok := value.(func() (interface{}, error)); ok {
+		if value, err = f(); err != nil || value == nil {
+			return nil, err
+		}
+	}
+	return value, a.Set(ctx, key, value, duration)
+}
+
+// GetOrSetFunc retrieves and returns the value of <key>, or sets <key> with result of
+// function <f> and returns its result if <key> does not exist in the cache. The key-value
+// pair expires after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It deletes the <key> if <duration> < 0 or given <value> is nil, but it does nothing
+// if <value> is a function and the function result is nil.
+func (a *AdapterRedis) GetOrSetFunc(ctx context.Context, key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
+	return a.GetOrSet(ctx, key, f, duration)
+}
+
+// GetOrSetFuncLock retrieves and returns the value of <key>, or sets <key> with result of
+// function <f> and returns its result if <key> does not exist in the cache. The key-value
+// pair expires after <duration>.
+//
+// It does not expire if <duration> == 0.
+// It does nothing if function <f> returns nil.
+//
+// Note that the function <f> is executed within a distributed lock of the <key>,
+// which makes sure only one process executes <f> for the same <key> at the same time.
+func (a *AdapterRedis) GetOrSetFuncLock(ctx context.Context, key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
+	v, err := a.Get(ctx, key)
+	if err != nil || v != nil {
+		return v, err
+	}
+	mutex := a.redis.Ctx(ctx).Mutex(a.redisKey(key)+adapterRedisLockKeySuffix, gredis.MutexOptions{
+		TTL:      a.lockTTL,
+		Watchdog: true,
+	})
+	if err = mutex.Lock(); err != nil {
+		return nil, err
+	}
+	defer mutex.Unlock()
+	// Doubly check whether the value was set by others during the lock waiting.
+	if v, err = a.Get(ctx, key); err != nil || v != nil {
+		return v, err
+	}
+	return a.GetOrSet(ctx, key, f, duration)
+}
+
+// Contains returns true if <key> exists in the cache, or else returns false.
+func (a *AdapterRedis) Contains(ctx context.Context, key interface{}) (bool, error) {
+	v, err := a.redis.Ctx(ctx).DoVar("EXISTS", a.redisKey(key))
+	if err != nil {
+		return false, err
+	}
+	return v.Bool(), nil
+}
+
+// GetExpire retrieves and returns the expiration of <key> in the cache.
+//
+// It returns 0 if the <key> does not expire.
+// It returns -1 if the <key> does not exist in the cache.
+func (a *AdapterRedis) GetExpire(ctx context.Context, key interface{}) (time.Duration, error) {
+	v, err := a.redis.Ctx(ctx).DoVar("PTTL", a.redisKey(key))
+	if err != nil {
+		return -1, err
+	}
+	return a.ttlToDuration(v.Int64()), nil
+}
+
+// Remove deletes one or more keys from cache, and returns its value.
+// If multiple keys are given, it returns the value of the last deleted item.
+func (a *AdapterRedis) Remove(ctx context.Context, keys ...interface{}) (value interface{}, err error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	keysAndArgs := make([]interface{}, 0, len(keys)+1)
+	keysAndArgs = append(keysAndArgs, len(keys))
+	for _, key := range keys {
+		keysAndArgs = append(keysAndArgs, a.redisKey(key))
+	}
+	reply, err := a.evalScript(ctx, adapterRedisRemoveScript, keysAndArgs...)
+	if err != nil || reply == nil {
+		return nil, err
+	}
+	return a.serializer.Deserialize(gconv.Bytes(reply))
+}
+
+// Update updates the value of <key> without changing its expiration and returns the old value.
+// The returned value <exist> is false if the <key> does not exist in the cache.
+//
+// It deletes the <key> if given <value> is nil.
+// It does nothing if <key> does not exist in the cache.
+func (a *AdapterRedis) Update(ctx context.Context, key interface{}, value interface{}) (oldValue interface{}, exist bool, err error) {
+	var reply interface{}
+	if value == nil {
+		reply, err = a.evalScript(ctx, adapterRedisRemoveScript, 1, a.redisKey(key))
+	} else {
+		var data []byte
+		if data, err = a.serializer.Serialize(value); err != nil {
+			return nil, false, err
+		}
+		reply, err = a.evalScript(ctx, adapterRedisUpdateScript, a.redisKey(key), data)
+	}
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	oldValue, err = a.serializer.Deserialize(gconv.Bytes(reply))
+	return oldValue, true, err
+}
+
+// UpdateExpire updates the expiration of <key> and returns the old expiration duration value.
+//
+// It returns -1 and does nothing if the <key> does not exist in the cache.
+// It deletes the <key> if <duration> < 0.
+func (a *AdapterRedis) UpdateExpire(ctx context.Context, key interface{}, duration time.Duration) (oldDuration time.Duration, err error) {
+	expire := a.durationMilli(duration)
+	if duration < 0 {
+		expire = -1
+	}
+	reply, err := a.evalScript(ctx, adapterRedisUpdateExpireScript, a.redisKey(key), expire)
+	if err != nil {
+		return -1, err
+	}
+	return a.ttlToDuration(gconv.Int64(reply)), nil
+}
+
+// Size returns the number of items in the cache.
+func (a *AdapterRedis) Size(ctx context.Context) (size int, err error) {
+	keys, err := a.scanKeys(ctx)
+	return len(keys), err
+}
+
+// Data returns a copy of all key-value pairs in the cache as map type.
+// Note that this function may leads lots of memory usage and redis loading.
+func (a *AdapterRedis) Data(ctx context.Context) (map[interface{}]interface{}, error) {
+	keys, values, err := a.scanKeysAndValues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[interface{}]interface{}, len(keys))
+	for i, key := range keys {
+		data[key] = values[i]
+	}
+	return data, nil
+}
+
+// Keys returns all keys in the cache as slice, which are without the namespace prefix.
+func (a *AdapterRedis) Keys(ctx context.Context) ([]interface{}, error) {
+	keys, err := a.scanKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	array := make([]interface{}, len(keys))
+	for i, key := range keys {
+		array[i] = a.cacheKey(key)
+	}
+	return array, nil
+}
+
+// Values returns all values in the cache as slice.
+func (a *AdapterRedis) Values(ctx context.Context) ([]interface{}, error) {
+	_, values, err := a.scanKeysAndValues(ctx)
+	return values, err
+}
+
+// Clear clears all data of the cache, which deletes all keys of the namespace prefix.
+// Note that this function is sensitive and should be carefully used, as it deletes all
+// keys of the redis database if the prefix is empty.
+func (a *AdapterRedis) Clear(ctx context.Context) error {
+	keys, err := a.scanKeys(ctx)
+	if err != nil {
+		return err
+	}
+	for len(keys) > 0 {
+		n := defaultAdapterRedisScanCount
+		if n > len(keys) {
+			n = len(keys)
+		}
+		if _, err = a.redis.Ctx(ctx).Do("DEL", gconv.Interfaces(keys[:n])...); err != nil {
+			return err
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+// Close does nothing for redis adapter, as the redis client is managed by its creator.
+func (a *AdapterRedis) Close(ctx context.Context) error {
+	return nil
+}
+
+// scanKeys retrieves all redis keys of the namespace prefix using SCAN command.
+func (a *AdapterRedis) scanKeys(ctx context.Context) ([]string, error) {
+	var (
+		cursor = "0"
+		keys   = make([]string, 0)
+		match  = a.escapeMatchPattern(a.prefix) + "*"
+	)
+	for {
+		v, err := a.redis.Ctx(ctx).Do("SCAN", cursor, "MATCH", match, "COUNT", defaultAdapterRedisScanCount)
+		if err != nil {
+			return nil, err
+		}
+		reply, err := redis.Values(v, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(reply) != 2 {
+			break
+		}
+		cursor = gconv.String(reply[0])
+		keys = append(keys, gconv.Strings(reply[1])...)
+		if cursor == "0" {
+			break
+		}
+	}
+	return keys, nil
+}
+
+// scanKeysAndValues retrieves all keys without prefix and their values of the namespace prefix.
+// Keys expired during the scanning are ignored.
+func (a *AdapterRedis) scanKeysAndValues(ctx context.Context) ([]interface{}, []interface{}, error) {
+	redisKeys, err := a.scanKeys(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		keys   = make([]interface{}, 0, len(redisKeys))
+		values = make([]interface{}, 0, len(redisKeys))
+	)
+	for len(redisKeys) > 0 {
+		n := defaultAdapterRedisScanCount
+		if n > len(redisKeys) {
+			n = len(redisKeys)
+		}
+		v, err := a.redis.Ctx(ctx).Do("MGET", gconv.Interfaces(redisKeys[:n])...)
+		if err != nil {
+			return nil, nil, err
+		}
+		reply, err := redis.Values(v, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, item := range reply {
+			if item == nil {
+				continue
+			}
+			value, err := a.serializer.Deserialize(gconv.Bytes(item))
+			if err != nil {
+				return nil, nil, err
+			}
+			keys = append(keys, a.cacheKey(redisKeys[i]))
+			values = append(values, value)
+		}
+		redisKeys = redisKeys[n:]
+	}
+	return keys, values, nil
+}
+
+// evalScript evaluates lua <script> with <keysAndArgs> using a connection from the pool.
+func (a *AdapterRedis) evalScript(ctx context.Context, script *redis.Script, keysAndArgs ...interface{}) (interface{}, error) {
+	conn := a.redis.Ctx(ctx).Conn()
+	defer conn.Close()
+	return script.Do(conn, keysAndArgs...)
+}
+
+// redisKey returns the redis key for <key> with namespace prefix.
+// The string key is used as it is unless it starts with a type tag byte,
+// and keys of other types are encoded with type tag using SerializerJson.
+func (a *AdapterRedis) redisKey(key interface{}) string {
+	if s, ok := key.(string); ok && (len(s) == 0 || s[0] > serializerTagJson) {
+		return a.prefix + s
+	}
+	data, err := SerializerJson.Serialize(key)
+	if err != nil {
+		return a.prefix + gconv.String(key)
+	}
+	return a.prefix + string(data)
+}
+
+// cacheKey decodes the cache key from <redisKey>, which is the reverse operation of redisKey.
+func (a *AdapterRedis) cacheKey(redisKey string) interface{} {
+	key := redisKey[len(a.prefix):]
+	if len(key) == 0 || key[0] > serializerTagJson {
+		return key
+	}
+	value, err := SerializerJson.Deserialize([]byte(key))
+	if err != nil {
+		return key
+	}
+	return value
+}
+
+// durationMilli converts <duration> to milliseconds, which is at least 1 for positive duration.
+func (a *AdapterRedis) durationMilli(duration time.Duration) int64 {
+	milli := duration.Milliseconds()
+	if milli == 0 && duration > 0 {
+		milli = 1
+	}
+	return milli
+}
+
+// ttlToDuration converts the PTTL result to the expiration duration of Adapter definition.
+func (a *AdapterRedis) ttlToDuration(ttl int64) time.Duration {
+	switch ttl {
+	case -2:
+		return -1
+	case -1:
+		return 0
+	}
+	return time.Duration(ttl) * time.Millisecond
+}
+
+// escapeMatchPattern escapes the glob-style special chars of <s> for SCAN MATCH.
+func (a *AdapterRedis) escapeMatchPattern(s string) string {
+	if !strings.ContainsAny(s, adapterRedisMatchSpecialChars) {
+		return s
+	}
+	var builder strings.Builder
+	for _, c := range s {
+		if strings.ContainsRune(adapterRedisMatchSpecialChars, c) {
+			builder.WriteByte('\\')
+		}
+		builder.WriteRune(c)
+	}
+	return builder.String()
+}
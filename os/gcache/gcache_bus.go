@@ -0,0 +1,32 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"context"
+)
+
+// Bus is the interface for broadcasting cache invalidation messages among nodes,
+// which is used by AdapterLayered to drop stale local entries on all nodes.
+type Bus interface {
+	// Publish broadcasts <message> to all subscribers, including the publisher itself.
+	Publish(ctx context.Context, message *BusMessage) error
+
+	// Subscribe registers <handler> receiving messages from the bus.
+	// The bus should keep the subscription alive until it is closed.
+	Subscribe(handler func(message *BusMessage)) error
+
+	// Close stops the subscription and releases the resources of the bus.
+	Close() error
+}
+
+// BusMessage is the invalidation message broadcast by Bus.
+type BusMessage struct {
+	Node  string   `json:"node"`  // Node id of the publisher, which is used for ignoring messages from itself.
+	Keys  []string `json:"keys"`  // Keys that should be dropped.
+	Clear bool     `json:"clear"` // Whether all keys should be dropped.
+}
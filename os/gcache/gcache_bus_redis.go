@@ -0,0 +1,133 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/container/gtype"
+	"github.com/gogf/gf/database/gredis"
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/internal/json"
+	"github.com/gomodule/redigo/redis"
+)
+
+// BusRedis is the Bus implements using Redis Pub/Sub.
+type BusRedis struct {
+	mu      sync.Mutex        // Mutex for concurrent safety of <psc>.
+	redis   *gredis.Redis     // Redis client.
+	channel string            // Pub/Sub channel name.
+	psc     *redis.PubSubConn // Dedicated connection for subscribing.
+	closed  *gtype.Bool       // Whether the bus is closed.
+}
+
+const (
+	// DefaultBusRedisChannel is the default Pub/Sub channel for BusRedis.
+	DefaultBusRedisChannel = "gcache:invalidation"
+
+	// busRedisReconnectInterval is the interval between two subscribing attempts
+	// when the subscription connection breaks.
+	busRedisReconnectInterval = time.Second
+)
+
+// NewBusRedis creates and returns a Bus using Redis Pub/Sub on <channel>,
+// which is DefaultBusRedisChannel if not given.
+func NewBusRedis(redis *gredis.Redis, channel ...string) *BusRedis {
+	b := &BusRedis{
+		redis:   redis,
+		channel: DefaultBusRedisChannel,
+		closed:  gtype.NewBool(),
+	}
+	if len(channel) > 0 && channel[0] != "" {
+		b.channel = channel[0]
+	}
+	return b
+}
+
+// Publish broadcasts <message> to the channel.
+func (b *BusRedis) Publish(ctx context.Context, message *BusMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = b.redis.Ctx(ctx).Do("PUBLISH", b.channel, data)
+	return err
+}
+
+// Subscribe subscribes the channel with <handler> in a new goroutine, which reconnects
+// automatically if the connection breaks. It returns error if the first subscribing fails.
+func (b *BusRedis) Subscribe(handler func(message *BusMessage)) error {
+	psc, err := b.subscribe()
+	if err != nil {
+		return err
+	}
+	go func() {
+		for {
+			if psc == nil {
+				time.Sleep(busRedisReconnectInterval)
+				if b.closed.Val() {
+					return
+				}
+				if psc, err = b.subscribe(); err != nil {
+					intlog.Error(err)
+					continue
+				}
+			}
+			switch v := psc.Receive().(type) {
+			case redis.Message:
+				message := &BusMessage{}
+				if err := json.Unmarshal(v.Data, message); err != nil {
+					intlog.Error(err)
+					continue
+				}
+				handler(message)
+
+			case redis.Subscription:
+				// All channels are unsubscribed by Close.
+				if v.Count == 0 && b.closed.Val() {
+					psc.Close()
+					return
+				}
+
+			case error:
+				psc.Close()
+				if b.closed.Val() {
+					return
+				}
+				intlog.Error(v)
+				psc = nil
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops the subscription.
+func (b *BusRedis) Close() error {
+	b.closed.Set(true)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.psc != nil {
+		return b.psc.Unsubscribe()
+	}
+	return nil
+}
+
+// subscribe creates a new connection subscribing the channel.
+func (b *BusRedis) subscribe() (*redis.PubSubConn, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	psc := &redis.PubSubConn{Conn: b.redis.Conn()}
+	if err := psc.Subscribe(b.channel); err != nil {
+		psc.Close()
+		return nil, err
+	}
+	b.psc = psc
+	return psc, nil
+}
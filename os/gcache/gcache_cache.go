@@ -9,27 +9,46 @@ package gcache
 import (
 	"context"
 	"github.com/gogf/gf/container/gvar"
-	"github.com/gogf/gf/os/gtimer"
 	"github.com/gogf/gf/util/gconv"
-	"time"
 )
 
 // Cache struct.
 type Cache struct {
 	adapter Adapter         // Adapter for cache features.
 	ctx     context.Context // Context for operations.
+	stats   *cacheStats     // Statistics of the cache, which is shared by cloned objects.
+	flight  *cacheFlight    // Coalescing of the concurrent loading, which is shared by cloned objects.
+	refresh *cacheRefresh   // Background refreshing of the loaded keys, which is shared by cloned objects.
 }
 
 // New creates and returns a new cache object using default memory adapter.
 // Note that the LRU feature is only available using memory adapter.
 func New(lruCap ...int) *Cache {
-	memAdapter := newAdapterMemory(lruCap...)
+	options := AdapterMemoryOptions{}
+	if len(lruCap) > 0 {
+		options.Cap = lruCap[0]
+	}
+	return NewWithOptions(options)
+}
+
+// NewWithOptions creates and returns a new cache object using memory adapter with given <options>,
+// which enables the eviction policies and bytes limit of the memory adapter.
+func NewWithOptions(options AdapterMemoryOptions) *Cache {
 	c := &Cache{
-		adapter: memAdapter,
+		stats:   newCacheStats(),
+		flight:  newCacheFlight(),
+		refresh: newCacheRefresh(),
+	}
+	onEvicted := options.OnEvicted
+	options.OnEvicted = func(key, value interface{}, reason EvictionReason) {
+		c.stats.recordEviction(reason)
+		if onEvicted != nil {
+			onEvicted(key, value, reason)
+		}
 	}
 	// Here may be a "timer leak" if adapter is manually changed from memory adapter.
 	// Do not worry about this, as adapter is less changed and it dose nothing if it's not used.
-	gtimer.AddSingleton(time.Second, memAdapter.syncEventAndClearExpired)
+	c.adapter = NewAdapterMemoryWithOptions(options)
 	return c
 }
 
@@ -38,6 +57,9 @@ func (c *Cache) Clone() *Cache {
 	return &Cache{
 		adapter: c.adapter,
 		ctx:     c.ctx,
+		stats:   c.stats,
+		flight:  c.flight,
+		refresh: c.refresh,
 	}
 }
 
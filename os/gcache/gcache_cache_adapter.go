@@ -42,7 +42,11 @@ func (c *Cache) SetIfNotExist(key interface{}, value interface{}, duration time.
 // Get retrieves and returns the associated value of given <key>.
 // It returns nil if it does not exist, its value is nil or it's expired.
 func (c *Cache) Get(key interface{}) (interface{}, error) {
-	return c.adapter.Get(c.getCtx(), key)
+	v, err := c.adapter.Get(c.getCtx(), key)
+	if err == nil {
+		c.stats.recordLookup(v)
+	}
+	return v, err
 }
 
 // GetOrSet retrieves and returns the value of <key>, or sets <key>-<value> pair and
@@ -53,6 +57,10 @@ func (c *Cache) Get(key interface{}) (interface{}, error) {
 // It deletes the <key> if <duration> < 0 or given <value> is nil, but it does nothing
 // if <value> is a function and the function result is nil.
 func (c *Cache) GetOrSet(key interface{}, value interface{}, duration time.Duration) (interface{}, error) {
+	v, err := c.Get(key)
+	if err != nil || v != nil {
+		return v, err
+	}
 	return c.adapter.GetOrSet(c.getCtx(), key, value, duration)
 }
 
@@ -63,8 +71,25 @@ func (c *Cache) GetOrSet(key interface{}, value interface{}, duration time.Durat
 // It does not expire if <duration> == 0.
 // It deletes the <key> if <duration> < 0 or given <value> is nil, but it does nothing
 // if <value> is a function and the function result is nil.
+//
+// The concurrent calls loading the same <key> are coalesced, in which only one function <f>
+// is executed and its result is shared.
 func (c *Cache) GetOrSetFunc(key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
-	return c.adapter.GetOrSetFunc(c.getCtx(), key, f, duration)
+	v, err := c.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if v != nil {
+		c.refreshIfNeeded(key, f, duration)
+		return v, nil
+	}
+	return c.flight.Do(key, func() (interface{}, error) {
+		value, err := c.stats.load(f)
+		if err != nil || value == nil {
+			return nil, err
+		}
+		return c.adapter.GetOrSet(c.getCtx(), key, value, c.refresh.storeDuration(duration))
+	})
 }
 
 // GetOrSetFuncLock retrieves and returns the value of <key>, or sets <key> with result of
@@ -76,8 +101,23 @@ func (c *Cache) GetOrSetFunc(key interface{}, f func() (interface{}, error), dur
 //
 // Note that the function <f> should be executed within writing mutex lock for concurrent
 // safety purpose.
+//
+// The concurrent calls loading the same <key> are coalesced, in which only one function <f>
+// is executed and its result is shared.
 func (c *Cache) GetOrSetFuncLock(key interface{}, f func() (interface{}, error), duration time.Duration) (interface{}, error) {
-	return c.adapter.GetOrSetFuncLock(c.getCtx(), key, f, duration)
+	v, err := c.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if v != nil {
+		c.refreshIfNeeded(key, f, duration)
+		return v, nil
+	}
+	return c.flight.Do(key, func() (interface{}, error) {
+		return c.adapter.GetOrSetFuncLock(c.getCtx(), key, func() (interface{}, error) {
+			return c.stats.load(f)
+		}, c.refresh.storeDuration(duration))
+	})
 }
 
 // Contains returns true if <key> exists in the cache, or else returns false.
@@ -0,0 +1,57 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"sync"
+)
+
+// cacheFlight coalesces the concurrent loading of the same key, so that only one loader
+// function runs for the key and the others wait for and share its result, which protects
+// the underlying data source from cache stampede.
+type cacheFlight struct {
+	mu    sync.Mutex                       // Mutex for calls.
+	calls map[interface{}]*cacheFlightCall // Loading calls by key.
+}
+
+// cacheFlightCall is an in-flight or completed loading call.
+type cacheFlightCall struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+func newCacheFlight() *cacheFlight {
+	return &cacheFlight{
+		calls: make(map[interface{}]*cacheFlightCall),
+	}
+}
+
+// Do executes <f> for <key> and returns its result, making sure that only one execution
+// is in-flight for the given key at a time. The duplicate callers wait for the original
+// one to complete and receive the same result.
+func (f *cacheFlight) Do(key interface{}, fn func() (interface{}, error)) (interface{}, error) {
+	f.mu.Lock()
+	if call, ok := f.calls[key]; ok {
+		f.mu.Unlock()
+		call.wg.Wait()
+		return call.value, call.err
+	}
+	call := new(cacheFlightCall)
+	call.wg.Add(1)
+	f.calls[key] = call
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		delete(f.calls, key)
+		f.mu.Unlock()
+		call.wg.Done()
+	}()
+	call.value, call.err = fn()
+	return call.value, call.err
+}
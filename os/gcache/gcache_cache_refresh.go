@@ -0,0 +1,104 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/container/gset"
+	"github.com/gogf/gf/internal/intlog"
+)
+
+// RefreshOptions configures the background refreshing of keys loaded by GetOrSetFunc*,
+// which keeps hot keys from expiring and blocking their callers for loading.
+type RefreshOptions struct {
+	// RefreshAhead is the ratio of the duration, after which the key is refreshed in
+	// background when it is accessed, eg: 0.8 means refreshing the key when 80% of its
+	// duration elapses. It is disabled if it is not in range (0, 1).
+	RefreshAhead float64
+
+	// StaleTTL keeps the key for extra StaleTTL after its duration expires, during which
+	// its stale value is returned and refreshed in background when it is accessed.
+	// Note that Get also returns the stale value in this period. It is disabled if it is 0.
+	StaleTTL time.Duration
+}
+
+// cacheRefresh manages the background refreshing of a cache.
+type cacheRefresh struct {
+	options    RefreshOptions
+	refreshing *gset.Set // Keys being refreshed.
+}
+
+func newCacheRefresh() *cacheRefresh {
+	return &cacheRefresh{
+		refreshing: gset.New(true),
+	}
+}
+
+// SetRefreshOptions sets the background refreshing options for keys loaded by GetOrSetFunc*.
+// Be very note that, this setting function is not concurrent-safe, which means you should not call
+// this setting function concurrently in multiple goroutines.
+func (c *Cache) SetRefreshOptions(options RefreshOptions) {
+	c.refresh.options = options
+}
+
+// enabled checks whether the background refreshing is enabled for keys of <duration>.
+func (r *cacheRefresh) enabled(duration time.Duration) bool {
+	if duration <= 0 {
+		return false
+	}
+	return r.options.StaleTTL > 0 || (r.options.RefreshAhead > 0 && r.options.RefreshAhead < 1)
+}
+
+// storeDuration returns the duration storing the loaded value to the adapter, which
+// contains the extra stale duration.
+func (r *cacheRefresh) storeDuration(duration time.Duration) time.Duration {
+	if r.enabled(duration) {
+		return duration + r.options.StaleTTL
+	}
+	return duration
+}
+
+// refreshIfNeeded refreshes <key> in background using loader <f> if it reaches its
+// refreshing time.
+func (c *Cache) refreshIfNeeded(key interface{}, f func() (interface{}, error), duration time.Duration) {
+	if !c.refresh.enabled(duration) {
+		return
+	}
+	var (
+		ctx       = c.getCtx()
+		stored    = c.refresh.storeDuration(duration)
+		threshold = duration
+	)
+	if c.refresh.options.RefreshAhead > 0 && c.refresh.options.RefreshAhead < 1 {
+		threshold = time.Duration(float64(duration) * c.refresh.options.RefreshAhead)
+	}
+	remaining, err := c.adapter.GetExpire(ctx, key)
+	if err != nil || remaining <= 0 || stored-remaining < threshold {
+		return
+	}
+	if !c.refresh.refreshing.AddIfNotExist(key) {
+		return
+	}
+	go func() {
+		defer c.refresh.refreshing.Remove(key)
+		// It uses a new context, as the context of the caller may be done after it returns.
+		ctx := context.Background()
+		value, err := c.stats.load(f)
+		if err != nil {
+			intlog.Error(err)
+			return
+		}
+		if value == nil {
+			return
+		}
+		if err = c.adapter.Set(ctx, key, value, stored); err != nil {
+			intlog.Error(err)
+		}
+	}()
+}
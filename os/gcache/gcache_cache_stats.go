@@ -0,0 +1,137 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"time"
+
+	"github.com/gogf/gf/container/gtype"
+)
+
+// Stats is the statistics of a cache object.
+type Stats struct {
+	Hits          uint64        // Number of lookups finding the key.
+	Misses        uint64        // Number of lookups not finding the key.
+	Loads         uint64        // Number of loader function calls by GetOrSetFunc*.
+	LoadErrors    uint64        // Number of loader function calls returning error.
+	Evictions     uint64        // Number of items evicted by the eviction policy.
+	Expirations   uint64        // Number of items deleted for expiration.
+	TotalLoadTime time.Duration // Total time spent in loader functions.
+}
+
+// cacheStats is the concurrent-safe counters of Stats.
+type cacheStats struct {
+	hits          *gtype.Uint64
+	misses        *gtype.Uint64
+	loads         *gtype.Uint64
+	loadErrors    *gtype.Uint64
+	evictions     *gtype.Uint64
+	expirations   *gtype.Uint64
+	totalLoadTime *gtype.Int64 // In nanoseconds.
+}
+
+func newCacheStats() *cacheStats {
+	return &cacheStats{
+		hits:          gtype.NewUint64(),
+		misses:        gtype.NewUint64(),
+		loads:         gtype.NewUint64(),
+		loadErrors:    gtype.NewUint64(),
+		evictions:     gtype.NewUint64(),
+		expirations:   gtype.NewUint64(),
+		totalLoadTime: gtype.NewInt64(),
+	}
+}
+
+// Stats returns the statistics snapshot of the cache.
+//
+// Note that the evictions and expirations are only available for the cache created by
+// New or NewWithOptions, which uses the memory adapter.
+func (c *Cache) Stats() Stats {
+	return Stats{
+		Hits:          c.stats.hits.Val(),
+		Misses:        c.stats.misses.Val(),
+		Loads:         c.stats.loads.Val(),
+		LoadErrors:    c.stats.loadErrors.Val(),
+		Evictions:     c.stats.evictions.Val(),
+		Expirations:   c.stats.expirations.Val(),
+		TotalLoadTime: time.Duration(c.stats.totalLoadTime.Val()),
+	}
+}
+
+// ResetStats resets all the statistics of the cache to zero.
+func (c *Cache) ResetStats() {
+	c.stats.hits.Set(0)
+	c.stats.misses.Set(0)
+	c.stats.loads.Set(0)
+	c.stats.loadErrors.Set(0)
+	c.stats.evictions.Set(0)
+	c.stats.expirations.Set(0)
+	c.stats.totalLoadTime.Set(0)
+}
+
+// Metrics returns the statistics of the cache as metric name to value map,
+// which can be conveniently exported to monitoring systems.
+func (c *Cache) Metrics() map[string]interface{} {
+	s := c.Stats()
+	return map[string]interface{}{
+		"hits":             s.Hits,
+		"misses":           s.Misses,
+		"hit_ratio":        s.HitRatio(),
+		"loads":            s.Loads,
+		"load_errors":      s.LoadErrors,
+		"evictions":        s.Evictions,
+		"expirations":      s.Expirations,
+		"avg_load_time_ms": float64(s.AvgLoadTime()) / float64(time.Millisecond),
+	}
+}
+
+// HitRatio returns the ratio of hits in all lookups, which is 0 if there's no lookup.
+func (s Stats) HitRatio() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+// AvgLoadTime returns the average time spent in loader functions.
+func (s Stats) AvgLoadTime() time.Duration {
+	if s.Loads > 0 {
+		return s.TotalLoadTime / time.Duration(s.Loads)
+	}
+	return 0
+}
+
+// recordLookup records a lookup of the key, which hits if <value> is not nil.
+func (s *cacheStats) recordLookup(value interface{}) {
+	if value != nil {
+		s.hits.Add(1)
+	} else {
+		s.misses.Add(1)
+	}
+}
+
+// recordEviction records an item deleted by the memory adapter for <reason>.
+func (s *cacheStats) recordEviction(reason EvictionReason) {
+	switch reason {
+	case EvictionReasonEvicted:
+		s.evictions.Add(1)
+	case EvictionReasonExpired:
+		s.expirations.Add(1)
+	}
+}
+
+// load calls loader function <f> and records its statistics.
+func (s *cacheStats) load(f func() (interface{}, error)) (interface{}, error) {
+	start := time.Now()
+	value, err := f()
+	s.loads.Add(1)
+	s.totalLoadTime.Add(int64(time.Since(start)))
+	if err != nil {
+		s.loadErrors.Add(1)
+	}
+	return value, err
+}
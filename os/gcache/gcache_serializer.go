@@ -0,0 +1,168 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/internal/json"
+)
+
+// Serializer is the interface for encoding/decoding cache values for remote adapters.
+type Serializer interface {
+	// Serialize encodes <value> to bytes.
+	Serialize(value interface{}) ([]byte, error)
+
+	// Deserialize decodes <data> to value.
+	Deserialize(data []byte) (interface{}, error)
+}
+
+// serializerJson is the Serializer implements using JSON with type tag.
+type serializerJson struct{}
+
+// SerializerFunc is a Serializer implements with encoding/decoding functions.
+type SerializerFunc struct {
+	SerializeFunc   func(value interface{}) ([]byte, error)
+	DeserializeFunc func(data []byte) (interface{}, error)
+}
+
+const (
+	serializerTagBytes  byte = 0x01 // Raw []byte.
+	serializerTagString byte = 0x02 // Raw string.
+	serializerTagTyped  byte = 0x03 // JSON of registered type, following the type name and a zero byte.
+	serializerTagJson   byte = 0x04 // JSON of unregistered type.
+)
+
+var (
+	// SerializerJson is the default Serializer for remote adapters.
+	//
+	// It stores []byte and string values as they are, and encodes other values as JSON
+	// with a type tag. The values of builtin basic types, time.Time, common slices/maps
+	// and types registered by RegisterType are decoded to their original types. Values of
+	// other types are decoded like json.Unmarshal with json.Number, for example, struct
+	// values are decoded as map[string]interface{}, which can be converted using gvar.Var.
+	SerializerJson Serializer = serializerJson{}
+
+	// serializerTypes maps the type names to the types registered for SerializerJson.
+	serializerTypes = sync.Map{}
+)
+
+func init() {
+	RegisterType(
+		false,
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+		float32(0), float64(0),
+		time.Time{},
+		[]interface{}{}, []string{}, []int{}, []int64{}, []float64{},
+		map[string]interface{}{}, map[string]string{}, map[string]int{},
+	)
+}
+
+// RegisterType registers the types of <values> for SerializerJson, so that the values
+// of these types are decoded as their original types instead of generic maps or slices.
+// It is commonly used for struct types, both the struct and its pointer can be registered.
+//
+// Note that the types should be registered with the same names on all nodes sharing the cache.
+func RegisterType(values ...interface{}) {
+	for _, value := range values {
+		if t := reflect.TypeOf(value); t != nil {
+			serializerTypes.Store(serializerTypeName(t), t)
+		}
+	}
+}
+
+// Serialize encodes <value> with type tag.
+func (s serializerJson) Serialize(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return append([]byte{serializerTagBytes}, v...), nil
+	case string:
+		return append([]byte{serializerTagString}, v...), nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	name := serializerTypeName(reflect.TypeOf(value))
+	if _, ok := serializerTypes.Load(name); !ok {
+		return append([]byte{serializerTagJson}, data...), nil
+	}
+	buffer := make([]byte, 0, len(name)+len(data)+2)
+	buffer = append(buffer, serializerTagTyped)
+	buffer = append(buffer, name...)
+	buffer = append(buffer, 0)
+	return append(buffer, data...), nil
+}
+
+// Deserialize decodes <data> to the value of its tagged type.
+// The data without type tag is decoded as plain JSON.
+func (s serializerJson) Deserialize(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return s.decodeJson(data)
+	}
+	switch data[0] {
+	case serializerTagBytes:
+		return append([]byte{}, data[1:]...), nil
+
+	case serializerTagString:
+		return string(data[1:]), nil
+
+	case serializerTagTyped:
+		index := bytes.IndexByte(data, 0)
+		if index < 0 {
+			return s.decodeJson(data[1:])
+		}
+		t, ok := serializerTypes.Load(string(data[1:index]))
+		if !ok {
+			return s.decodeJson(data[index+1:])
+		}
+		pointer := reflect.New(t.(reflect.Type))
+		if err := json.UnmarshalUseNumber(data[index+1:], pointer.Interface()); err != nil {
+			return nil, err
+		}
+		return pointer.Elem().Interface(), nil
+
+	case serializerTagJson:
+		return s.decodeJson(data[1:])
+	}
+	return s.decodeJson(data)
+}
+
+// decodeJson decodes JSON <data>, using json.Number for numbers.
+func (s serializerJson) decodeJson(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := json.UnmarshalUseNumber(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Serialize encodes <value> using SerializeFunc.
+func (s SerializerFunc) Serialize(value interface{}) ([]byte, error) {
+	return s.SerializeFunc(value)
+}
+
+// Deserialize decodes <data> using DeserializeFunc.
+func (s SerializerFunc) Deserialize(data []byte) (interface{}, error) {
+	return s.DeserializeFunc(data)
+}
+
+// serializerTypeName returns the unique name of type <t>, which contains the package path
+// for named types.
+func serializerTypeName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		return "*" + serializerTypeName(t.Elem())
+	}
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+	return t.String()
+}
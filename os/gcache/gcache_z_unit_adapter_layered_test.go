@@ -0,0 +1,209 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/os/gcache"
+	"github.com/gogf/gf/test/gtest"
+)
+
+// localBus is a Bus delivering messages synchronously in process for testing.
+type localBus struct {
+	mu       sync.RWMutex
+	handlers []func(message *gcache.BusMessage)
+}
+
+func (b *localBus) Publish(ctx context.Context, message *gcache.BusMessage) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(message)
+	}
+	return nil
+}
+
+func (b *localBus) Subscribe(handler func(message *gcache.BusMessage)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+	return nil
+}
+
+func (b *localBus) Close() error {
+	return nil
+}
+
+// remoteAdapter wraps an Adapter counting its Get calls.
+type remoteAdapter struct {
+	gcache.Adapter
+	mu    sync.Mutex
+	gets  int
+	onGet func()
+}
+
+func (a *remoteAdapter) Get(ctx context.Context, key interface{}) (interface{}, error) {
+	a.mu.Lock()
+	a.gets++
+	a.mu.Unlock()
+	v, err := a.Adapter.Get(ctx, key)
+	if a.onGet != nil {
+		a.onGet()
+	}
+	return v, err
+}
+
+func TestAdapterLayered_Get(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.Background()
+			remote = &remoteAdapter{Adapter: gcache.NewAdapterMemory()}
+		)
+		adapter, err := gcache.NewAdapterLayered(remote)
+		t.Assert(err, nil)
+		defer adapter.Close(ctx)
+
+		t.Assert(remote.Set(ctx, "k", "v", 0), nil)
+		for i := 0; i < 3; i++ {
+			v, err := adapter.Get(ctx, "k")
+			t.Assert(err, nil)
+			t.Assert(v, "v")
+		}
+		t.Assert(remote.gets, 1)
+
+		v, err := adapter.Get(ctx, "none")
+		t.Assert(err, nil)
+		t.Assert(v, nil)
+	})
+}
+
+func TestAdapterLayered_L1TTL(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.Background()
+			remote = gcache.NewAdapterMemory()
+		)
+		adapter, err := gcache.NewAdapterLayered(remote, gcache.AdapterLayeredOptions{
+			L1TTL: 100 * time.Millisecond,
+		})
+		t.Assert(err, nil)
+		defer adapter.Close(ctx)
+
+		t.Assert(adapter.Set(ctx, "k", "v1", 0), nil)
+		// Changed in L2 bypassing the layered adapter.
+		_, _, err = remote.Update(ctx, "k", "v2")
+		t.Assert(err, nil)
+		v, _ := adapter.Get(ctx, "k")
+		t.Assert(v, "v1")
+
+		time.Sleep(200 * time.Millisecond)
+		v, _ = adapter.Get(ctx, "k")
+		t.Assert(v, "v2")
+	})
+}
+
+func TestAdapterLayered_Invalidation(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.Background()
+			bus    = &localBus{}
+			remote = gcache.NewAdapterMemory()
+		)
+		node1, err := gcache.NewAdapterLayered(remote, gcache.AdapterLayeredOptions{Bus: bus})
+		t.Assert(err, nil)
+		node2, err := gcache.NewAdapterLayered(remote, gcache.AdapterLayeredOptions{Bus: bus})
+		t.Assert(err, nil)
+
+		t.Assert(node1.Set(ctx, 1, "v1", 0), nil)
+		v, _ := node2.Get(ctx, 1)
+		t.Assert(v, "v1")
+
+		t.Assert(node1.Set(ctx, 1, "v2", 0), nil)
+		v, _ = node2.Get(ctx, 1)
+		t.Assert(v, "v2")
+
+		_, _, err = node2.Update(ctx, 1, "v3")
+		t.Assert(err, nil)
+		v, _ = node1.Get(ctx, 1)
+		t.Assert(v, "v3")
+
+		_, err = node1.Remove(ctx, 1)
+		t.Assert(err, nil)
+		v, _ = node2.Get(ctx, 1)
+		t.Assert(v, nil)
+
+		t.Assert(node1.Set(ctx, 2, "v", 0), nil)
+		v, _ = node2.Get(ctx, 2)
+		t.Assert(v, "v")
+		t.Assert(node1.Clear(ctx), nil)
+		v, _ = node2.Get(ctx, 2)
+		t.Assert(v, nil)
+	})
+}
+
+func TestAdapterLayered_ValueTypes(t *testing.T) {
+	type User struct {
+		Id   int
+		Name string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.Background()
+			bus    = &localBus{}
+			remote = gcache.NewAdapterMemory()
+			user   = User{Id: 1, Name: "john"}
+		)
+		node1, err := gcache.NewAdapterLayered(remote, gcache.AdapterLayeredOptions{Bus: bus})
+		t.Assert(err, nil)
+		node2, err := gcache.NewAdapterLayered(remote, gcache.AdapterLayeredOptions{Bus: bus})
+		t.Assert(err, nil)
+
+		// Unregistered struct is decoded as map on all nodes.
+		t.Assert(node1.Set(ctx, "user", user, 0), nil)
+		v1, _ := node1.Get(ctx, "user")
+		v2, _ := node2.Get(ctx, "user")
+		t.Assert(reflect.TypeOf(v1), reflect.TypeOf(v2))
+		t.Assert(v1, v2)
+
+		t.Assert(node1.Set(ctx, "id", int64(1), 0), nil)
+		v1, _ = node1.Get(ctx, "id")
+		v2, _ = node2.Get(ctx, "id")
+		t.Assert(reflect.TypeOf(v1), reflect.TypeOf(v2))
+	})
+}
+
+func TestAdapterLayered_InvalidationDuringGet(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx    = context.Background()
+			bus    = &localBus{}
+			remote = &remoteAdapter{Adapter: gcache.NewAdapterMemory()}
+		)
+		node1, err := gcache.NewAdapterLayered(remote, gcache.AdapterLayeredOptions{Bus: bus})
+		t.Assert(err, nil)
+		node2, err := gcache.NewAdapterLayered(remote, gcache.AdapterLayeredOptions{Bus: bus})
+		t.Assert(err, nil)
+
+		t.Assert(remote.Set(ctx, "k", "v1", 0), nil)
+		// The value is changed by node1 after node2 retrieved the old value from L2.
+		remote.onGet = func() {
+			remote.onGet = nil
+			t.Assert(node1.Set(ctx, "k", "v2", 0), nil)
+		}
+		v, err := node2.Get(ctx, "k")
+		t.Assert(err, nil)
+		t.Assert(v, "v1")
+		v, err = node2.Get(ctx, "k")
+		t.Assert(err, nil)
+		t.Assert(v, "v2")
+	})
+}
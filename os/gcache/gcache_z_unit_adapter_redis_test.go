@@ -0,0 +1,219 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/database/gredis"
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/os/gcache"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/util/gconv"
+	"github.com/gogf/gf/util/guid"
+)
+
+func newRedisCache(t *gtest.T) *gcache.Cache {
+	redis, err := gredis.NewFromStr("127.0.0.1:6379,1")
+	t.Assert(err, nil)
+	cache := gcache.New()
+	cache.SetAdapter(gcache.NewAdapterRedis(redis, guid.S()+":"))
+	return cache
+}
+
+func TestAdapterRedis_SetGet(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cache := newRedisCache(t)
+		defer cache.Clear()
+
+		t.Assert(cache.Set(1, 11, 0), nil)
+		v, err := cache.Get(1)
+		t.Assert(err, nil)
+		t.Assert(v, 11)
+		ok, err := cache.Contains(1)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+
+		t.Assert(cache.Set("user", g.Map{"id": 1, "name": "john"}, 0), nil)
+		value, err := cache.GetVar("user")
+		t.Assert(err, nil)
+		t.Assert(value.Map()["name"], "john")
+
+		t.Assert(cache.Set(1, nil, 0), nil)
+		ok, err = cache.Contains(1)
+		t.Assert(err, nil)
+		t.Assert(ok, false)
+	})
+}
+
+func TestAdapterRedis_Expire(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cache := newRedisCache(t)
+		defer cache.Clear()
+
+		t.Assert(cache.Set(1, 11, time.Second), nil)
+		expire, err := cache.GetExpire(1)
+		t.Assert(err, nil)
+		t.Assert(expire > 0 && expire <= time.Second, true)
+
+		oldExpire, err := cache.UpdateExpire(1, 0)
+		t.Assert(err, nil)
+		t.Assert(oldExpire > 0, true)
+		expire, _ = cache.GetExpire(1)
+		t.Assert(expire == 0, true)
+
+		oldExpire, err = cache.UpdateExpire(2, time.Second)
+		t.Assert(err, nil)
+		t.Assert(oldExpire < 0, true)
+
+		t.Assert(cache.Set(3, 33, 100*time.Millisecond), nil)
+		time.Sleep(200 * time.Millisecond)
+		v, _ := cache.Get(3)
+		t.Assert(v, nil)
+	})
+}
+
+func TestAdapterRedis_Update(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cache := newRedisCache(t)
+		defer cache.Clear()
+
+		t.Assert(cache.Set(1, 11, time.Second), nil)
+		oldValue, exist, err := cache.Update(1, 12)
+		t.Assert(err, nil)
+		t.Assert(exist, true)
+		t.Assert(oldValue, 11)
+		v, _ := cache.Get(1)
+		t.Assert(v, 12)
+		expire, _ := cache.GetExpire(1)
+		t.Assert(expire > 0, true)
+
+		_, exist, err = cache.Update(2, 22)
+		t.Assert(err, nil)
+		t.Assert(exist, false)
+	})
+}
+
+func TestAdapterRedis_SetIfNotExist(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cache := newRedisCache(t)
+		defer cache.Clear()
+
+		ok, err := cache.SetIfNotExist(1, 11, 0)
+		t.Assert(err, nil)
+		t.Assert(ok, true)
+		ok, err = cache.SetIfNotExist(1, 12, 0)
+		t.Assert(err, nil)
+		t.Assert(ok, false)
+		v, _ := cache.Get(1)
+		t.Assert(v, 11)
+	})
+}
+
+func TestAdapterRedis_GetOrSetFuncLock(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cache := newRedisCache(t)
+		defer cache.Clear()
+
+		v, err := cache.GetOrSetFuncLock(1, func() (interface{}, error) {
+			return 11, nil
+		}, 0)
+		t.Assert(err, nil)
+		t.Assert(v, 11)
+		v, err = cache.GetOrSetFuncLock(1, func() (interface{}, error) {
+			return 12, nil
+		}, 0)
+		t.Assert(err, nil)
+		t.Assert(v, 11)
+	})
+}
+
+func TestAdapterRedis_KeysValues(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cache := newRedisCache(t)
+		defer cache.Clear()
+
+		t.Assert(cache.Sets(g.MapAnyAny{1: 11, 2: 22}, 0), nil)
+		keys, err := cache.KeyStrings()
+		t.Assert(err, nil)
+		t.AssertIN("1", keys)
+		t.AssertIN("2", keys)
+		rawKeys, err := cache.Keys()
+		t.Assert(err, nil)
+		t.AssertIN(1, rawKeys)
+		for _, key := range rawKeys {
+			_, ok := key.(int)
+			t.Assert(ok, true)
+		}
+		values, err := cache.Values()
+		t.Assert(err, nil)
+		t.Assert(len(values), 2)
+		size, err := cache.Size()
+		t.Assert(err, nil)
+		t.Assert(size, 2)
+
+		v, err := cache.Remove(1, 2)
+		t.Assert(err, nil)
+		t.Assert(v, 22)
+		size, _ = cache.Size()
+		t.Assert(size, 0)
+	})
+}
+
+func TestSerializerJson(t *testing.T) {
+	type User struct {
+		Id   int
+		Name string
+	}
+	gcache.RegisterType(User{}, &User{})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			now    = time.Unix(1600000000, 0)
+			values = []interface{}{
+				[]byte{0, 1, 2},
+				"john",
+				"",
+				11,
+				int64(12),
+				uint8(13),
+				1.5,
+				true,
+				now,
+				[]string{"a", "b"},
+				map[string]interface{}{"id": 1},
+				User{Id: 1, Name: "john"},
+				&User{Id: 2, Name: "smith"},
+			}
+		)
+		for _, value := range values {
+			data, err := gcache.SerializerJson.Serialize(value)
+			t.Assert(err, nil)
+			decoded, err := gcache.SerializerJson.Deserialize(data)
+			t.Assert(err, nil)
+			t.Assert(reflect.TypeOf(decoded), reflect.TypeOf(value))
+			t.Assert(decoded, value)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// Unregistered types are decoded as generic JSON values.
+		type Item struct {
+			Name string
+		}
+		data, err := gcache.SerializerJson.Serialize(Item{Name: "john"})
+		t.Assert(err, nil)
+		decoded, err := gcache.SerializerJson.Deserialize(data)
+		t.Assert(err, nil)
+		t.Assert(decoded, g.Map{"Name": "john"})
+
+		// Plain JSON without type tag.
+		decoded, err = gcache.SerializerJson.Deserialize([]byte(`{"id":1}`))
+		t.Assert(err, nil)
+		t.Assert(gconv.Map(decoded)["id"], 1)
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/container/gmap"
+	"github.com/gogf/gf/os/gcache"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/util/gconv"
+)
+
+func TestCache_Eviction_LFU(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gcache.NewWithOptions(gcache.AdapterMemoryOptions{
+			Cap:    3,
+			Policy: gcache.EvictionLFU,
+		})
+		defer c.Close()
+		for i := 1; i <= 3; i++ {
+			c.Set(i, i, 0)
+		}
+		time.Sleep(1500 * time.Millisecond)
+		c.Get(1)
+		c.Get(1)
+		c.Get(2)
+		c.Set(4, 4, 0)
+		time.Sleep(1500 * time.Millisecond)
+		n, _ := c.Size()
+		t.Assert(n, 3)
+		for k, v := range map[int]bool{1: true, 2: true, 3: false, 4: true} {
+			ok, _ := c.Contains(k)
+			t.Assert(ok, v)
+		}
+	})
+}
+
+func TestCache_Eviction_TinyLFU(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gcache.NewWithOptions(gcache.AdapterMemoryOptions{
+			Cap:    100,
+			Policy: gcache.EvictionTinyLFU,
+		})
+		defer c.Close()
+		// Hot keys accessed frequently.
+		for i := 0; i < 50; i++ {
+			c.Set(i, i, 0)
+		}
+		time.Sleep(1500 * time.Millisecond)
+		for n := 0; n < 5; n++ {
+			for i := 0; i < 50; i++ {
+				c.Get(i)
+			}
+		}
+		// Scanning keys accessed only once, which should not flush the hot keys.
+		for i := 1000; i < 2000; i++ {
+			c.Set(i, i, 0)
+		}
+		time.Sleep(1500 * time.Millisecond)
+		n, _ := c.Size()
+		t.Assert(n, 100)
+		hot := 0
+		for i := 0; i < 50; i++ {
+			if ok, _ := c.Contains(i); ok {
+				hot++
+			}
+		}
+		t.Assert(hot, 50)
+	})
+}
+
+func TestCache_Eviction_ARC(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gcache.NewWithOptions(gcache.AdapterMemoryOptions{
+			Cap:    3,
+			Policy: gcache.EvictionARC,
+		})
+		defer c.Close()
+		for i := 1; i <= 3; i++ {
+			c.Set(i, i, 0)
+		}
+		time.Sleep(1500 * time.Millisecond)
+		c.Get(1)
+		c.Set(4, 4, 0)
+		time.Sleep(1500 * time.Millisecond)
+		n, _ := c.Size()
+		t.Assert(n, 3)
+		for k, v := range map[int]bool{1: true, 2: false, 3: true, 4: true} {
+			ok, _ := c.Contains(k)
+			t.Assert(ok, v)
+		}
+	})
+}
+
+func TestCache_Eviction_MaxBytes(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		reasons := gmap.NewStrStrMap(true)
+		c := gcache.NewWithOptions(gcache.AdapterMemoryOptions{
+			MaxBytes: 10,
+			SizeFunc: func(key, value interface{}) int64 {
+				return int64(len(gconv.String(value)))
+			},
+			OnEvicted: func(key, value interface{}, reason gcache.EvictionReason) {
+				reasons.Set(gconv.String(key), reason.String())
+			},
+		})
+		defer c.Close()
+		c.Set("a", "12345", 0)
+		c.Set("b", "12345", 0)
+		time.Sleep(1500 * time.Millisecond)
+		n, _ := c.Size()
+		t.Assert(n, 2)
+
+		c.Set("c", "12345", 0)
+		time.Sleep(1500 * time.Millisecond)
+		n, _ = c.Size()
+		t.Assert(n, 2)
+		ok, _ := c.Contains("a")
+		t.Assert(ok, false)
+		t.Assert(reasons.Get("a"), "evicted")
+
+		c.Remove("b")
+		t.Assert(reasons.Get("b"), "removed")
+
+		c.Set("d", "1", 100*time.Millisecond)
+		time.Sleep(3500 * time.Millisecond)
+		t.Assert(reasons.Get("d"), "expired")
+		t.Assert(reasons.Contains("c"), false)
+	})
+}
+
+func TestCache_Eviction_MaxBytesWithoutSizeFunc(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		gcache.NewWithOptions(gcache.AdapterMemoryOptions{
+			MaxBytes: 10,
+		})
+	})
+}
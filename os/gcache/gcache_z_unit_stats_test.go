@@ -0,0 +1,165 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/container/gtype"
+	"github.com/gogf/gf/os/gcache"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func TestCache_Stats(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := gcache.New()
+		defer c.Close()
+		c.Set(1, 1, 0)
+		c.Get(1)
+		c.Get(2)
+		c.GetOrSetFunc(3, func() (interface{}, error) {
+			time.Sleep(10 * time.Millisecond)
+			return 3, nil
+		}, 0)
+		c.GetOrSetFunc(4, func() (interface{}, error) {
+			return nil, errors.New("error")
+		}, 0)
+		c.GetOrSetFuncLock(3, func() (interface{}, error) {
+			return 33, nil
+		}, 0)
+
+		s := c.Stats()
+		t.Assert(s.Hits, 2)
+		t.Assert(s.Misses, 3)
+		t.Assert(s.Loads, 2)
+		t.Assert(s.LoadErrors, 1)
+		t.Assert(s.HitRatio(), 0.4)
+		t.Assert(s.AvgLoadTime() >= 5*time.Millisecond, true)
+		t.Assert(c.Metrics()["hits"], 2)
+
+		c.ResetStats()
+		t.Assert(c.Stats().Hits, 0)
+		t.Assert(c.Stats().HitRatio(), 0)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// Eviction only: no TTL, one item over the LRU capacity.
+		c := gcache.New(2)
+		defer c.Close()
+		c.Set(1, 1, 0)
+		c.Set(2, 2, 0)
+		c.Set(3, 3, 0)
+		time.Sleep(2500 * time.Millisecond)
+		s := c.Stats()
+		t.Assert(s.Evictions >= 1, true)
+		t.Assert(s.Expirations, 0)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// Expiration only: no LRU capacity.
+		c := gcache.New()
+		defer c.Close()
+		c.Set(1, 1, 0)
+		c.Set(2, 2, 100*time.Millisecond)
+		time.Sleep(2500 * time.Millisecond)
+		s := c.Stats()
+		t.Assert(s.Expirations >= 1, true)
+		t.Assert(s.Evictions, 0)
+	})
+}
+
+func TestCache_GetOrSetFunc_Coalescing(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			c     = gcache.New()
+			calls = gtype.NewInt()
+			wg    = sync.WaitGroup{}
+		)
+		defer c.Close()
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				v, err := c.GetOrSetFunc(1, func() (interface{}, error) {
+					calls.Add(1)
+					time.Sleep(100 * time.Millisecond)
+					return 1, nil
+				}, 0)
+				t.Assert(err, nil)
+				t.Assert(v, 1)
+			}()
+			go func() {
+				defer wg.Done()
+				v, err := c.GetOrSetFuncLock(2, func() (interface{}, error) {
+					calls.Add(1)
+					time.Sleep(100 * time.Millisecond)
+					return 2, nil
+				}, 0)
+				t.Assert(err, nil)
+				t.Assert(v, 2)
+			}()
+		}
+		wg.Wait()
+		t.Assert(calls.Val(), 2)
+		t.Assert(c.Stats().Loads, 2)
+	})
+}
+
+func TestCache_RefreshAhead(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			c       = gcache.New()
+			counter = gtype.NewInt()
+			f       = func() (interface{}, error) {
+				return counter.Add(1), nil
+			}
+		)
+		defer c.Close()
+		c.SetRefreshOptions(gcache.RefreshOptions{
+			RefreshAhead: 0.5,
+		})
+		v, _ := c.GetOrSetFunc(1, f, time.Second)
+		t.Assert(v, 1)
+		v, _ = c.GetOrSetFunc(1, f, time.Second)
+		t.Assert(v, 1)
+
+		time.Sleep(600 * time.Millisecond)
+		v, _ = c.GetOrSetFunc(1, f, time.Second)
+		t.Assert(v, 1)
+		time.Sleep(100 * time.Millisecond)
+		v, _ = c.Get(1)
+		t.Assert(v, 2)
+		expire, _ := c.GetExpire(1)
+		t.Assert(expire > 800*time.Millisecond, true)
+	})
+}
+
+func TestCache_StaleWhileRevalidate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			c       = gcache.New()
+			counter = gtype.NewInt()
+			f       = func() (interface{}, error) {
+				return counter.Add(1), nil
+			}
+		)
+		defer c.Close()
+		c.SetRefreshOptions(gcache.RefreshOptions{
+			StaleTTL: time.Second,
+		})
+		v, _ := c.GetOrSetFunc(1, f, 500*time.Millisecond)
+		t.Assert(v, 1)
+
+		time.Sleep(700 * time.Millisecond)
+		v, _ = c.GetOrSetFunc(1, f, 500*time.Millisecond)
+		t.Assert(v, 1)
+		time.Sleep(100 * time.Millisecond)
+		v, _ = c.Get(1)
+		t.Assert(v, 2)
+	})
+}
@@ -8,8 +8,11 @@
 package gcfg
 
 import (
+	"sync"
+
 	"github.com/gogf/gf/container/garray"
 	"github.com/gogf/gf/container/gmap"
+	"github.com/gogf/gf/container/gtype"
 	"github.com/gogf/gf/internal/intlog"
 	"github.com/gogf/gf/os/gcmd"
 )
@@ -17,15 +20,24 @@ import (
 // Config is the configuration manager.
 type Config struct {
 	defaultName   string           // Default configuration file name.
+	profile       string           // Profile of the configuration, eg: dev, prod.
 	searchPaths   *garray.StrArray // Searching path array.
 	jsonMap       *gmap.StrAnyMap  // The pared JSON objects for configuration files.
+	adapters      *garray.Array    // Configuration source adapters layered on top of the default configuration file.
+	subscribers   *garray.Array    // Subscribers of configuration changes, see OnChange and Watch.
+	validators    *garray.Array    // Validators checking the new configuration on reloading.
+	reloadMu      sync.Mutex       // Mutex for reloading, which makes reloading in sequence.
+	reloadDelay   *gtype.Int64     // Delay for reloading after changes, which merges burst changes.
+	reloadEntries *gmap.StrAnyMap  // Timer entries of scheduled reloading by configuration name.
 	violenceCheck bool             // Whether do violence check in value index searching. It affects the performance when set true(false in default).
+	keyProvider   KeyProvider      // Key provider for encrypted values, which uses the key from command option or environment if nil.
 }
 
 const (
 	DefaultName       = "config"             // DefaultName is the default group name for instance usage.
 	DefaultConfigFile = "config.toml"        // DefaultConfigFile is the default configuration file name.
 	cmdEnvKey         = "gf.gcfg"            // cmdEnvKey is the configuration key for command argument or environment.
+	profileCmdEnvKey  = "gf.profile"         // profileCmdEnvKey is the key for command argument or environment specifying the profile.
 	errorPrintKey     = "gf.gcfg.errorprint" // errorPrintKey is used to specify the key controlling error printing to stdout.
 )
 
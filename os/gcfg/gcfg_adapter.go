@@ -0,0 +1,76 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"strings"
+)
+
+// Adapter is the interface for configuration sources, which are layered on top of the
+// default configuration file of Config. See Config.AddAdapter.
+type Adapter interface {
+	// Name returns the name of the source, which is used in logging and error messages.
+	Name() string
+
+	// Load loads and returns the configuration data of the source.
+	// The hierarchical keys should be nested maps, eg: {"server": {"address": ":80"}}.
+	Load() (map[string]interface{}, error)
+}
+
+// AdapterWatcher is the optional interface for Adapter which can notify the changes of its source.
+// Config drops its cached configuration when `callback` is called, and reloads all sources on next access.
+type AdapterWatcher interface {
+	// Watch registers `callback` which is called after the source content changes.
+	Watch(callback func()) error
+}
+
+// mergeMap deeply merges `src` into `dst`, in which the values of `src` take precedence.
+//
+// The keys of `src` are matched case-insensitively against the existing keys of `dst`, so that
+// sources like environment variables which have no letter case can override the keys of file.
+// The maps of `src` are copied, so `src` is never modified by later merging.
+func mergeMap(dst, src map[string]interface{}) {
+	for k, v := range src {
+		key := k
+		if _, ok := dst[key]; !ok {
+			for dstKey := range dst {
+				if strings.EqualFold(dstKey, k) {
+					key = dstKey
+					break
+				}
+			}
+		}
+		srcMap, srcIsMap := v.(map[string]interface{})
+		if !srcIsMap {
+			dst[key] = v
+			continue
+		}
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if !dstIsMap {
+			dstMap = make(map[string]interface{}, len(srcMap))
+			dst[key] = dstMap
+		}
+		mergeMap(dstMap, srcMap)
+	}
+}
+
+// setMapValueByKeys sets `value` to `m` in hierarchy by `keys`, which creates the
+// middle maps if necessary. It overwrites the middle value if it's not a map.
+func setMapValueByKeys(m map[string]interface{}, keys []string, value interface{}) {
+	for i, key := range keys {
+		if i == len(keys)-1 {
+			m[key] = value
+			return
+		}
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[key] = next
+		}
+		m = next
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/os/gcmd"
+)
+
+// AdapterCmd is the configuration source of command line options, in which the option
+// names are hierarchical configuration keys separated by char '.', eg:
+// --server.address=:8080 -> server.address.
+type AdapterCmd struct {
+	prefix string       // Prefix of option names, eg: "config.".
+	parser *gcmd.Parser // Custom parser, it uses the default options of gcmd if nil.
+}
+
+// NewAdapterCmd creates and returns a configuration source of command line options.
+// The optional parameter `prefix` specifies that only the options with name prefix
+// `prefix` are used, and the prefix is trimmed from the configuration key, eg:
+// --config.server.address=:8080 -> server.address, if the prefix is "config".
+func NewAdapterCmd(prefix ...string) *AdapterCmd {
+	a := &AdapterCmd{}
+	if len(prefix) > 0 && prefix[0] != "" {
+		a.prefix = strings.TrimRight(prefix[0], ".") + "."
+	}
+	return a
+}
+
+// NewAdapterCmdWithParser creates and returns a configuration source of options of `parser`.
+// See NewAdapterCmd.
+func NewAdapterCmdWithParser(parser *gcmd.Parser, prefix ...string) *AdapterCmd {
+	a := NewAdapterCmd(prefix...)
+	a.parser = parser
+	return a
+}
+
+// Name returns the name of the source.
+func (a *AdapterCmd) Name() string {
+	return "cmd:" + a.prefix
+}
+
+// Load loads and returns the configuration data of command line options.
+func (a *AdapterCmd) Load() (map[string]interface{}, error) {
+	var options map[string]string
+	if a.parser != nil {
+		options = a.parser.GetOptAll()
+	} else {
+		options = gcmd.GetOptAll()
+	}
+	names := make([]string, 0, len(options))
+	for name := range options {
+		if strings.HasPrefix(name, a.prefix) && len(name) > len(a.prefix) {
+			names = append(names, name)
+		}
+	}
+	// Sorted, so that the option with deeper hierarchy takes precedence.
+	sort.Strings(names)
+	data := make(map[string]interface{})
+	for _, name := range names {
+		setMapValueByKeys(data, strings.Split(name[len(a.prefix):], "."), options[name])
+	}
+	return data, nil
+}
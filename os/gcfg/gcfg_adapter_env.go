@@ -0,0 +1,60 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// AdapterEnv is the configuration source of environment variables.
+//
+// The environment variable names are converted to hierarchical configuration keys by
+// trimming the prefix, converting to lower case and replacing char '_' with '.', eg:
+// APP_SERVER_ADDRESS -> server.address, if the prefix is "APP".
+type AdapterEnv struct {
+	prefix string // Prefix of environment variable names, which is upper case and ends with '_'.
+}
+
+// NewAdapterEnv creates and returns a configuration source of environment variables,
+// which only uses the variables with name prefix `prefix`, eg: "APP".
+//
+// Note that all environment variables are used if `prefix` is empty.
+func NewAdapterEnv(prefix string) *AdapterEnv {
+	prefix = strings.ToUpper(strings.TrimRight(prefix, "_"))
+	if prefix != "" {
+		prefix += "_"
+	}
+	return &AdapterEnv{prefix: prefix}
+}
+
+// Name returns the name of the source.
+func (a *AdapterEnv) Name() string {
+	return "env:" + a.prefix
+}
+
+// Load loads and returns the configuration data of environment variables.
+func (a *AdapterEnv) Load() (map[string]interface{}, error) {
+	environ := os.Environ()
+	// Sorted, so that the variable with deeper hierarchy takes precedence,
+	// eg: APP_SERVER_ADDRESS overwrites APP_SERVER.
+	sort.Strings(environ)
+	data := make(map[string]interface{})
+	for _, v := range environ {
+		array := strings.SplitN(v, "=", 2)
+		if len(array) != 2 || !strings.HasPrefix(strings.ToUpper(array[0]), a.prefix) {
+			continue
+		}
+		name := strings.Trim(array[0][len(a.prefix):], "_")
+		if name == "" {
+			continue
+		}
+		setMapValueByKeys(data, strings.Split(strings.ToLower(name), "_"), array[1])
+	}
+	return data, nil
+}
@@ -0,0 +1,131 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"sort"
+
+	"github.com/gogf/gf/container/garray"
+	"github.com/gogf/gf/encoding/gjson"
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gfsnotify"
+	"github.com/gogf/gf/os/gres"
+)
+
+// AdapterFile is the configuration source of a single configuration file.
+type AdapterFile struct {
+	path string // Absolute path of the file or path in resource manager.
+}
+
+// AdapterDir is the configuration source of a directory, which merges all the
+// configuration files of supported types directly in the directory by file name
+// order, that the latter file takes precedence, eg: 00-base.toml < 10-override.yaml.
+type AdapterDir struct {
+	path string // Absolute path of the directory.
+}
+
+// NewAdapterFile creates and returns a configuration source of file `path`.
+// The parameter `path` can be an absolute or relative path, or a path in resource manager.
+func NewAdapterFile(path string) (*AdapterFile, error) {
+	if gres.Contains(path) {
+		return &AdapterFile{path: path}, nil
+	}
+	realPath := gfile.RealPath(path)
+	if realPath == "" || gfile.IsDir(realPath) {
+		return nil, gerror.Newf(`[gcfg] configuration file "%s" does not exist`, path)
+	}
+	return &AdapterFile{path: realPath}, nil
+}
+
+// Name returns the name of the source.
+func (a *AdapterFile) Name() string {
+	return "file:" + a.path
+}
+
+// Load loads and returns the configuration data of the file.
+func (a *AdapterFile) Load() (map[string]interface{}, error) {
+	return loadFileData(a.path)
+}
+
+// Watch registers `callback` which is called after the file changes.
+func (a *AdapterFile) Watch(callback func()) error {
+	if gres.Contains(a.path) {
+		return nil
+	}
+	_, err := gfsnotify.Add(a.path, func(event *gfsnotify.Event) {
+		callback()
+	})
+	return err
+}
+
+// NewAdapterDir creates and returns a configuration source of directory `path`.
+func NewAdapterDir(path string) (*AdapterDir, error) {
+	realPath := gfile.RealPath(path)
+	if realPath == "" || !gfile.IsDir(realPath) {
+		return nil, gerror.Newf(`[gcfg] configuration directory "%s" does not exist`, path)
+	}
+	return &AdapterDir{path: realPath}, nil
+}
+
+// Name returns the name of the source.
+func (a *AdapterDir) Name() string {
+	return "dir:" + a.path
+}
+
+// Load loads and returns the merged configuration data of the files in the directory.
+func (a *AdapterDir) Load() (map[string]interface{}, error) {
+	files, err := gfile.ScanDirFile(a.path, "*")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	data := make(map[string]interface{})
+	for _, file := range files {
+		if !garray.NewStrArrayFrom(supportedFileTypes).Contains(gfile.ExtName(file)) {
+			continue
+		}
+		fileData, err := loadFileData(file)
+		if err != nil {
+			return nil, err
+		}
+		mergeMap(data, fileData)
+	}
+	return data, nil
+}
+
+// Watch registers `callback` which is called after any file changes in the directory.
+func (a *AdapterDir) Watch(callback func()) error {
+	_, err := gfsnotify.Add(a.path, func(event *gfsnotify.Event) {
+		callback()
+	}, false)
+	return err
+}
+
+// loadFileData reads and parses the configuration file `path` according to its file type.
+func loadFileData(path string) (map[string]interface{}, error) {
+	var content []byte
+	if file := gres.Get(path); file != nil {
+		content = file.Content()
+	} else {
+		content = gfile.GetBytes(path)
+	}
+	var (
+		j        *gjson.Json
+		err      error
+		dataType = gfile.ExtName(path)
+	)
+	if gjson.IsValidDataType(dataType) {
+		j, err = gjson.LoadContentType(dataType, content)
+	} else {
+		j, err = gjson.LoadContent(content)
+	}
+	if err != nil {
+		return nil, gerror.Newf(`[gcfg] load config file "%s" failed: %s`, path, err.Error())
+	}
+	return j.Map(), nil
+}
@@ -0,0 +1,181 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/encoding/gjson"
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/os/gtimer"
+)
+
+// AdapterHttp is the configuration source polling configuration content from HTTP server,
+// like a remote KV store that returns the raw value of a key, eg: Consul KV with "?raw".
+//
+// It fetches the content at intervals after the first watcher is registered, eg: it's added
+// to Config by AddAdapter, and notifies the watchers if the content changes.
+// If a fetching fails, it keeps using the last content successfully fetched.
+type AdapterHttp struct {
+	mu        sync.RWMutex
+	options   AdapterHttpOptions
+	client    *http.Client
+	content   []byte        // Last content successfully fetched.
+	fetched   bool          // Whether the content was ever fetched successfully.
+	callbacks []func()      // Callbacks of watchers.
+	entry     *gtimer.Entry // Timer entry of polling, which is created by the first Watch.
+	closed    bool          // Whether the adapter is closed.
+}
+
+// AdapterHttpOptions is the options for AdapterHttp.
+type AdapterHttpOptions struct {
+	Url      string            `json:"url"`      // URL of the configuration content.
+	Headers  map[string]string `json:"headers"`  // Extra request headers, eg: Authorization, X-Consul-Token.
+	DataType string            `json:"dataType"` // Data type of content: json, xml, ini, yaml, toml. It's checked automatically if empty.
+	Interval time.Duration     `json:"interval"` // Polling interval, which is 30 seconds in default.
+	Timeout  time.Duration     `json:"timeout"`  // Request timeout, which is 10 seconds in default.
+}
+
+const (
+	defaultAdapterHttpInterval = 30 * time.Second
+	defaultAdapterHttpTimeout  = 10 * time.Second
+)
+
+// NewAdapterHttp creates and returns a configuration source of HTTP server.
+// Note that it starts polling the content after the first watcher is registered by Watch.
+func NewAdapterHttp(options AdapterHttpOptions) (*AdapterHttp, error) {
+	if options.Url == "" {
+		return nil, gerror.New("[gcfg] http adapter url cannot be empty")
+	}
+	if options.DataType != "" && !gjson.IsValidDataType(options.DataType) {
+		return nil, gerror.Newf(`[gcfg] invalid data type for http adapter: %s`, options.DataType)
+	}
+	if options.Interval <= 0 {
+		options.Interval = defaultAdapterHttpInterval
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultAdapterHttpTimeout
+	}
+	return &AdapterHttp{
+		options: options,
+		client:  &http.Client{Timeout: options.Timeout},
+	}, nil
+}
+
+// Name returns the name of the source.
+func (a *AdapterHttp) Name() string {
+	return "http:" + a.options.Url
+}
+
+// Load returns the configuration data of the content last fetched.
+// It fetches the content if it was never fetched successfully.
+func (a *AdapterHttp) Load() (map[string]interface{}, error) {
+	a.mu.RLock()
+	content, fetched := a.content, a.fetched
+	a.mu.RUnlock()
+	if !fetched {
+		var err error
+		if content, err = a.fetch(); err != nil {
+			return nil, err
+		}
+		a.mu.Lock()
+		a.content, a.fetched = content, true
+		a.mu.Unlock()
+	}
+	var (
+		j   *gjson.Json
+		err error
+	)
+	if a.options.DataType != "" {
+		j, err = gjson.LoadContentType(a.options.DataType, content)
+	} else {
+		j, err = gjson.LoadContent(content)
+	}
+	if err != nil {
+		return nil, gerror.Newf(`[gcfg] load config from "%s" failed: %s`, a.options.Url, err.Error())
+	}
+	return j.Map(), nil
+}
+
+// Watch registers `callback` which is called after the content changes.
+// It starts polling the content if it is not started yet.
+func (a *AdapterHttp) Watch(callback func()) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return gerror.Newf(`[gcfg] http adapter is closed: %s`, a.options.Url)
+	}
+	a.callbacks = append(a.callbacks, callback)
+	if a.entry == nil {
+		a.entry = gtimer.AddSingleton(a.options.Interval, a.poll)
+	}
+	return nil
+}
+
+// Close stops polling of the adapter.
+func (a *AdapterHttp) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closed = true
+	if a.entry != nil {
+		a.entry.Close()
+		a.entry = nil
+	}
+}
+
+// poll fetches the content and notifies the watchers if it changes,
+// or it is fetched successfully the first time.
+func (a *AdapterHttp) poll() {
+	content, err := a.fetch()
+	if err != nil {
+		intlog.Error(err)
+		return
+	}
+	a.mu.Lock()
+	changed := !a.fetched || !bytes.Equal(a.content, content)
+	a.content, a.fetched = content, true
+	callbacks := a.callbacks
+	a.mu.Unlock()
+	if changed {
+		intlog.Printf(`configuration content changed: %s`, a.options.Url)
+		for _, callback := range callbacks {
+			callback()
+		}
+	}
+}
+
+// fetch requests and returns the content from HTTP server.
+func (a *AdapterHttp) fetch() ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, a.options.Url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range a.options.Headers {
+		request.Header.Set(k, v)
+	}
+	response, err := a.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, gerror.Newf(
+			`[gcfg] fetch config from "%s" failed: %d %s`,
+			a.options.Url, response.StatusCode, bytes.TrimSpace(content),
+		)
+	}
+	return content, nil
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"github.com/gogf/gf/container/garray"
 	"github.com/gogf/gf/container/gmap"
+	"github.com/gogf/gf/container/gtype"
 	"github.com/gogf/gf/encoding/gjson"
 	"github.com/gogf/gf/errors/gerror"
 	"github.com/gogf/gf/internal/intlog"
@@ -38,9 +39,18 @@ func New(file ...string) *Config {
 		}
 	}
 	c := &Config{
-		defaultName: name,
-		searchPaths: garray.NewStrArray(true),
-		jsonMap:     gmap.NewStrAnyMap(true),
+		defaultName:   name,
+		searchPaths:   garray.NewStrArray(true),
+		jsonMap:       gmap.NewStrAnyMap(true),
+		adapters:      garray.New(true),
+		subscribers:   garray.New(true),
+		validators:    garray.New(true),
+		reloadDelay:   gtype.NewInt64(int64(defaultReloadDelay)),
+		reloadEntries: gmap.NewStrAnyMap(true),
+	}
+	// Profile from command line or environment.
+	if profile := gcmd.GetOptWithEnv(profileCmdEnvKey).String(); profile != "" {
+		c.profile = profile
 	}
 	// Customized dir path from env/cmd.
 	if customPath := gcmd.GetOptWithEnv(fmt.Sprintf("%s.path", cmdEnvKey)).String(); customPath != "" {
@@ -347,8 +357,49 @@ func (c *Config) autoCheckAndAddMainPkgPathToSearchPaths() {
 	}
 }
 
+// AddAdapter adds configuration source adapters, which are layered on top of the default
+// configuration file. The configuration of the default file name is the merged view of all
+// sources with precedence:
+// default configuration file < profile file < adapters in the order added, that the latter
+// one takes precedence.
+//
+// A common layering is like:
+// c.AddAdapter(dirAdapter, httpAdapter, NewAdapterEnv("APP"), NewAdapterCmd())
+//
+// The configuration is reloaded if any adapter implementing AdapterWatcher notifies changes.
+func (c *Config) AddAdapter(adapters ...Adapter) error {
+	for _, adapter := range adapters {
+		if watcher, ok := adapter.(AdapterWatcher); ok {
+			err := watcher.Watch(func() {
+				c.scheduleReload(c.defaultName)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		c.adapters.Append(adapter)
+		intlog.Print("AddAdapter:", adapter.Name())
+	}
+	c.jsonMap.Clear()
+	return nil
+}
+
+// GetAdapters returns all adapters of the configuration in the order added.
+func (c *Config) GetAdapters() []Adapter {
+	adapters := make([]Adapter, 0, c.adapters.Len())
+	c.adapters.RLockFunc(func(array []interface{}) {
+		for _, v := range array {
+			adapters = append(adapters, v.(Adapter))
+		}
+	})
+	return adapters
+}
+
 // getJson returns a *gjson.Json object for the specified `file` content.
 // It would print error if file reading fails. It return nil if any error occurs.
+//
+// The configuration of the default file name is merged with its profile file and all the
+// adapters if any.
 func (c *Config) getJson(file ...string) *gjson.Json {
 	var name string
 	if len(file) > 0 && file[0] != "" {
@@ -357,59 +408,10 @@ func (c *Config) getJson(file ...string) *gjson.Json {
 		name = c.defaultName
 	}
 	r := c.jsonMap.GetOrSetFuncLock(name, func() interface{} {
-		var (
-			err      error
-			content  string
-			filePath string
-		)
-		// The configured content can be any kind of data type different from its file type.
-		isFromConfigContent := true
-		if content = GetContent(name); content == "" {
-			isFromConfigContent = false
-			filePath, err = c.GetFilePath(name)
-			if err != nil && errorPrint() {
-				glog.Error(err)
-			}
-			if filePath == "" {
-				return nil
-			}
-			if file := gres.Get(filePath); file != nil {
-				content = string(file.Content())
-			} else {
-				content = gfile.GetContents(filePath)
-			}
-		}
-		// Note that the underlying configuration json object operations are concurrent safe.
-		var (
-			j *gjson.Json
-		)
-		dataType := gfile.ExtName(name)
-		if gjson.IsValidDataType(dataType) && !isFromConfigContent {
-			j, err = gjson.LoadContentType(dataType, content, true)
-		} else {
-			j, err = gjson.LoadContent(content, true)
-		}
-		if err == nil {
-			j.SetViolenceCheck(c.violenceCheck)
-			// Add monitor for this configuration file,
-			// any changes of this file will refresh its cache in Config object.
-			if filePath != "" && !gres.Contains(filePath) {
-				_, err = gfsnotify.Add(filePath, func(event *gfsnotify.Event) {
-					c.jsonMap.Remove(name)
-				})
-				if err != nil && errorPrint() {
-					glog.Error(err)
-				}
-			}
+		// The failed sources of layered configuration are ignored for the first loading.
+		if j, _ := c.loadJsonByName(name); j != nil {
 			return j
 		}
-		if errorPrint() {
-			if filePath != "" {
-				glog.Criticalf(`[gcfg] load config file "%s" failed: %s`, filePath, err.Error())
-			} else {
-				glog.Criticalf(`[gcfg] load configuration failed: %s`, err.Error())
-			}
-		}
 		return nil
 	})
 	if r != nil {
@@ -417,3 +419,119 @@ func (c *Config) getJson(file ...string) *gjson.Json {
 	}
 	return nil
 }
+
+// loadJsonByName loads and returns the configuration `name`, in which the default configuration
+// is layered with its profile file and adapters if any. For layered configuration, it returns the
+// merged configuration of the sources loaded successfully along with the error of failed ones.
+func (c *Config) loadJsonByName(name string) (*gjson.Json, error) {
+	if name == c.defaultName {
+		profileName := c.getProfileFileName(name)
+		if profileName != "" || c.adapters.Len() > 0 {
+			return c.loadJsonLayered(name, profileName)
+		}
+	}
+	if j := c.loadJson(name, name); j != nil {
+		return j, nil
+	}
+	return nil, gerror.Newf(`[gcfg] load config "%s" failed`, name)
+}
+
+// loadJsonLayered loads and merges the configuration file `name`, its profile file `profileName`
+// and all the adapters. The files are optional. The source failing loading is ignored with error
+// printed, and the first error is returned along with the merged configuration of other sources.
+func (c *Config) loadJsonLayered(name, profileName string) (*gjson.Json, error) {
+	var (
+		firstErr error
+		data     = make(map[string]interface{})
+	)
+	for _, fileName := range []string{name, profileName} {
+		if fileName == "" || !c.Available(fileName) {
+			continue
+		}
+		if j := c.loadJson(fileName, name); j != nil {
+			mergeMap(data, j.Map())
+		} else if firstErr == nil {
+			firstErr = gerror.Newf(`[gcfg] load config "%s" failed`, fileName)
+		}
+	}
+	c.adapters.RLockFunc(func(array []interface{}) {
+		for _, v := range array {
+			adapter := v.(Adapter)
+			adapterData, err := adapter.Load()
+			if err != nil {
+				if errorPrint() {
+					glog.Errorf(`[gcfg] load configuration from adapter "%s" failed: %s`, adapter.Name(), err.Error())
+				}
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			mergeMap(data, adapterData)
+		}
+	})
+	j := gjson.New(data, true)
+	j.SetViolenceCheck(c.violenceCheck)
+	return j, firstErr
+}
+
+// loadJson loads and returns a *gjson.Json object for the configuration file `name`.
+// It adds monitor for the file, which reloads the cached configuration `cacheName` if the
+// file changes.
+func (c *Config) loadJson(name, cacheName string) *gjson.Json {
+	var (
+		err      error
+		content  string
+		filePath string
+	)
+	// The configured content can be any kind of data type different from its file type.
+	isFromConfigContent := true
+	if content = GetContent(name); content == "" {
+		isFromConfigContent = false
+		filePath, err = c.GetFilePath(name)
+		if err != nil && errorPrint() {
+			glog.Error(err)
+		}
+		if filePath == "" {
+			return nil
+		}
+		if file := gres.Get(filePath); file != nil {
+			content = string(file.Content())
+		} else {
+			content = gfile.GetContents(filePath)
+		}
+	}
+	// Note that the underlying configuration json object operations are concurrent safe.
+	var (
+		j *gjson.Json
+	)
+	dataType := gfile.ExtName(name)
+	if gjson.IsValidDataType(dataType) && !isFromConfigContent {
+		j, err = gjson.LoadContentType(dataType, content, true)
+	} else {
+		j, err = gjson.LoadContent(content, true)
+	}
+	if err == nil {
+		j.SetViolenceCheck(c.violenceCheck)
+		// Add monitor for this configuration file,
+		// any changes of this file will reload its cache in Config object.
+		// It is added only once for each file, as the reloading calls this function again.
+		if filePath != "" && !gres.Contains(filePath) {
+			_, err = gfsnotify.AddOnce(fmt.Sprintf(`gcfg.config:%p:%s`, c, filePath), filePath, func(event *gfsnotify.Event) {
+				c.scheduleReload(cacheName)
+			})
+			if err != nil && errorPrint() {
+				glog.Error(err)
+			}
+		}
+		return j
+	}
+	if errorPrint() {
+		if filePath != "" {
+			glog.Criticalf(`[gcfg] load config file "%s" failed: %s`, filePath, err.Error())
+		} else {
+			glog.Criticalf(`[gcfg] load configuration failed: %s`, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/util/gconv"
+	"github.com/gogf/gf/util/gutil"
+	"github.com/gogf/gf/util/gvalid"
+)
+
+// BindError is the error returned by Config.Bind, which contains all the validation
+// violations of the configuration by their configuration paths.
+type BindError struct {
+	violations map[string][]string // Violation messages by configuration path, like: server.address.
+}
+
+var (
+	// defaultValueTags are the struct tag names for default values of configuration.
+	defaultValueTags = []string{"d", "default"}
+
+	// sensitiveKeyWords are the key words of configuration keys whose values are masked in dumping.
+	sensitiveKeyWords = []string{"password", "passwd", "pwd", "secret", "token", "credential", "privatekey", "apikey"}
+
+	// leafStructTypes are the struct types that are bound as single values instead of nested configuration.
+	leafStructTypes = map[reflect.Type]struct{}{
+		reflect.TypeOf(time.Time{}):  {},
+		reflect.TypeOf(gtime.Time{}): {},
+	}
+)
+
+const (
+	// maskedValue is the value replacing encrypted and sensitive values in dumping.
+	maskedValue = "******"
+)
+
+// Error implements the error interface, which lists all the violations in order of paths.
+func (e *BindError) Error() string {
+	buffer := bytes.NewBufferString(`[gcfg] invalid configuration:`)
+	for _, path := range e.Paths() {
+		buffer.WriteString("\n  ")
+		buffer.WriteString(path)
+		buffer.WriteString(": ")
+		buffer.WriteString(strings.Join(e.violations[path], "; "))
+	}
+	return buffer.String()
+}
+
+// Paths returns the configuration paths having violations in ascending order.
+func (e *BindError) Paths() []string {
+	paths := make([]string, 0, len(e.violations))
+	for path := range e.violations {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Violations returns all the violation messages by their configuration paths.
+func (e *BindError) Violations() map[string][]string {
+	return e.violations
+}
+
+// add adds violation messages `messages` of configuration `path`.
+func (e *BindError) add(path string, messages ...string) {
+	e.violations[path] = append(e.violations[path], messages...)
+}
+
+// Bind binds the value of default configuration by `pattern` to struct `pointer`, which is
+// supposed to be called at startup so that misconfiguration fails fast.
+//
+// The missing or nil configuration values are filled with the default values in struct tag
+// "d" or "default" before binding, but the values explicitly configured like 0, false or ""
+// are kept, and then the struct is validated with the rules in struct
+// tag "v" using package gvalid, eg:
+//
+// type ServerConfig struct {
+//     Address string `d:":8000" v:"required"`
+//     Timeout int    `d:"30"    v:"min:1#timeout should be positive"`
+// }
+//
+// The nested structs are also filled and validated recursively. It returns *BindError containing
+// all the violations with their configuration paths like "server.timeout" if validation fails.
+func (c *Config) Bind(pattern string, pointer interface{}) error {
+	rv := reflect.ValueOf(pointer)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return gerror.Newf(`[gcfg] Bind failed: pointer should be type of *struct, but got %T`, pointer)
+	}
+	// It copies the configuration data as it is changed by default values.
+	data := make(map[string]interface{})
+	if j := c.getJson(); j != nil {
+		if m, ok := c.getValue(j, pattern).(map[string]interface{}); ok {
+			mergeMap(data, m)
+		}
+	}
+	applyDefaultValues(data, rv.Elem().Type())
+	if err := gconv.Struct(data, pointer); err != nil {
+		return gerror.Wrapf(err, `[gcfg] bind config "%s" to %s failed`, pattern, rv.Type().String())
+	}
+	bindErr := &BindError{violations: make(map[string][]string)}
+	validateStruct(context.TODO(), rv.Elem(), configPathPrefix(pattern), bindErr)
+	if len(bindErr.violations) > 0 {
+		return bindErr
+	}
+	return nil
+}
+
+// ToMapMasked returns the effective configuration of default file name as map, in which the
+// placeholders are resolved, but the encrypted values and the values of sensitive keys like
+// "password" and "token" are masked. It is used for printing the configuration safely.
+func (c *Config) ToMapMasked() map[string]interface{} {
+	j := c.getJson()
+	if j == nil {
+		return nil
+	}
+	m, ok := maskSensitiveValues(c.getMaskedValue(j, ".")).(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return m
+}
+
+// DumpMasked prints the effective configuration of default file name with secrets masked.
+// See ToMapMasked.
+func (c *Config) DumpMasked() {
+	if m := c.ToMapMasked(); m != nil {
+		gutil.Dump(m)
+	}
+}
+
+// applyDefaultValues fills the missing or nil items of `data` with the default values in
+// struct tags of `structType` recursively.
+func applyDefaultValues(data map[string]interface{}, structType reflect.Type) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous {
+			if fieldType.Kind() == reflect.Struct {
+				applyDefaultValues(data, fieldType)
+			}
+			continue
+		}
+		var (
+			name       = configFieldName(field)
+			key, value = gutil.MapPossibleItemByKey(data, name)
+		)
+		if def, ok := lookupDefaultValue(field); ok {
+			if key == "" {
+				data[name] = def
+			} else if value == nil {
+				data[key] = def
+			}
+			continue
+		}
+		if !isNestedStruct(fieldType) {
+			continue
+		}
+		if key == "" {
+			sub := make(map[string]interface{})
+			if applyDefaultValues(sub, fieldType); len(sub) > 0 {
+				data[name] = sub
+			}
+		} else if sub, ok := value.(map[string]interface{}); ok {
+			applyDefaultValues(sub, fieldType)
+		}
+	}
+}
+
+// validateStruct validates struct `value` and its nested structs, and adds the violations with
+// configuration path prefix `prefix` to `bindErr`.
+func validateStruct(ctx context.Context, value reflect.Value, prefix string, bindErr *BindError) {
+	if err := gvalid.CheckStruct(ctx, value.Addr().Interface(), nil); err != nil {
+		names := make(map[string]string)
+		collectFieldNames(value.Type(), names)
+		for key, rules := range err.Maps() {
+			path := key
+			if name, ok := names[key]; ok {
+				path = name
+			}
+			messages := make([]string, 0, len(rules))
+			for _, message := range rules {
+				messages = append(messages, message)
+			}
+			sort.Strings(messages)
+			bindErr.add(prefix+path, messages...)
+		}
+	}
+	validateNestedStructs(ctx, value, prefix, bindErr)
+}
+
+// validateNestedStructs validates the nested struct fields of struct `value`.
+func validateNestedStructs(ctx context.Context, value reflect.Value, prefix string, bindErr *BindError) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if !isNestedStruct(fieldValue.Type()) {
+			continue
+		}
+		if field.Anonymous {
+			// The fields of embedded struct are validated along with its parent by gvalid.
+			validateNestedStructs(ctx, fieldValue, prefix, bindErr)
+			continue
+		}
+		validateStruct(ctx, fieldValue, prefix+configFieldName(field)+".", bindErr)
+	}
+}
+
+// collectFieldNames collects the configuration names of the fields of `structType`, by its field
+// name and its validation alias name, which are the keys of gvalid error.
+func collectFieldNames(structType reflect.Type, names map[string]string) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Anonymous {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				collectFieldNames(fieldType, names)
+			}
+			continue
+		}
+		name := configFieldName(field)
+		names[field.Name] = name
+		for _, tag := range []string{"param", "params", "p"} {
+			if alias := field.Tag.Get(tag); alias != "" {
+				names[alias] = name
+			}
+		}
+	}
+}
+
+// configFieldName returns the configuration key name of struct field `field`, which is the
+// name in conversion tags like "json", or else the field name.
+func configFieldName(field reflect.StructField) string {
+	for _, tag := range gconv.StructTagPriority {
+		if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// lookupDefaultValue returns the default value in struct tag of `field`.
+func lookupDefaultValue(field reflect.StructField) (string, bool) {
+	for _, tag := range defaultValueTags {
+		if value, ok := field.Tag.Lookup(tag); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// isNestedStruct checks whether `t` is a struct type bound from nested configuration.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := leafStructTypes[t]
+	return !ok
+}
+
+// configPathPrefix returns the prefix of configuration paths for `pattern`.
+func configPathPrefix(pattern string) string {
+	if pattern == "" || pattern == "." {
+		return ""
+	}
+	return pattern + "."
+}
+
+// maskSensitiveValues returns the copy of `value`, in which the values of sensitive keys are
+// replaced with masked value recursively.
+func maskSensitiveValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if _, ok := item.(map[string]interface{}); !ok && item != nil && isSensitiveKey(key) {
+				m[key] = maskedValue
+			} else {
+				m[key] = maskSensitiveValues(item)
+			}
+		}
+		return m
+
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, item := range v {
+			array[i] = maskSensitiveValues(item)
+		}
+		return array
+	}
+	return value
+}
+
+// isSensitiveKey checks whether configuration key `key` refers to a secret, like "password".
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, word := range sensitiveKeyWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
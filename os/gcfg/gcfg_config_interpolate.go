@@ -0,0 +1,161 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/gogf/gf/encoding/gjson"
+	"github.com/gogf/gf/os/glog"
+	"github.com/gogf/gf/util/gconv"
+)
+
+const (
+	// maxInterpolationDepth is the max depth of placeholders referring to other
+	// configuration values, which prevents infinite resolving of circular references.
+	maxInterpolationDepth = 10
+)
+
+var (
+	// placeholderRegex matches placeholders like: ${NAME}, ${NAME:default}, ${database.host}.
+	placeholderRegex = regexp.MustCompile(`\$\{([^{}]+)\}`)
+)
+
+// getValue retrieves the value of `j` by `pattern`, in which the placeholders are resolved
+// and the encrypted values are decrypted.
+//
+// The placeholders are resolved lazily on each access, which supports formats:
+// ${NAME}           : Environment variable NAME, or configuration value of key NAME.
+// ${NAME:default}   : Same as ${NAME}, but uses "default" if neither of them exists.
+// ${database.host}  : Configuration value of key "database.host", which can be another placeholder.
+//
+// The encrypted value like ENC(base64...) is decrypted, see EncryptValue.
+//
+// The environment variable takes precedence over the configuration value of the same name.
+// If the whole string value is a placeholder, the referred value is returned in its original type,
+// or else the referred value is converted to string and replaced into the string value. The
+// placeholders that cannot be resolved are kept as they are.
+func (c *Config) getValue(j *gjson.Json, pattern string, def ...interface{}) interface{} {
+	return c.resolveValue(j, j.Get(pattern, def...), 0, false)
+}
+
+// getMaskedValue is the same as getValue, but the encrypted values are masked instead of decrypted.
+func (c *Config) getMaskedValue(j *gjson.Json, pattern string) interface{} {
+	return c.resolveValue(j, j.Get(pattern), 0, true)
+}
+
+// resolveValue returns the copy of `value` with all placeholders resolved and encrypted values
+// decrypted, or masked if `mask` is true. It returns `value` itself if it contains neither
+// placeholder nor encrypted value.
+func (c *Config) resolveValue(j *gjson.Json, value interface{}, depth int, mask bool) interface{} {
+	if !containsPlaceholder(value) {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		return c.resolveString(j, v, depth, mask)
+
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = c.resolveValue(j, item, depth, mask)
+		}
+		return m
+
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, item := range v {
+			array[i] = c.resolveValue(j, item, depth, mask)
+		}
+		return array
+	}
+	return value
+}
+
+// resolveString resolves the placeholders in string `s`, or decrypts `s` if it is encrypted.
+func (c *Config) resolveString(j *gjson.Json, s string, depth int, mask bool) interface{} {
+	if IsEncryptedValue(s) {
+		if mask {
+			return maskedValue
+		}
+		return c.decryptString(s)
+	}
+	if depth >= maxInterpolationDepth {
+		return s
+	}
+	// The whole string is a placeholder.
+	if match := placeholderRegex.FindStringSubmatchIndex(s); match != nil && match[0] == 0 && match[1] == len(s) {
+		return c.resolvePlaceholder(j, s, s[match[2]:match[3]], depth, mask)
+	}
+	return placeholderRegex.ReplaceAllStringFunc(s, func(placeholder string) string {
+		return gconv.String(c.resolvePlaceholder(j, placeholder, placeholder[2:len(placeholder)-1], depth, mask))
+	})
+}
+
+// resolvePlaceholder returns the value of `placeholder` with its content `expr`.
+func (c *Config) resolvePlaceholder(j *gjson.Json, placeholder, expr string, depth int, mask bool) interface{} {
+	var (
+		name       = expr
+		def        = ""
+		defaultSet = false
+	)
+	if pos := strings.IndexByte(expr, ':'); pos != -1 {
+		name, def, defaultSet = expr[:pos], expr[pos+1:], true
+	}
+	name = strings.TrimSpace(name)
+	if v, ok := os.LookupEnv(name); ok {
+		return v
+	}
+	if v := j.Get(name); v != nil {
+		return c.resolveValue(j, v, depth+1, mask)
+	}
+	if defaultSet {
+		return def
+	}
+	return placeholder
+}
+
+// decryptString decrypts the encrypted value `s` with the key of the configuration.
+// It prints the error and returns `s` itself if the decryption fails.
+func (c *Config) decryptString(s string) string {
+	key, err := c.getSecretKey()
+	if err == nil {
+		var plainText string
+		if plainText, err = DecryptValue(s, key); err == nil {
+			return plainText
+		}
+	}
+	if errorPrint() {
+		glog.Error(err)
+	}
+	return s
+}
+
+// containsPlaceholder checks whether `value` or its items contain any placeholder or encrypted value.
+func containsPlaceholder(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.Contains(v, "${") || strings.Contains(v, "ENC(")
+
+	case map[string]interface{}:
+		for _, item := range v {
+			if containsPlaceholder(item) {
+				return true
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			if containsPlaceholder(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
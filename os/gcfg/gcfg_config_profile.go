@@ -0,0 +1,60 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"fmt"
+
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/text/gstr"
+)
+
+// SetProfile sets the profile of the configuration, eg: dev, test, prod.
+//
+// The profile file "<name>.<profile>.<ext>" of the default configuration file is layered on
+// top of the default file, eg: config.prod.toml overrides config.toml. The profile file can be
+// of any supported file type, but the same type as the default file is searched first.
+//
+// The profile can also be specified by command option "gf.profile" or environment GF_PROFILE.
+func (c *Config) SetProfile(profile string) *Config {
+	c.profile = profile
+	c.jsonMap.Clear()
+	return c
+}
+
+// GetProfile returns the profile of the configuration.
+func (c *Config) GetProfile() string {
+	return c.profile
+}
+
+// getProfileFileName returns the available profile file name of configuration file `name`.
+// It returns an empty string if no profile specified or the profile file does not exist.
+func (c *Config) getProfileFileName(name string) string {
+	if c.profile == "" {
+		return ""
+	}
+	var (
+		extName  = gfile.ExtName(name)
+		baseName = name
+		names    = make([]string, 0, len(supportedFileTypes)+1)
+	)
+	if extName != "" {
+		baseName = gstr.TrimRightStr(name, "."+extName)
+		names = append(names, fmt.Sprintf(`%s.%s.%s`, baseName, c.profile, extName))
+	}
+	for _, fileType := range supportedFileTypes {
+		if fileType != extName {
+			names = append(names, fmt.Sprintf(`%s.%s.%s`, baseName, c.profile, fileType))
+		}
+	}
+	for _, profileName := range names {
+		if c.Available(profileName) {
+			return profileName
+		}
+	}
+	return ""
+}
@@ -0,0 +1,214 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/gogf/gf/container/gtype"
+	"github.com/gogf/gf/container/gvar"
+	"github.com/gogf/gf/encoding/gjson"
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/os/glog"
+	"github.com/gogf/gf/os/gtimer"
+	"github.com/gogf/gf/util/gconv"
+)
+
+// configSubscriber is the subscriber of configuration changes by pattern.
+type configSubscriber struct {
+	pattern  string                   // Pattern of the subscribed value.
+	callback func(old, new *gvar.Var) // Callback function, which is called after the value changes.
+	watcher  *StructWatcher           // Struct watcher, which is nil for OnChange subscribers.
+}
+
+// StructWatcher holds the struct bound from the configuration value, which is re-bound to a
+// new struct after the value changes.
+type StructWatcher struct {
+	value     *gtype.Interface // Pointer to the struct currently bound.
+	structTyp reflect.Type     // Type of the struct.
+}
+
+const (
+	defaultReloadDelay = 500 * time.Millisecond
+)
+
+// OnChange subscribes the changes of the value of default configuration by `pattern`,
+// and `callback` is called with the old and new value after the value changes on reloading.
+// It subscribes all the configuration if `pattern` is empty or ".".
+//
+// Note that the reloading happens when the configuration file or any adapter changes.
+// See Reload.
+func (c *Config) OnChange(pattern string, callback func(old, new *gvar.Var)) {
+	c.subscribers.Append(&configSubscriber{
+		pattern:  pattern,
+		callback: callback,
+	})
+}
+
+// Watch binds the value of default configuration by `pattern` to a struct, and re-binds it to
+// a new struct after the value changes on reloading. The new configuration is rejected if the
+// value cannot be bound to the struct.
+//
+// The parameter `pointer` specifies the type of the struct, which should be type of *struct,
+// eg: (*Server)(nil). The bound struct is retrieved by Load of the returned watcher, eg:
+// w.Load().(*Server), which is replaced atomically on changes, so concurrent readers always
+// see a complete struct.
+func (c *Config) Watch(pattern string, pointer interface{}) (*StructWatcher, error) {
+	structTyp := reflect.TypeOf(pointer)
+	if structTyp == nil || structTyp.Kind() != reflect.Ptr || structTyp.Elem().Kind() != reflect.Struct {
+		return nil, gerror.Newf(`[gcfg] Watch failed: pointer should be type of *struct, but got %T`, pointer)
+	}
+	watcher := &StructWatcher{
+		value:     gtype.NewInterface(reflect.New(structTyp.Elem()).Interface()),
+		structTyp: structTyp.Elem(),
+	}
+	if j := c.getJson(); j != nil {
+		value, err := watcher.bind(c.getValue(j, pattern), pattern)
+		if err != nil {
+			return nil, err
+		}
+		watcher.store(value)
+	}
+	c.subscribers.Append(&configSubscriber{
+		pattern: pattern,
+		watcher: watcher,
+	})
+	return watcher, nil
+}
+
+// Load returns the pointer to the struct currently bound, which is the same type as the
+// parameter `pointer` of Watch. It is concurrent-safe. Note that the returned struct is shared
+// by all readers, which should not be modified.
+func (w *StructWatcher) Load() interface{} {
+	return w.value.Val()
+}
+
+// AddValidator adds `validator` checking the new content of default configuration on
+// reloading. The new configuration is rejected if any validator returns error, and the
+// old one is kept in use.
+func (c *Config) AddValidator(validator func(j *gjson.Json) error) {
+	c.validators.Append(validator)
+}
+
+// SetReloadDelay sets the delay for reloading after changes, in which burst changes like
+// multiple file events of one saving are merged into one reloading. It's 500ms in default.
+func (c *Config) SetReloadDelay(delay time.Duration) {
+	c.reloadDelay.Set(int64(delay))
+}
+
+// Reload reloads the cached configuration of default file name immediately, validates it, and
+// notifies the subscribers whose values changed. It returns error and keeps the old configuration
+// in use if the new configuration cannot be loaded or validated.
+//
+// It does nothing if the configuration has not been loaded yet, as it is loaded on first access.
+func (c *Config) Reload() error {
+	return c.reload(c.defaultName)
+}
+
+// scheduleReload schedules reloading of configuration `name` after the reload delay,
+// which cancels the reloading scheduled before but not executed yet.
+func (c *Config) scheduleReload(name string) {
+	delay := time.Duration(c.reloadDelay.Val())
+	c.reloadEntries.LockFunc(func(m map[string]interface{}) {
+		if entry, ok := m[name]; ok {
+			entry.(*gtimer.Entry).Close()
+		}
+		m[name] = gtimer.AddOnce(delay, func() {
+			c.reloadEntries.Remove(name)
+			if err := c.reload(name); err != nil && errorPrint() {
+				glog.Error(err)
+			}
+		})
+	})
+}
+
+// reload reloads the cached configuration `name`. See Reload.
+func (c *Config) reload(name string) error {
+	c.reloadMu.Lock()
+	defer c.reloadMu.Unlock()
+
+	v := c.jsonMap.Get(name)
+	if v == nil {
+		return nil
+	}
+	var (
+		err     error
+		oldJson = v.(*gjson.Json)
+		newJson *gjson.Json
+	)
+	isDefault := name == c.defaultName
+	if newJson, err = c.loadJsonByName(name); err != nil {
+		return gerror.Wrapf(err, `[gcfg] reload config "%s" rejected`, name)
+	}
+	if !isDefault {
+		c.jsonMap.Set(name, newJson)
+		intlog.Printf(`config reloaded: %s`, name)
+		return nil
+	}
+	// Validation of the new configuration.
+	var validators []interface{}
+	c.validators.RLockFunc(func(array []interface{}) {
+		validators = append(validators, array...)
+	})
+	for _, validator := range validators {
+		if err = validator.(func(j *gjson.Json) error)(newJson); err != nil {
+			return gerror.Wrapf(err, `[gcfg] reload config "%s" rejected`, name)
+		}
+	}
+	// Binding for struct watchers, which also validates the new configuration.
+	var (
+		subscribers []*configSubscriber
+		changed     []*configSubscriber
+		bound       = make(map[*configSubscriber]reflect.Value)
+	)
+	c.subscribers.RLockFunc(func(array []interface{}) {
+		for _, v := range array {
+			subscribers = append(subscribers, v.(*configSubscriber))
+		}
+	})
+	for _, s := range subscribers {
+		if reflect.DeepEqual(c.getValue(oldJson, s.pattern), c.getValue(newJson, s.pattern)) {
+			continue
+		}
+		changed = append(changed, s)
+		if s.watcher != nil {
+			value, err := s.watcher.bind(c.getValue(newJson, s.pattern), s.pattern)
+			if err != nil {
+				return gerror.Wrapf(err, `[gcfg] reload config "%s" rejected`, name)
+			}
+			bound[s] = value
+		}
+	}
+	c.jsonMap.Set(name, newJson)
+	intlog.Printf(`config reloaded: %s`, name)
+
+	// Notifying the subscribers whose value changed.
+	for _, s := range changed {
+		if s.watcher != nil {
+			s.watcher.store(bound[s])
+		} else {
+			s.callback(gvar.New(c.getValue(oldJson, s.pattern)), gvar.New(c.getValue(newJson, s.pattern)))
+		}
+	}
+	return nil
+}
+
+// bind converts the configuration `data` of `pattern` to a new struct, and returns the pointer to it.
+func (w *StructWatcher) bind(data interface{}, pattern string) (reflect.Value, error) {
+	value := reflect.New(w.structTyp)
+	if err := gconv.Struct(data, value.Interface()); err != nil {
+		return value, gerror.Wrapf(err, `[gcfg] bind config "%s" to %s failed`, pattern, w.structTyp.String())
+	}
+	return value, nil
+}
+
+// store stores the struct pointed by `value` as the currently bound struct.
+func (w *StructWatcher) store(value reflect.Value) {
+	w.value.Set(value.Interface())
+}
@@ -0,0 +1,210 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gogf/gf/crypto/gaes"
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/os/gcmd"
+	"github.com/gogf/gf/os/gfile"
+)
+
+// KeyProvider provides the AES key for encrypted values in configuration, like: ENC(base64...).
+type KeyProvider interface {
+	// Key returns the AES key, which must be 16/24/32 bytes.
+	Key() ([]byte, error)
+}
+
+// KeyProviderFunc is the function implementing KeyProvider.
+type KeyProviderFunc func() ([]byte, error)
+
+// keyProviderEnv provides the key from environment variable or command option.
+type keyProviderEnv struct {
+	name string // Name of command option or environment variable, like: gf.gcfg.secretkey.
+}
+
+// keyProviderFile provides the key from key file.
+type keyProviderFile struct {
+	path string // Path of the key file.
+}
+
+const (
+	secretKeyCmdEnvKey     = "gf.gcfg.secretkey"     // Key for command option or environment of secret key.
+	secretKeyFileCmdEnvKey = "gf.gcfg.secretkeyfile" // Key for command option or environment of secret key file path.
+	secretKeyBase64Prefix  = "base64:"               // Prefix of the secret key in base64 encoding.
+)
+
+var (
+	// encryptedValueRegex matches the encrypted value in configuration content.
+	encryptedValueRegex = regexp.MustCompile(`ENC\(([A-Za-z0-9+/=]+)\)`)
+)
+
+// Key implements KeyProvider.
+func (f KeyProviderFunc) Key() ([]byte, error) {
+	return f()
+}
+
+// NewKeyProviderEnv creates and returns a KeyProvider which reads the key from command option
+// or environment variable `name`, eg: "app.secret.key" for --app.secret.key or APP_SECRET_KEY.
+// See gcmd.GetOptWithEnv.
+//
+// The key is either the raw key string, or the base64 encoding of key bytes with prefix
+// "base64:", eg: base64:MDEyMzQ1Njc4OWFiY2RlZg==.
+func NewKeyProviderEnv(name string) KeyProvider {
+	return &keyProviderEnv{name: name}
+}
+
+// NewKeyProviderFile creates and returns a KeyProvider which reads the key from file `path`.
+//
+// The key is either the raw key string, or the base64 encoding of key bytes with prefix
+// "base64:", and the leading and trailing white spaces of the file content are ignored.
+func NewKeyProviderFile(path string) KeyProvider {
+	return &keyProviderFile{path: path}
+}
+
+// Key implements KeyProvider.
+func (p *keyProviderEnv) Key() ([]byte, error) {
+	value := gcmd.GetOptWithEnv(p.name).String()
+	if value == "" {
+		return nil, gerror.Newf(`[gcfg] secret key "%s" not found in command options or environment`, p.name)
+	}
+	return parseSecretKey(value)
+}
+
+// Key implements KeyProvider.
+func (p *keyProviderFile) Key() ([]byte, error) {
+	if !gfile.Exists(p.path) {
+		return nil, gerror.Newf(`[gcfg] secret key file "%s" does not exist`, p.path)
+	}
+	return parseSecretKey(gfile.GetContents(p.path))
+}
+
+// defaultKeyProvider is the KeyProvider used if no provider set for Config, which reads key
+// from option/environment "gf.gcfg.secretkey", or key file specified by "gf.gcfg.secretkeyfile".
+func defaultKeyProvider() ([]byte, error) {
+	if path := gcmd.GetOptWithEnv(secretKeyFileCmdEnvKey).String(); path != "" {
+		return NewKeyProviderFile(path).Key()
+	}
+	return NewKeyProviderEnv(secretKeyCmdEnvKey).Key()
+}
+
+// parseSecretKey parses the key string `s`, which is the raw key, or the base64 encoding
+// of key bytes with prefix "base64:".
+func parseSecretKey(s string) ([]byte, error) {
+	var (
+		err error
+		key = []byte(strings.TrimSpace(s))
+	)
+	if bytes.HasPrefix(key, []byte(secretKeyBase64Prefix)) {
+		if key, err = base64.StdEncoding.DecodeString(string(key[len(secretKeyBase64Prefix):])); err != nil {
+			return nil, gerror.Wrap(err, `[gcfg] invalid base64 secret key`)
+		}
+	}
+	if !isValidSecretKey(key) {
+		return nil, gerror.New(`[gcfg] invalid secret key, which should be 16/24/32 bytes`)
+	}
+	return key, nil
+}
+
+// isValidSecretKey checks whether `key` is a valid AES key.
+func isValidSecretKey(key []byte) bool {
+	switch len(key) {
+	case 16, 24, 32:
+		return true
+	}
+	return false
+}
+
+// SetKeyProvider sets the KeyProvider for decrypting the encrypted values in configuration.
+func (c *Config) SetKeyProvider(provider KeyProvider) {
+	c.keyProvider = provider
+}
+
+// getSecretKey returns the key for encrypted values from the KeyProvider of the configuration.
+func (c *Config) getSecretKey() ([]byte, error) {
+	if c.keyProvider != nil {
+		return c.keyProvider.Key()
+	}
+	return defaultKeyProvider()
+}
+
+// IsEncryptedValue checks whether `value` is an encrypted value like: ENC(base64...).
+func IsEncryptedValue(value string) bool {
+	value = strings.TrimSpace(value)
+	match := encryptedValueRegex.FindStringIndex(value)
+	return match != nil && match[0] == 0 && match[1] == len(value)
+}
+
+// EncryptValue encrypts `value` with `key` using AES-GCM, and returns the encrypted value
+// like ENC(base64...), which can be put in configuration file as a string value.
+func EncryptValue(value string, key []byte) (string, error) {
+	cipherText, err := gaes.EncryptGCM([]byte(value), key)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`ENC(%s)`, base64.StdEncoding.EncodeToString(cipherText)), nil
+}
+
+// DecryptValue decrypts the encrypted value `value` like ENC(base64...) with `key`.
+func DecryptValue(value string, key []byte) (string, error) {
+	if !IsEncryptedValue(value) {
+		return "", gerror.New(`[gcfg] invalid encrypted value, which should be like: ENC(base64...)`)
+	}
+	value = strings.TrimSpace(value)
+	cipherText, err := base64.StdEncoding.DecodeString(value[4 : len(value)-1])
+	if err != nil {
+		return "", err
+	}
+	plainText, err := gaes.DecryptGCM(cipherText, key)
+	if err != nil {
+		return "", gerror.Wrap(err, `[gcfg] decrypt value failed`)
+	}
+	return string(plainText), nil
+}
+
+// RekeyContent re-encrypts all the encrypted values in configuration `content` from `oldKey`
+// to `newKey`, and returns the new content. The other parts of the content are kept unchanged,
+// so it works for any configuration file type.
+func RekeyContent(content string, oldKey, newKey []byte) (string, error) {
+	var err error
+	result := encryptedValueRegex.ReplaceAllStringFunc(content, func(value string) string {
+		if err != nil {
+			return value
+		}
+		var plainText, newValue string
+		if plainText, err = DecryptValue(value, oldKey); err != nil {
+			return value
+		}
+		if newValue, err = EncryptValue(plainText, newKey); err != nil {
+			return value
+		}
+		return newValue
+	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// RekeyFile re-encrypts all the encrypted values in configuration file `path` from `oldKey`
+// to `newKey`. The file is not changed if any value fails re-encrypting. See RekeyContent.
+func RekeyFile(path string, oldKey, newKey []byte) error {
+	if !gfile.Exists(path) {
+		return gerror.Newf(`[gcfg] configuration file "%s" does not exist`, path)
+	}
+	content, err := RekeyContent(gfile.GetContents(path), oldKey, newKey)
+	if err != nil {
+		return gerror.Wrapf(err, `[gcfg] rekey file "%s" failed`, path)
+	}
+	return gfile.PutContents(path, content)
+}
@@ -0,0 +1,179 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/os/gcfg"
+	"github.com/gogf/gf/os/gcmd"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_Adapter_Layered(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dirPath      = gfile.TempDir(gtime.TimestampNanoStr())
+			confDirPath  = gfile.Join(dirPath, "conf.d")
+			overridePath = gfile.Join(dirPath, "override.yaml")
+		)
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.toml"), `
+name = "base"
+[server]
+	address        = ":80"
+	maxHeaderBytes = 1024
+	logPath        = "/var/log"
+`), nil)
+		t.Assert(gfile.PutContents(gfile.Join(confDirPath, "00-server.toml"), `
+[server]
+	address = ":81"
+	timeout = 10
+`), nil)
+		t.Assert(gfile.PutContents(gfile.Join(confDirPath, "10-server.json"), `{"server": {"timeout": 20}}`), nil)
+		t.Assert(gfile.PutContents(overridePath, "server:\n  address: \":82\"\n"), nil)
+
+		dirAdapter, err := gcfg.NewAdapterDir(confDirPath)
+		t.Assert(err, nil)
+		fileAdapter, err := gcfg.NewAdapterFile(overridePath)
+		t.Assert(err, nil)
+
+		os.Setenv("GCFGTEST_SERVER_MAXHEADERBYTES", "2048")
+		os.Setenv("GCFGTEST_DATABASE_HOST", "127.0.0.1")
+		defer os.Unsetenv("GCFGTEST_SERVER_MAXHEADERBYTES")
+		defer os.Unsetenv("GCFGTEST_DATABASE_HOST")
+
+		c := gcfg.New()
+		t.Assert(c.SetPath(dirPath), nil)
+		t.Assert(c.AddAdapter(dirAdapter, fileAdapter, gcfg.NewAdapterEnv("GCFGTEST")), nil)
+		t.Assert(len(c.GetAdapters()), 3)
+
+		t.Assert(c.GetString("name"), "base")
+		t.Assert(c.GetString("server.address"), ":82")
+		t.Assert(c.GetInt("server.timeout"), 20)
+		t.Assert(c.GetInt("server.maxHeaderBytes"), 2048)
+		t.Assert(c.GetString("server.logPath"), "/var/log")
+		t.Assert(c.GetString("database.host"), "127.0.0.1")
+
+		// Command line options take precedence over environment variables.
+		parser, err := gcmd.ParseWithArgs(
+			[]string{"app", "--config.server.address=:83"},
+			map[string]bool{"config.server.address": true},
+		)
+		t.Assert(err, nil)
+		t.Assert(c.AddAdapter(gcfg.NewAdapterCmdWithParser(parser, "config")), nil)
+		t.Assert(c.GetString("server.address"), ":83")
+		t.Assert(c.GetInt("server.maxHeaderBytes"), 2048)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gcfg.NewAdapterFile(gtime.TimestampNanoStr())
+		t.AssertNE(err, nil)
+		_, err = gcfg.NewAdapterDir(gtime.TimestampNanoStr())
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Adapter_Http(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			mu      sync.Mutex
+			content = `{"server": {"address": ":80"}}`
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Token") != "token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			w.Write([]byte(content))
+		}))
+		defer server.Close()
+
+		adapter, err := gcfg.NewAdapterHttp(gcfg.AdapterHttpOptions{
+			Url:      server.URL,
+			Headers:  map[string]string{"X-Token": "token"},
+			Interval: 100 * time.Millisecond,
+		})
+		t.Assert(err, nil)
+		defer adapter.Close()
+
+		c := gcfg.New()
+		c.SetReloadDelay(100 * time.Millisecond)
+		t.Assert(c.AddAdapter(adapter), nil)
+		t.Assert(c.GetString("server.address"), ":80")
+
+		mu.Lock()
+		content = `{"server": {"address": ":81"}}`
+		mu.Unlock()
+		time.Sleep(time.Second)
+		t.Assert(c.GetString("server.address"), ":81")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		adapter, err := gcfg.NewAdapterHttp(gcfg.AdapterHttpOptions{Url: server.URL})
+		t.Assert(err, nil)
+		defer adapter.Close()
+		_, err = adapter.Load()
+		t.AssertNE(err, nil)
+
+		_, err = gcfg.NewAdapterHttp(gcfg.AdapterHttpOptions{})
+		t.AssertNE(err, nil)
+	})
+	// It starts polling after added, and notifies the first successful fetching.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			mu        sync.Mutex
+			available = false
+			requests  = 0
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests++
+			if !available {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"server": {"address": ":80"}}`))
+		}))
+		defer server.Close()
+
+		adapter, err := gcfg.NewAdapterHttp(gcfg.AdapterHttpOptions{
+			Url:      server.URL,
+			Interval: 100 * time.Millisecond,
+		})
+		t.Assert(err, nil)
+		defer adapter.Close()
+		time.Sleep(300 * time.Millisecond)
+		mu.Lock()
+		t.Assert(requests, 0)
+		mu.Unlock()
+
+		c := gcfg.New()
+		c.SetReloadDelay(100 * time.Millisecond)
+		t.Assert(c.AddAdapter(adapter), nil)
+		t.Assert(c.GetString("server.address"), "")
+
+		mu.Lock()
+		available = true
+		mu.Unlock()
+		time.Sleep(time.Second)
+		t.Assert(c.GetString("server.address"), ":80")
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gogf/gf/os/gcfg"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/text/gstr"
+)
+
+func Test_Bind_Defaults(t *testing.T) {
+	type Log struct {
+		Path  string `d:"/var/log"`
+		Level string `d:"all"`
+	}
+	type Server struct {
+		Address string `d:":8000" v:"required"`
+		Timeout int    `d:"30"`
+		Debug   bool
+		Log     Log
+	}
+	gtest.C(t, func(t *gtest.T) {
+		dirPath := gfile.TempDir(gtime.TimestampNanoStr())
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.toml"), `
+[server]
+	address = ":80"
+	timeout = 0
+	[server.log]
+	level = "error"
+`), nil)
+		c := gcfg.New()
+		t.Assert(c.SetPath(dirPath), nil)
+
+		var server Server
+		t.Assert(c.Bind("server", &server), nil)
+		t.Assert(server.Address, ":80")
+		// The explicit zero value is not overridden by default value.
+		t.Assert(server.Timeout, 0)
+		t.Assert(server.Log.Path, "/var/log")
+		t.Assert(server.Log.Level, "error")
+		// The cached configuration is not changed by default values.
+		t.Assert(c.Contains("server.log.path"), false)
+		t.Assert(c.GetInt("server.timeout"), 0)
+
+		var other Server
+		t.Assert(c.Bind("other", &other), nil)
+		t.Assert(other.Address, ":8000")
+		t.Assert(other.Timeout, 30)
+		t.Assert(other.Log.Level, "all")
+
+		t.AssertNE(c.Bind("server", server), nil)
+		t.AssertNE(c.Bind("server", nil), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		type App struct {
+			Debug   bool `d:"true"`
+			Workers int  `d:"8"`
+		}
+		dirPath := gfile.TempDir(gtime.TimestampNanoStr())
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(
+			gfile.Join(dirPath, "config.json"),
+			`{"app": {"debug": false, "workers": 0}, "nil": {"debug": null}}`,
+		), nil)
+		c := gcfg.New("config.json")
+		t.Assert(c.SetPath(dirPath), nil)
+
+		var app App
+		t.Assert(c.Bind("app", &app), nil)
+		t.Assert(app, App{Debug: false, Workers: 0})
+		t.Assert(c.Bind("nil", &app), nil)
+		t.Assert(app, App{Debug: true, Workers: 8})
+	})
+}
+
+func Test_Bind_Validation(t *testing.T) {
+	type Database struct {
+		Host string `v:"required#database host is required"`
+		Port int    `json:"port" v:"between:1,65535"`
+	}
+	type Config struct {
+		Name     string   `v:"required"`
+		Database Database `json:"db"`
+		Cache    *Database
+	}
+	gtest.C(t, func(t *gtest.T) {
+		dirPath := gfile.TempDir(gtime.TimestampNanoStr())
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.toml"), `
+[app]
+	[app.db]
+	port = 70000
+	[app.cache]
+	host = "127.0.0.1"
+	port = 6379
+`), nil)
+		c := gcfg.New()
+		t.Assert(c.SetPath(dirPath), nil)
+
+		var config Config
+		err := c.Bind("app", &config)
+		t.AssertNE(err, nil)
+		bindErr, ok := err.(*gcfg.BindError)
+		t.Assert(ok, true)
+		t.Assert(bindErr.Paths(), []string{"app.Name", "app.db.Host", "app.db.port"})
+		t.Assert(bindErr.Violations()["app.db.Host"], []string{"database host is required"})
+		t.Assert(gstr.Contains(err.Error(), "app.db.port: "), true)
+		t.Assert(config.Cache.Port, 6379)
+	})
+}
+
+func Test_ToMapMasked(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key     = []byte("0123456789abcdef")
+			dirPath = gfile.TempDir(gtime.TimestampNanoStr())
+		)
+		defer gfile.Remove(dirPath)
+		value, err := gcfg.EncryptValue("123456", key)
+		t.Assert(err, nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.toml"), fmt.Sprintf(`
+[database]
+	user = "root"
+	pass = "%s"
+	link = "mysql:${database.user}:${database.pass}@tcp(127.0.0.1:3306)/test"
+[redis]
+	password = "123456"
+	api_token = ""
+	db = 1
+`, value)), nil)
+		c := gcfg.New()
+		t.Assert(c.SetPath(dirPath), nil)
+		c.SetKeyProvider(gcfg.KeyProviderFunc(func() ([]byte, error) {
+			return key, nil
+		}))
+		m := c.ToMapMasked()
+		t.Assert(m["database"], map[string]interface{}{
+			"user": "root",
+			"pass": "******",
+			"link": "mysql:root:******@tcp(127.0.0.1:3306)/test",
+		})
+		t.Assert(m["redis"], map[string]interface{}{
+			"password":  "******",
+			"api_token": "******",
+			"db":        1,
+		})
+		// The effective configuration is not changed by masking.
+		t.Assert(c.GetString("database.pass"), "123456")
+		t.Assert(c.GetString("redis.password"), "123456")
+	})
+}
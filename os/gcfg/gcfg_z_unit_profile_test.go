@@ -0,0 +1,101 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gogf/gf/os/gcfg"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_Profile(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		dirPath := gfile.TempDir(gtime.TimestampNanoStr())
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.toml"), `
+name = "app"
+[server]
+	address = ":80"
+	timeout = 10
+`), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.prod.toml"), `
+[server]
+	address = ":8080"
+`), nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.test.yaml"), "server:\n  address: \":8081\"\n"), nil)
+
+		c := gcfg.New()
+		t.Assert(c.SetPath(dirPath), nil)
+		t.Assert(c.GetString("server.address"), ":80")
+
+		c.SetProfile("prod")
+		t.Assert(c.GetProfile(), "prod")
+		t.Assert(c.GetString("name"), "app")
+		t.Assert(c.GetString("server.address"), ":8080")
+		t.Assert(c.GetInt("server.timeout"), 10)
+
+		c.SetProfile("test")
+		t.Assert(c.GetString("server.address"), ":8081")
+
+		// Profile file does not exist.
+		c.SetProfile("dev")
+		t.Assert(c.GetString("server.address"), ":80")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		os.Setenv("GF_PROFILE", "prod")
+		defer os.Unsetenv("GF_PROFILE")
+		t.Assert(gcfg.New().GetProfile(), "prod")
+	})
+}
+
+func Test_Interpolation(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		dirPath := gfile.TempDir(gtime.TimestampNanoStr())
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.toml"), `
+host    = "${GCFG_TEST_HOST:127.0.0.1}"
+port    = 3306
+link    = "mysql:root@tcp(${host}:${port})/test"
+ports   = "${ports_ref}"
+unknown = "${GCFG_TEST_UNKNOWN}"
+loop    = "${loop}"
+ports_ref = [80, 443]
+[database]
+	link = "${link}"
+	port = "${port}"
+`), nil)
+
+		c := gcfg.New()
+		t.Assert(c.SetPath(dirPath), nil)
+		t.Assert(c.GetString("host"), "127.0.0.1")
+		t.Assert(c.GetString("link"), "mysql:root@tcp(127.0.0.1:3306)/test")
+		t.Assert(c.GetString("database.link"), "mysql:root@tcp(127.0.0.1:3306)/test")
+		t.Assert(c.Get("database.port"), 3306)
+		t.Assert(c.GetInts("ports"), []int{80, 443})
+		t.Assert(c.GetString("unknown"), "${GCFG_TEST_UNKNOWN}")
+		t.Assert(c.GetString("loop"), "${loop}")
+		t.Assert(c.GetMap("database")["link"], "mysql:root@tcp(127.0.0.1:3306)/test")
+
+		// It is resolved lazily on access.
+		os.Setenv("GCFG_TEST_HOST", "localhost")
+		defer os.Unsetenv("GCFG_TEST_HOST")
+		t.Assert(c.GetString("host"), "localhost")
+		t.Assert(c.GetString("database.link"), "mysql:root@tcp(localhost:3306)/test")
+
+		var database struct {
+			Link string
+			Port int
+		}
+		t.Assert(c.GetStruct("database", &database), nil)
+		t.Assert(database.Link, "mysql:root@tcp(localhost:3306)/test")
+		t.Assert(database.Port, 3306)
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gogf/gf/os/gcfg"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/text/gstr"
+)
+
+func Test_Secret_EncryptValue(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		key := []byte("0123456789abcdef")
+		value, err := gcfg.EncryptValue("123456", key)
+		t.Assert(err, nil)
+		t.Assert(gcfg.IsEncryptedValue(value), true)
+		t.Assert(gstr.HasPrefix(value, "ENC("), true)
+
+		plainText, err := gcfg.DecryptValue(value, key)
+		t.Assert(err, nil)
+		t.Assert(plainText, "123456")
+
+		_, err = gcfg.DecryptValue(value, []byte("0123456789abcdeg"))
+		t.AssertNE(err, nil)
+		_, err = gcfg.DecryptValue("123456", key)
+		t.AssertNE(err, nil)
+		t.Assert(gcfg.IsEncryptedValue("ENC(123"), false)
+	})
+}
+
+func Test_Secret_Config(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key      = []byte("0123456789abcdef0123456789abcdef")
+			dirPath  = gfile.TempDir(gtime.TimestampNanoStr())
+			keyPath  = gfile.Join(dirPath, "secret.key")
+			password = "p@ss:word"
+		)
+		defer gfile.Remove(dirPath)
+		value, err := gcfg.EncryptValue(password, key)
+		t.Assert(err, nil)
+		t.Assert(gfile.PutContents(gfile.Join(dirPath, "config.toml"), fmt.Sprintf(`
+[database]
+	user = "root"
+	pass = "%s"
+	link = "mysql:${database.user}:${database.pass}@tcp(127.0.0.1:3306)/test"
+`, value)), nil)
+		t.Assert(gfile.PutContents(keyPath, "base64:"+base64.StdEncoding.EncodeToString(key)+"\n"), nil)
+
+		c := gcfg.New()
+		t.Assert(c.SetPath(dirPath), nil)
+
+		// No key.
+		t.Assert(c.GetString("database.pass"), value)
+
+		// Key from file.
+		c.SetKeyProvider(gcfg.NewKeyProviderFile(keyPath))
+		t.Assert(c.GetString("database.pass"), password)
+		t.Assert(c.GetString("database.link"), "mysql:root:p@ss:word@tcp(127.0.0.1:3306)/test")
+		t.Assert(c.GetMap("database")["pass"], password)
+
+		// Key from environment.
+		os.Setenv("GCFG_TEST_SECRET_KEY", string(key))
+		defer os.Unsetenv("GCFG_TEST_SECRET_KEY")
+		c.SetKeyProvider(gcfg.NewKeyProviderEnv("gcfg.test.secret.key"))
+		t.Assert(c.GetString("database.pass"), password)
+
+		// Pluggable key provider.
+		c.SetKeyProvider(gcfg.KeyProviderFunc(func() ([]byte, error) {
+			return key, nil
+		}))
+		t.Assert(c.GetString("database.pass"), password)
+	})
+}
+
+func Test_Secret_Rekey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			oldKey   = []byte("0123456789abcdef")
+			newKey   = []byte("fedcba9876543210")
+			filePath = gfile.Join(gfile.TempDir(gtime.TimestampNanoStr()), "config.yaml")
+		)
+		defer gfile.Remove(gfile.Dir(filePath))
+		value1, err := gcfg.EncryptValue("value1", oldKey)
+		t.Assert(err, nil)
+		value2, err := gcfg.EncryptValue("value2", oldKey)
+		t.Assert(err, nil)
+		content := fmt.Sprintf("# comment\nkey1: %s\nkey2: \"%s\"\nkey3: value3\n", value1, value2)
+		t.Assert(gfile.PutContents(filePath, content), nil)
+
+		// Wrong key, the file is not changed.
+		t.AssertNE(gcfg.RekeyFile(filePath, newKey, oldKey), nil)
+		t.Assert(gfile.GetContents(filePath), content)
+
+		t.Assert(gcfg.RekeyFile(filePath, oldKey, newKey), nil)
+		newContent := gfile.GetContents(filePath)
+		t.Assert(gstr.Contains(newContent, "# comment\n"), true)
+		t.Assert(gstr.Contains(newContent, "key3: value3\n"), true)
+		t.Assert(gstr.Contains(newContent, value1), false)
+
+		c := gcfg.New()
+		t.Assert(c.SetPath(gfile.Dir(filePath)), nil)
+		c.SetFileName("config.yaml")
+		c.SetKeyProvider(gcfg.KeyProviderFunc(func() ([]byte, error) {
+			return newKey, nil
+		}))
+		t.Assert(c.GetString("key1"), "value1")
+		t.Assert(c.GetString("key2"), "value2")
+	})
+}
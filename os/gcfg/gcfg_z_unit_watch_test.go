@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcfg_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/container/garray"
+	"github.com/gogf/gf/container/gvar"
+	"github.com/gogf/gf/encoding/gjson"
+	"github.com/gogf/gf/os/gcfg"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_Watch_OnChange(t *testing.T) {
+	type Server struct {
+		Address string
+		Timeout int
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dirPath  = gfile.TempDir(gtime.TimestampNanoStr())
+			filePath = gfile.Join(dirPath, "config.toml")
+			changes  = garray.NewStrArray(true)
+		)
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(filePath, "name = \"app\"\n[server]\naddress = \":80\"\ntimeout = 10\n"), nil)
+
+		c := gcfg.New()
+		t.Assert(c.SetPath(dirPath), nil)
+		c.SetReloadDelay(100 * time.Millisecond)
+		c.OnChange("server.address", func(old, new *gvar.Var) {
+			changes.Append(old.String() + "->" + new.String())
+		})
+		c.OnChange("name", func(old, new *gvar.Var) {
+			changes.Append("name")
+		})
+		watcher, err := c.Watch("server", (*Server)(nil))
+		t.Assert(err, nil)
+		t.Assert(watcher.Load().(*Server).Address, ":80")
+		oldServer := watcher.Load().(*Server)
+
+		// Concurrent readers always see a complete struct.
+		var (
+			stop = make(chan struct{})
+			done = make(chan struct{})
+		)
+		go func() {
+			defer close(done)
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					_ = watcher.Load().(*Server).Address
+				}
+			}
+		}()
+
+		// Burst changes are merged into one reloading.
+		t.Assert(gfile.PutContents(filePath, "name = \"app\"\n[server]\naddress = \":81\"\ntimeout = 10\n"), nil)
+		t.Assert(gfile.PutContents(filePath, "name = \"app\"\n[server]\naddress = \":82\"\ntimeout = 20\n"), nil)
+		time.Sleep(time.Second)
+		t.Assert(changes.Slice(), []string{":80->:82"})
+		t.Assert(c.GetString("server.address"), ":82")
+		close(stop)
+		<-done
+		server := watcher.Load().(*Server)
+		t.Assert(server.Address, ":82")
+		t.Assert(server.Timeout, 20)
+		t.Assert(oldServer.Address, ":80")
+	})
+}
+
+func Test_Watch_Reject(t *testing.T) {
+	type Server struct {
+		Address string
+		Timeout int
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dirPath  = gfile.TempDir(gtime.TimestampNanoStr())
+			filePath = gfile.Join(dirPath, "config.toml")
+			changed  = false
+		)
+		defer gfile.Remove(dirPath)
+		t.Assert(gfile.PutContents(filePath, "[server]\naddress = \":80\"\n"), nil)
+
+		c := gcfg.New()
+		t.Assert(c.SetPath(dirPath), nil)
+		c.SetReloadDelay(time.Hour)
+		c.AddValidator(func(j *gjson.Json) error {
+			if j.GetString("server.address") == "" {
+				return errors.New("server.address is required")
+			}
+			return nil
+		})
+		c.OnChange("server", func(old, new *gvar.Var) {
+			changed = true
+		})
+		watcher, err := c.Watch("server", (*Server)(nil))
+		t.Assert(err, nil)
+
+		// Invalid content.
+		t.Assert(gfile.PutContents(filePath, "[server\naddress = \":81\"\n"), nil)
+		t.AssertNE(c.Reload(), nil)
+		t.Assert(c.GetString("server.address"), ":80")
+
+		// Validator fails.
+		t.Assert(gfile.PutContents(filePath, "[server]\ntimeout = 10\n"), nil)
+		t.AssertNE(c.Reload(), nil)
+		t.Assert(c.GetString("server.address"), ":80")
+
+		t.Assert(watcher.Load().(*Server).Address, ":80")
+		t.Assert(changed, false)
+
+		t.Assert(gfile.PutContents(filePath, "[server]\naddress = \":81\"\n"), nil)
+		t.Assert(c.Reload(), nil)
+		t.Assert(c.GetString("server.address"), ":81")
+		t.Assert(watcher.Load().(*Server).Address, ":81")
+		t.Assert(changed, true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		c := gcfg.New()
+		var (
+			s      string
+			server Server
+		)
+		_, err := c.Watch("server", &s)
+		t.AssertNE(err, nil)
+		_, err = c.Watch("server", nil)
+		t.AssertNE(err, nil)
+		_, err = c.Watch("server", server)
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gogf/gf/errors/gerror"
+)
+
+// Command is a command of the command tree, which can have sub commands.
+//
+// A command line like "app db migrate --step 1 extra" runs the command "migrate", which is
+// a sub command of "db" of the root command "app". The options of all the commands in the
+// path are supported, so the options of parent commands act as global options of its sub commands.
+type Command struct {
+	Name        string          // Command name, which is case-sensitive.
+	Usage       string          // Custom usage line in help, like: "app serve [OPTION] DIR".
+	Brief       string          // Brief description in one line.
+	Description string          // Detailed description.
+	Examples    string          // Usage examples.
+	Options     interface{}     // Pointer to option struct, whose fields are bound from command options.
+	EnvPrefix   string          // Prefix of environment variables as fallback of options, like: "APP" for APP_PORT.
+	Validator   OptionValidator // Validator for option structs of the command and its sub commands, eg: gvalid.CheckCommandOptions.
+	Func        CommandFunc     // Function running the command.
+	Sub         []*Command      // Sub commands.
+	Hidden      bool            // Whether hidden from help and completion.
+}
+
+// CommandFunc is the function running a command. The parameter <parser> contains the arguments
+// and options, in which the first argument is the program name and the followings are the
+// arguments after the command path.
+type CommandFunc func(ctx context.Context, parser *Parser) error
+
+// OptionValidator validates the option struct <pointer> after binding.
+type OptionValidator func(ctx context.Context, pointer interface{}) error
+
+const (
+	helpOptionName  = "help"
+	helpOptionShort = "h"
+)
+
+var (
+	// optionValidator is the default validator for option structs of all commands,
+	// as package gcmd cannot import package gvalid due to import cycle.
+	optionValidator OptionValidator
+)
+
+// SetOptionValidator sets the default validator for option structs of all commands, which is
+// used if no Validator is set in the command path, eg: gcmd.SetOptionValidator(gvalid.CheckCommandOptions).
+//
+// Note that running a command whose option struct has validation rules in struct tag "v" fails
+// if no validator is set, so that the rules are never skipped silently.
+func SetOptionValidator(validator OptionValidator) {
+	optionValidator = validator
+}
+
+// Run runs the command tree with os.Args. See RunWithArgs.
+func (c *Command) Run(ctx context.Context) error {
+	return c.RunWithArgs(ctx, os.Args)
+}
+
+// RunWithArgs runs the command tree with arguments <args>, in which the first one is the
+// program name.
+//
+// It finds the command by the leading arguments, binds the options of the commands in
+// the path to their option structs, validates them using the Validator of the nearest command
+// in the path or the default validator, and calls the Func of the command.
+// It prints the help content of the command if option "-h/--help" is passed, or the
+// command has no Func.
+func (c *Command) RunWithArgs(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		args = []string{c.Name}
+	}
+	path, remaining, err := c.findPath(args[1:])
+	if err != nil {
+		return err
+	}
+	var (
+		cmd              = path[len(path)-1]
+		supportedOptions = map[string]bool{
+			helpOptionShort + "," + helpOptionName: false,
+		}
+		optionsList = make([][]*commandOption, len(path))
+	)
+	for i, item := range path {
+		if optionsList[i], err = item.parseOptions(); err != nil {
+			return err
+		}
+		for _, option := range optionsList[i] {
+			supportedOptions[option.names()] = option.NeedArgument
+		}
+	}
+	parser, err := ParseWithArgs(append([]string{args[0]}, remaining...), supportedOptions, true)
+	if err != nil {
+		return gerror.Wrapf(err, `command "%s" failed`, cmd.fullName(path))
+	}
+	if parser.ContainsOpt(helpOptionName) || cmd.Func == nil {
+		fmt.Print(cmd.helpContent(path))
+		return nil
+	}
+	validator := optionValidator
+	for i, item := range path {
+		if item.Validator != nil {
+			validator = item.Validator
+		}
+		if err = item.bindOptions(ctx, parser, optionsList[i], validator); err != nil {
+			return gerror.Wrapf(err, `command "%s" failed`, cmd.fullName(path))
+		}
+	}
+	return cmd.Func(ctx, parser)
+}
+
+// Help returns the help content of the command.
+func (c *Command) Help() string {
+	return c.helpContent([]*Command{c})
+}
+
+// findPath finds the command path by leading arguments <args>, and returns the command path
+// from the root command and the remaining arguments without the command names.
+func (c *Command) findPath(args []string) (path []*Command, remaining []string, err error) {
+	var (
+		cmd         = c
+		options     []*commandOption
+		positioning = false
+	)
+	path = []*Command{c}
+	if options, err = c.parseOptions(); err != nil {
+		return
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, "-") {
+			remaining = append(remaining, arg)
+			// The option value in the next argument.
+			if name := strings.TrimLeft(arg, "-"); !strings.Contains(name, "=") && i < len(args)-1 {
+				if option := findOption(options, name); option != nil && option.NeedArgument {
+					remaining = append(remaining, args[i+1])
+					i++
+				}
+			}
+			continue
+		}
+		if !positioning {
+			if sub := cmd.findSub(arg); sub != nil {
+				cmd = sub
+				path = append(path, sub)
+				var subOptions []*commandOption
+				if subOptions, err = sub.parseOptions(); err != nil {
+					return
+				}
+				options = append(options, subOptions...)
+				continue
+			}
+			if len(cmd.Sub) > 0 && cmd.Func == nil {
+				err = gerror.Newf(`unknown command "%s", see "%s --help"`, arg, cmd.fullName(path))
+				return
+			}
+			positioning = true
+		}
+		remaining = append(remaining, arg)
+	}
+	return
+}
+
+// findSub returns the sub command named <name>.
+func (c *Command) findSub(name string) *Command {
+	for _, sub := range c.Sub {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// fullName returns the full name of the command of <path>, like: "app db migrate".
+func (c *Command) fullName(path []*Command) string {
+	names := make([]string, len(path))
+	for i, cmd := range path {
+		names[i] = cmd.Name
+	}
+	return strings.Join(names, " ")
+}
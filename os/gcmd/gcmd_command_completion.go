@@ -0,0 +1,154 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gcmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/errors/gerror"
+)
+
+// completionItem is a candidate word of completion with its description.
+type completionItem struct {
+	word  string
+	brief string
+}
+
+// completionNode is a command in the command tree for completion.
+type completionNode struct {
+	path  string           // Full name of the command, like: "app db migrate".
+	items []completionItem // Candidate sub commands and options.
+}
+
+// Completion returns the completion script of the command tree for shell <shell>,
+// which is "bash" or "zsh".
+//
+// The script can be loaded in shell like:
+// bash: source <(app completion bash)
+// zsh : app completion zsh > "${fpath[1]}/_app"
+func (c *Command) Completion(shell string) (string, error) {
+	nodes := make([]completionNode, 0)
+	c.completionNodes([]*Command{c}, &nodes)
+	switch shell {
+	case "bash":
+		return c.completionBash(nodes), nil
+	case "zsh":
+		return c.completionZsh(nodes), nil
+	}
+	return "", gerror.Newf(`unsupported shell "%s" for completion, it should be one of: bash, zsh`, shell)
+}
+
+// completionNodes collects the completion nodes of the command of <path> and its sub commands.
+func (c *Command) completionNodes(path []*Command, nodes *[]completionNode) {
+	node := completionNode{path: c.fullName(path)}
+	for _, sub := range c.visibleSubs() {
+		node.items = append(node.items, completionItem{word: sub.Name, brief: sub.Brief})
+	}
+	for _, cmd := range path {
+		options, _ := cmd.parseOptions()
+		for _, option := range options {
+			node.items = append(node.items, completionItem{word: "--" + option.Name, brief: option.Brief})
+			if option.Short != "" {
+				node.items = append(node.items, completionItem{word: "-" + option.Short, brief: option.Brief})
+			}
+		}
+	}
+	node.items = append(node.items, completionItem{word: "--" + helpOptionName, brief: "show help information"})
+	*nodes = append(*nodes, node)
+	for _, sub := range c.visibleSubs() {
+		sub.completionNodes(append(path[:len(path):len(path)], sub), nodes)
+	}
+}
+
+// completionBash returns the bash completion script.
+func (c *Command) completionBash(nodes []completionNode) string {
+	var (
+		buffer   = bytes.NewBuffer(nil)
+		funcName = completionFuncName(c.Name)
+	)
+	buffer.WriteString(fmt.Sprintf("# bash completion for %s\n", c.Name))
+	buffer.WriteString(fmt.Sprintf("%s() {\n", funcName))
+	buffer.WriteString("    local cur cmdpath i\n")
+	buffer.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	buffer.WriteString(fmt.Sprintf("    cmdpath=%s\n", shellQuote(c.Name)))
+	c.writeCompletionPathLoop(buffer, nodes, "1", "COMP_CWORD", "COMP_WORDS[i]")
+	buffer.WriteString("    case \"${cmdpath}\" in\n")
+	for _, node := range nodes {
+		words := make([]string, len(node.items))
+		for i, item := range node.items {
+			words[i] = item.word
+		}
+		buffer.WriteString(fmt.Sprintf(
+			"        %s) COMPREPLY=($(compgen -W %s -- \"${cur}\")) ;;\n",
+			shellQuote(node.path), shellQuote(strings.Join(words, " ")),
+		))
+	}
+	buffer.WriteString("    esac\n")
+	buffer.WriteString("}\n")
+	buffer.WriteString(fmt.Sprintf("complete -F %s %s\n", funcName, c.Name))
+	return buffer.String()
+}
+
+// completionZsh returns the zsh completion script.
+func (c *Command) completionZsh(nodes []completionNode) string {
+	var (
+		buffer   = bytes.NewBuffer(nil)
+		funcName = completionFuncName(c.Name)
+	)
+	buffer.WriteString(fmt.Sprintf("#compdef %s\n", c.Name))
+	buffer.WriteString(fmt.Sprintf("%s() {\n", funcName))
+	buffer.WriteString("    local cmdpath i\n")
+	buffer.WriteString("    local -a candidates\n")
+	buffer.WriteString(fmt.Sprintf("    cmdpath=%s\n", shellQuote(c.Name)))
+	c.writeCompletionPathLoop(buffer, nodes, "2", "CURRENT", "words[i]")
+	buffer.WriteString("    case \"${cmdpath}\" in\n")
+	for _, node := range nodes {
+		items := make([]string, len(node.items))
+		for i, item := range node.items {
+			items[i] = shellQuote(strings.Replace(item.word, ":", `\:`, -1) + ":" + item.brief)
+		}
+		buffer.WriteString(fmt.Sprintf(
+			"        %s) candidates=(%s) ;;\n",
+			shellQuote(node.path), strings.Join(items, " "),
+		))
+	}
+	buffer.WriteString("    esac\n")
+	buffer.WriteString("    _describe 'command' candidates\n")
+	buffer.WriteString("}\n")
+	buffer.WriteString(fmt.Sprintf("compdef %s %s\n", funcName, c.Name))
+	return buffer.String()
+}
+
+// writeCompletionPathLoop writes the shell loop into <buffer>, which finds the command path
+// from the typed words from index <start> to <end>.
+func (c *Command) writeCompletionPathLoop(buffer *bytes.Buffer, nodes []completionNode, start, end, word string) {
+	paths := make([]string, 0, len(nodes))
+	for _, node := range nodes[1:] {
+		paths = append(paths, shellQuote(node.path))
+	}
+	if len(paths) == 0 {
+		return
+	}
+	buffer.WriteString(fmt.Sprintf("    for ((i = %s; i < %s; i++)); do\n", start, end))
+	buffer.WriteString(fmt.Sprintf("        case \"${cmdpath} ${%s}\" in\n", word))
+	buffer.WriteString(fmt.Sprintf("            %s) cmdpath=\"${cmdpath} ${%s}\" ;;\n", strings.Join(paths, "|"), word))
+	buffer.WriteString("        esac\n")
+	buffer.WriteString("    done\n")
+}
+
+// completionFuncName returns the shell function name of completion for command <name>.
+func completionFuncName(name string) string {
+	return "_" + strings.NewReplacer("-", "_", ".", "_").Replace(name) + "_completion"
+}
+
+// shellQuote quotes <s> with single quotes for shell script.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,143 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gcmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	helpIndent = "    "
+)
+
+// helpContent returns the help content of the command of <path>, which contains the usage,
+// sub commands, options of all the commands in the path, description and examples.
+func (c *Command) helpContent(path []*Command) string {
+	var (
+		buffer   = bytes.NewBuffer(nil)
+		fullName = c.fullName(path)
+	)
+	if c.Brief != "" {
+		buffer.WriteString(c.Brief + "\n\n")
+	}
+	// Usage.
+	usage := c.Usage
+	if usage == "" {
+		usage = fullName
+		if len(c.visibleSubs()) > 0 {
+			usage += " COMMAND"
+		}
+		usage += " [OPTION]"
+		if c.Func != nil {
+			usage += " [ARGUMENT...]"
+		}
+	}
+	buffer.WriteString("USAGE\n")
+	buffer.WriteString(helpIndent + usage + "\n")
+
+	// Sub commands.
+	if subs := c.visibleSubs(); len(subs) > 0 {
+		rows := make([][2]string, len(subs))
+		for i, sub := range subs {
+			rows[i] = [2]string{sub.Name, sub.Brief}
+		}
+		buffer.WriteString("\nCOMMAND\n")
+		writeHelpRows(buffer, rows)
+	}
+
+	// Options of all the commands in the path.
+	rows := make([][2]string, 0)
+	for _, cmd := range path {
+		options, _ := cmd.parseOptions()
+		for _, option := range options {
+			rows = append(rows, [2]string{option.helpName(), option.helpBrief()})
+		}
+	}
+	rows = append(rows, [2]string{
+		fmt.Sprintf(`-%s, --%s`, helpOptionShort, helpOptionName),
+		"show help information",
+	})
+	buffer.WriteString("\nOPTION\n")
+	writeHelpRows(buffer, rows)
+
+	if c.Examples != "" {
+		buffer.WriteString("\nEXAMPLE\n")
+		writeHelpText(buffer, c.Examples)
+	}
+	if c.Description != "" {
+		buffer.WriteString("\nDESCRIPTION\n")
+		writeHelpText(buffer, c.Description)
+	}
+	if len(c.visibleSubs()) > 0 {
+		buffer.WriteString(fmt.Sprintf("\nUse \"%s COMMAND --help\" for more information about a command.\n", fullName))
+	}
+	return buffer.String()
+}
+
+// visibleSubs returns the sub commands that are not hidden.
+func (c *Command) visibleSubs() []*Command {
+	subs := make([]*Command, 0, len(c.Sub))
+	for _, sub := range c.Sub {
+		if !sub.Hidden {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// helpName returns the option names in help, like: "-c, --config-file string".
+func (o *commandOption) helpName() string {
+	name := "--" + o.Name
+	if o.Short != "" {
+		name = "-" + o.Short + ", " + name
+	} else {
+		name = "    " + name
+	}
+	if o.NeedArgument {
+		name += " " + o.Type
+	}
+	return name
+}
+
+// helpBrief returns the option description in help, with its default value and environment.
+func (o *commandOption) helpBrief() string {
+	brief := o.Brief
+	if o.HasDefault && o.Default != "" {
+		brief += fmt.Sprintf(` (default: %s)`, o.Default)
+	}
+	if o.Env != "" {
+		brief += fmt.Sprintf(` [env: %s]`, o.Env)
+	}
+	return strings.TrimSpace(brief)
+}
+
+// writeHelpRows writes <rows> of name and brief into <buffer> with aligned columns.
+func writeHelpRows(buffer *bytes.Buffer, rows [][2]string) {
+	width := 0
+	for _, row := range rows {
+		if len(row[0]) > width {
+			width = len(row[0])
+		}
+	}
+	for _, row := range rows {
+		line := helpIndent + row[0]
+		if row[1] != "" {
+			line += strings.Repeat(" ", width-len(row[0])+4) + row[1]
+		}
+		buffer.WriteString(line + "\n")
+	}
+}
+
+// writeHelpText writes multiple lines text <text> into <buffer> with indent.
+func writeHelpText(buffer *bytes.Buffer, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		buffer.WriteString(strings.TrimRight(helpIndent+strings.TrimSpace(line), " ") + "\n")
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gcmd
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/text/gstr"
+	"github.com/gogf/gf/util/gconv"
+)
+
+// commandOption is the option parsed from the field of option struct.
+//
+// The option struct field supports tags:
+// name    : Long option name, which is the kebab case of field name in default, eg: "config-file".
+// short   : Short option name, eg: "c".
+// brief   : Brief description of the option in help.
+// d       : Default value, "default" is also supported.
+// env     : Environment variable name as fallback of the option, eg: "APP_CONFIG_FILE".
+// v       : Validation rules of package gvalid, eg: "required|min:1".
+//
+// The field is ignored if its option name is "-".
+// Option of bool field does not need argument, like: --debug, but --debug=false is also supported.
+// Option of slice field receives values separated by char ',', like: --tags=a,b.
+// The value of numeric, bool or time.Duration field is checked, and the binding fails if the
+// value cannot be converted, like: --port=abc.
+type commandOption struct {
+	Name         string // Long option name.
+	Short        string // Short option name.
+	Brief        string // Brief description.
+	Default      string // Default value.
+	HasDefault   bool   // Whether the default value is specified.
+	Env          string // Environment variable name.
+	Rule         string // Validation rules.
+	Field        string // Field name in option struct.
+	Type         string // Value type in help, like: string, int.
+	IsSlice      bool   // Whether the field is type of slice.
+	NeedArgument bool   // Whether the option needs argument.
+
+	valueType reflect.Type // Type of the field, or type of the element for slice field.
+}
+
+var (
+	// defaultValueTags are the struct tag names for default values of options.
+	defaultValueTags = []string{"d", "default"}
+)
+
+// parseOptions parses and returns the options from the option struct of the command.
+func (c *Command) parseOptions() ([]*commandOption, error) {
+	if c.Options == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(c.Options)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil, gerror.Newf(`options of command "%s" should be type of *struct, but got %T`, c.Name, c.Options)
+	}
+	options := make([]*commandOption, 0)
+	c.parseStructOptions(rv.Elem().Type(), &options)
+	return options, nil
+}
+
+// parseStructOptions parses the options from the fields of <structType> into <options>,
+// including the fields of embedded structs.
+func (c *Command) parseStructOptions(structType reflect.Type, options *[]*commandOption) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			c.parseStructOptions(field.Type, options)
+			continue
+		}
+		option := &commandOption{
+			Name:  field.Tag.Get("name"),
+			Short: field.Tag.Get("short"),
+			Brief: field.Tag.Get("brief"),
+			Env:   field.Tag.Get("env"),
+			Rule:  field.Tag.Get("v"),
+			Field: field.Name,
+		}
+		if option.Name == "-" {
+			continue
+		}
+		if option.Name == "" {
+			option.Name = gstr.CaseKebab(field.Name)
+		}
+		if option.Env == "" && c.EnvPrefix != "" {
+			option.Env = strings.ToUpper(c.EnvPrefix) + "_" + gstr.CaseSnakeScreaming(option.Name)
+		}
+		for _, tag := range defaultValueTags {
+			if value, ok := field.Tag.Lookup(tag); ok {
+				option.Default, option.HasDefault = value, true
+				break
+			}
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		option.valueType = fieldType
+		switch fieldType.Kind() {
+		case reflect.Bool:
+			option.Type = "bool"
+		case reflect.Slice, reflect.Array:
+			option.Type = fieldType.Elem().Kind().String() + "s"
+			option.IsSlice = true
+			option.NeedArgument = true
+			option.valueType = fieldType.Elem()
+		default:
+			option.Type = fieldType.Kind().String()
+			option.NeedArgument = true
+			if fieldType == reflect.TypeOf(time.Duration(0)) {
+				option.Type = "duration"
+			}
+		}
+		*options = append(*options, option)
+	}
+}
+
+// bindOptions binds the option values of <parser> to the option struct of the command,
+// and validates it using <validator>.
+//
+// The value of each option is retrieved in order of: command option, environment variable,
+// default value. The field is kept unchanged if none of them exists.
+func (c *Command) bindOptions(ctx context.Context, parser *Parser, options []*commandOption, validator OptionValidator) error {
+	if c.Options == nil {
+		return nil
+	}
+	hasRule := false
+	for _, option := range options {
+		if option.Rule != "" {
+			hasRule = true
+			break
+		}
+	}
+	if hasRule && validator == nil {
+		return gerror.Newf(
+			`options of command "%s" have validation rules in tag "v", but no validator is set, see Command.Validator`,
+			c.Name,
+		)
+	}
+	var (
+		data    = make(map[string]interface{})
+		mapping = make(map[string]string)
+	)
+	for _, option := range options {
+		var (
+			value string
+			found = true
+		)
+		if parser.ContainsOpt(option.Name) {
+			value = parser.GetOpt(option.Name)
+			if !option.NeedArgument && value == "" {
+				value = "true"
+			}
+		} else if v, ok := lookupEnv(option.Env); ok {
+			value = v
+		} else if option.HasDefault {
+			value = option.Default
+		} else {
+			found = false
+		}
+		if !found {
+			continue
+		}
+		if option.IsSlice {
+			values := gstr.SplitAndTrim(value, ",")
+			for _, v := range values {
+				if err := option.checkValue(v); err != nil {
+					return err
+				}
+			}
+			data[option.Field] = values
+		} else {
+			if err := option.checkValue(value); err != nil {
+				return err
+			}
+			data[option.Field] = value
+		}
+		mapping[option.Field] = option.Field
+	}
+	if len(data) > 0 {
+		if err := gconv.Struct(data, c.Options, mapping); err != nil {
+			return err
+		}
+	}
+	if validator != nil {
+		return validator(ctx, c.Options)
+	}
+	return nil
+}
+
+// checkValue checks whether <value> can be converted to the value type of the option.
+func (o *commandOption) checkValue(value string) error {
+	var err error
+	switch o.valueType.Kind() {
+	case reflect.Bool:
+		_, err = strconv.ParseBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if o.valueType == reflect.TypeOf(time.Duration(0)) {
+			if _, err = time.ParseDuration(value); err != nil {
+				// Duration in nanoseconds is also supported.
+				_, err = strconv.ParseInt(value, 10, 64)
+			}
+		} else {
+			_, err = strconv.ParseInt(value, 10, o.valueType.Bits())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, err = strconv.ParseUint(value, 10, o.valueType.Bits())
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(value, o.valueType.Bits())
+	}
+	if err != nil {
+		return gerror.Newf(`invalid value "%s" of option "%s", %s is expected`, value, o.Name, o.valueType.String())
+	}
+	return nil
+}
+
+// names returns the option names for Parser, like: "c,config-file".
+func (o *commandOption) names() string {
+	if o.Short != "" {
+		return o.Short + "," + o.Name
+	}
+	return o.Name
+}
+
+// findOption returns the option of <name> from <options>, which can be its long or short name.
+func findOption(options []*commandOption, name string) *commandOption {
+	for _, option := range options {
+		if option.Name == name || (option.Short != "" && option.Short == name) {
+			return option
+		}
+	}
+	return nil
+}
+
+// lookupEnv retrieves the environment variable <name>.
+func lookupEnv(name string) (string, bool) {
+	if name == "" {
+		return "", false
+	}
+	return os.LookupEnv(name)
+}
@@ -0,0 +1,181 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcmd_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/os/gcmd"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/text/gstr"
+	"github.com/gogf/gf/util/gvalid"
+)
+
+type commandGlobalOptions struct {
+	Config string `short:"c" brief:"config file path" d:"config.toml"`
+	Debug  bool   `brief:"enable debug mode"`
+}
+
+type commandServeOptions struct {
+	Port    int           `short:"p" brief:"listening port" d:"8000" v:"min:1#port should be positive"`
+	Address string        `name:"addr" brief:"listening address" env:"GCMD_TEST_ADDR"`
+	Tags    []string      `brief:"server tags"`
+	Name    string        `v:"required#name is required"`
+	Timeout time.Duration `brief:"request timeout" d:"10s"`
+	Weights []int         `brief:"server weights"`
+}
+
+func newTestCommand() (*gcmd.Command, *commandGlobalOptions, *commandServeOptions, *[]string) {
+	var (
+		global = &commandGlobalOptions{}
+		serve  = &commandServeOptions{}
+		args   = &[]string{}
+	)
+	return &gcmd.Command{
+		Name:      "app",
+		Brief:     "app is a demo application",
+		Options:   global,
+		Validator: gvalid.CheckCommandOptions,
+		Sub: []*gcmd.Command{
+			{
+				Name:      "serve",
+				Brief:     "start http server",
+				Options:   serve,
+				EnvPrefix: "GCMD_TEST",
+				Func: func(ctx context.Context, parser *gcmd.Parser) error {
+					*args = parser.GetArgAll()
+					return nil
+				},
+			},
+			{
+				Name:  "db",
+				Brief: "database operations",
+				Sub: []*gcmd.Command{
+					{
+						Name:  "migrate",
+						Brief: "migrate database",
+						Func: func(ctx context.Context, parser *gcmd.Parser) error {
+							*args = parser.GetArgAll()
+							return nil
+						},
+					},
+				},
+			},
+		},
+	}, global, serve, args
+}
+
+func Test_Command_Run(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cmd, global, serve, args := newTestCommand()
+		err := cmd.RunWithArgs(context.TODO(), []string{
+			"app", "--debug", "serve", "-p", "80", "--name=demo", "--tags", "a, b", "dir",
+		})
+		t.Assert(err, nil)
+		t.Assert(global.Config, "config.toml")
+		t.Assert(global.Debug, true)
+		t.Assert(serve.Port, 80)
+		t.Assert(serve.Name, "demo")
+		t.Assert(serve.Tags, []string{"a", "b"})
+		t.Assert(serve.Timeout, 10*time.Second)
+		t.Assert(*args, []string{"app", "dir"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		cmd, global, _, args := newTestCommand()
+		err := cmd.RunWithArgs(context.TODO(), []string{"app", "db", "-c", "prod.toml", "migrate", "up"})
+		t.Assert(err, nil)
+		t.Assert(global.Config, "prod.toml")
+		t.Assert(*args, []string{"app", "up"})
+	})
+	// Environment variables.
+	gtest.C(t, func(t *gtest.T) {
+		os.Setenv("GCMD_TEST_ADDR", "127.0.0.1")
+		os.Setenv("GCMD_TEST_NAME", "env")
+		defer os.Unsetenv("GCMD_TEST_ADDR")
+		defer os.Unsetenv("GCMD_TEST_NAME")
+		cmd, _, serve, _ := newTestCommand()
+		t.Assert(cmd.RunWithArgs(context.TODO(), []string{"app", "serve"}), nil)
+		t.Assert(serve.Address, "127.0.0.1")
+		t.Assert(serve.Name, "env")
+
+		t.Assert(cmd.RunWithArgs(context.TODO(), []string{"app", "serve", "--addr=0.0.0.0", "--name", "opt"}), nil)
+		t.Assert(serve.Address, "0.0.0.0")
+		t.Assert(serve.Name, "opt")
+	})
+}
+
+func Test_Command_Error(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cmd, _, _, _ := newTestCommand()
+		err := cmd.RunWithArgs(context.TODO(), []string{"app", "serve", "-p", "0"})
+		t.AssertNE(err, nil)
+		t.Assert(gstr.Contains(err.Error(), "port should be positive"), true)
+		t.Assert(gstr.Contains(err.Error(), "name is required"), true)
+
+		t.AssertNE(cmd.RunWithArgs(context.TODO(), []string{"app", "unknown"}), nil)
+		t.AssertNE(cmd.RunWithArgs(context.TODO(), []string{"app", "serve", "--none"}), nil)
+	})
+	// Invalid typed values.
+	gtest.C(t, func(t *gtest.T) {
+		cmd, _, _, _ := newTestCommand()
+		err := cmd.RunWithArgs(context.TODO(), []string{"app", "serve", "--name=demo", "--port=abc"})
+		t.AssertNE(err, nil)
+		t.Assert(gstr.Contains(err.Error(), `invalid value "abc" of option "port"`), true)
+
+		err = cmd.RunWithArgs(context.TODO(), []string{"app", "serve", "--name=demo", "--weights=1,x"})
+		t.AssertNE(err, nil)
+		err = cmd.RunWithArgs(context.TODO(), []string{"app", "serve", "--name=demo", "--timeout=abc"})
+		t.AssertNE(err, nil)
+		err = cmd.RunWithArgs(context.TODO(), []string{"app", "--debug=yes", "serve", "--name=demo"})
+		t.AssertNE(err, nil)
+	})
+	// Validation rules without validator.
+	gtest.C(t, func(t *gtest.T) {
+		cmd, _, _, _ := newTestCommand()
+		cmd.Validator = nil
+		err := cmd.RunWithArgs(context.TODO(), []string{"app", "serve", "-p", "0"})
+		t.AssertNE(err, nil)
+		t.Assert(gstr.Contains(err.Error(), "no validator is set"), true)
+		// The command without validation rules runs without validator.
+		t.Assert(cmd.RunWithArgs(context.TODO(), []string{"app", "db", "migrate"}), nil)
+	})
+}
+
+func Test_Command_Help(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cmd, _, _, _ := newTestCommand()
+		help := cmd.Help()
+		t.Assert(gstr.Contains(help, "app COMMAND [OPTION]"), true)
+		t.Assert(gstr.Contains(help, "serve    start http server"), true)
+		t.Assert(gstr.Contains(help, "-c, --config string    config file path (default: config.toml)"), true)
+		t.Assert(gstr.Contains(help, "--debug"), true)
+		t.Assert(gstr.Contains(help, "-h, --help"), true)
+		t.Assert(cmd.RunWithArgs(context.TODO(), []string{"app", "serve", "-h"}), nil)
+	})
+}
+
+func Test_Command_Completion(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		cmd, _, _, _ := newTestCommand()
+		script, err := cmd.Completion("bash")
+		t.Assert(err, nil)
+		t.Assert(gstr.Contains(script, "complete -F _app_completion app"), true)
+		t.Assert(gstr.Contains(script, `'app serve'|'app db'|'app db migrate'`), true)
+		t.Assert(gstr.Contains(script, `'app db migrate') COMPREPLY=($(compgen -W '--config -c --debug --help'`), true)
+
+		script, err = cmd.Completion("zsh")
+		t.Assert(err, nil)
+		t.Assert(gstr.Contains(script, "#compdef app"), true)
+		t.Assert(gstr.Contains(script, `'serve:start http server'`), true)
+
+		_, err = cmd.Completion("fish")
+		t.AssertNE(err, nil)
+	})
+}
@@ -104,3 +104,9 @@ func Line(long ...bool) *Logger {
 func Async(enabled ...bool) *Logger {
 	return logger.Async(enabled...)
 }
+
+// With is a chaining function,
+// which binds structured key-value fields for the current logging content output.
+func With(keyValues ...interface{}) *Logger {
+	return logger.With(keyValues...)
+}
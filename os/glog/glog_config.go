@@ -153,3 +153,19 @@ func GetLevelPrefix(level int) string {
 func SetHandlers(handlers ...Handler) {
 	logger.SetHandlers(handlers...)
 }
+
+// SetSinks sets the remote sinks for the default logger.
+func SetSinks(sinks ...Sink) {
+	logger.SetSinks(sinks...)
+}
+
+// Flush delivers the pending logging contents of all remote sinks of the default logger.
+func Flush() error {
+	return logger.Flush()
+}
+
+// Close flushes and closes all remote sinks of the default logger,
+// which should be called on application shutdown.
+func Close() error {
+	return logger.Close()
+}
@@ -19,13 +19,18 @@ var (
 )
 
 // Instance returns an instance of Logger with default settings.
-// The parameter <name> is the name for the instance.
+// The parameter <name> is the name for the instance, which can be hierarchical using '.',
+// eg: "db.query" is the child of "db" and inherits its level if its own level is not set.
 func Instance(name ...string) *Logger {
 	key := DefaultName
 	if len(name) > 0 && name[0] != "" {
 		key = name[0]
 	}
 	return instances.GetOrSetFuncLock(key, func() interface{} {
-		return New()
+		logger := New()
+		logger.name = key
+		// The new instance may be the ancestor of existing instances.
+		levelVersion.Add(1)
+		return logger
 	}).(*Logger)
 }
@@ -0,0 +1,243 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/container/gmap"
+	"github.com/gogf/gf/container/gtype"
+)
+
+// The named logger instances are hierarchical by their names separated by '.', eg: logger
+// "db.query" is the child of logger "db". The effective level of a named logger is resolved
+// in sequence of:
+// 1. The most specific level override matching its name, which is set by SetLevelOverride or
+//    the "levels" configuration of any logger, and can be changed at runtime;
+// 2. Its own level, if it is explicitly set by SetLevel, SetLevelStr or configuration;
+// 3. The effective level of its nearest ancestor instance;
+// 4. Its default level.
+
+var (
+	// levelOverrides is the pattern to level mapping overriding the levels of named loggers,
+	// which is concurrent-safe and can be changed at runtime.
+	levelOverrides = gmap.NewStrIntMap(true)
+
+	// levelOverridesCount is the count of level overrides for fast checks.
+	levelOverridesCount = gtype.NewInt()
+
+	// levelVersion is increased each time anything affecting the effective levels changes,
+	// which invalidates the effective levels cached by loggers.
+	levelVersion = gtype.NewInt64(1)
+)
+
+// SetLevelOverride overrides the level of named loggers whose name matches <pattern>.
+// The <pattern> can be an exact logger name, or a name pattern using '*' as wildcard,
+// eg: "db.*" matches "db", "db.query" and "db.query.slow", "*" matches all named loggers.
+// The more specific pattern takes precedence if multiple patterns match the name.
+//
+// It is concurrent-safe, which can be used to change logging levels at runtime.
+func SetLevelOverride(pattern string, level int) {
+	levelOverrides.Set(pattern, level)
+	levelOverridesCount.Set(levelOverrides.Size())
+	levelVersion.Add(1)
+}
+
+// SetLevelOverrideStr overrides the level of named loggers whose name matches <pattern>
+// using level string, eg: DEBUG, INFO, ERROR.
+func SetLevelOverrideStr(pattern string, levelStr string) error {
+	level, ok := levelStringMap[strings.ToUpper(levelStr)]
+	if !ok {
+		return errors.New(fmt.Sprintf(`invalid level string: %s`, levelStr))
+	}
+	SetLevelOverride(pattern, level)
+	return nil
+}
+
+// SetLevelOverrides batch overrides the levels of named loggers using pattern to level
+// string map, eg: {"db.*": "DEBUG", "http": "WARN"}.
+//
+// Note that the overrides are global for all named loggers, which is the same as the
+// "levels" item of logger configuration.
+func SetLevelOverrides(levels map[string]string) error {
+	overrides, err := parseLevelOverrides(levels)
+	if err != nil {
+		return err
+	}
+	for pattern, level := range overrides {
+		SetLevelOverride(pattern, level)
+	}
+	return nil
+}
+
+// RemoveLevelOverride removes the level override of <pattern>.
+func RemoveLevelOverride(pattern string) {
+	levelOverrides.Remove(pattern)
+	levelOverridesCount.Set(levelOverrides.Size())
+	levelVersion.Add(1)
+}
+
+// GetLevelOverrides returns a copy of all level overrides.
+func GetLevelOverrides() map[string]int {
+	return levelOverrides.Map()
+}
+
+// Names returns the sorted names of all logger instances.
+func Names() []string {
+	names := instances.Keys()
+	sort.Strings(names)
+	return names
+}
+
+// LevelToString returns the level string of <level>, eg: DEBUG, INFO, NONE.
+// Note that LEVEL_ALL is returned as "DEBUG", as they are the same level.
+// It returns the level value as string if it is not a standard level.
+func LevelToString(level int) string {
+	switch level {
+	case LEVEL_NONE:
+		return "NONE"
+	case levelStringMap["DEBUG"]:
+		return "DEBUG"
+	case levelStringMap["INFO"]:
+		return "INFO"
+	case levelStringMap["NOTI"]:
+		return "NOTICE"
+	case levelStringMap["WARN"]:
+		return "WARN"
+	case levelStringMap["ERRO"]:
+		return "ERROR"
+	case levelStringMap["CRIT"]:
+		return "CRITICAL"
+	}
+	return fmt.Sprintf("%d", level)
+}
+
+// GetName returns the instance name of the logger,
+// which is empty if the logger is not created by Instance.
+func (l *Logger) GetName() string {
+	return l.name
+}
+
+// GetEffectiveLevel returns the level that is actually used for logging output,
+// which is resolved from level overrides and ancestors for named loggers.
+// The resolved level is cached until anything affecting it changes.
+func (l *Logger) GetEffectiveLevel() int {
+	if l.name == "" {
+		return l.config.Level
+	}
+	var (
+		version = uint32(levelVersion.Val())
+		cached  = l.levelCache.Val()
+	)
+	// The cached value is composed of the version in high 32 bits and the level in low 32 bits.
+	if uint32(cached>>32) == version {
+		return int(uint32(cached))
+	}
+	level := l.resolveLevel()
+	l.levelCache.Set(uint64(version)<<32 | uint64(uint32(level)))
+	return level
+}
+
+// resolveLevel resolves the effective level of the named logger without cache.
+func (l *Logger) resolveLevel() int {
+	if levelOverridesCount.Val() > 0 {
+		var (
+			level int
+			ok    bool
+		)
+		levelOverrides.RLockFunc(func(m map[string]int) {
+			level, ok = matchLevelOverride(m, l.name)
+		})
+		if ok {
+			return level
+		}
+	}
+	if l.levelSet {
+		return l.config.Level
+	}
+	// Inherits from the nearest ancestor instance.
+	for name := parentLoggerName(l.name); name != ""; name = parentLoggerName(name) {
+		if v := instances.Get(name); v != nil {
+			return v.(*Logger).resolveLevel()
+		}
+	}
+	return l.config.Level
+}
+
+// registerLevelOverrides registers <overrides> from the "levels" configuration of the logger
+// as global level overrides, replacing the ones registered by its previous configuration.
+// The previous override of a pattern is kept if it has been changed by others since then.
+func (l *Logger) registerLevelOverrides(overrides map[string]int) {
+	levelOverrides.LockFunc(func(m map[string]int) {
+		for pattern, level := range l.levelOverrides {
+			if v, ok := m[pattern]; ok && v == level {
+				delete(m, pattern)
+			}
+		}
+		for pattern, level := range overrides {
+			m[pattern] = level
+		}
+		levelOverridesCount.Set(len(m))
+	})
+	levelVersion.Add(1)
+	l.levelOverrides = overrides
+}
+
+// parseLevelOverrides converts the pattern to level string map <levels> to pattern to level map.
+func parseLevelOverrides(levels map[string]string) (map[string]int, error) {
+	overrides := make(map[string]int, len(levels))
+	for pattern, levelStr := range levels {
+		level, ok := levelStringMap[strings.ToUpper(levelStr)]
+		if !ok {
+			return nil, errors.New(fmt.Sprintf(`invalid level string: %s`, levelStr))
+		}
+		overrides[pattern] = level
+	}
+	return overrides, nil
+}
+
+// matchLevelOverride returns the level of the most specific override in <overrides> matching
+// <name>. The exact name is the most specific, and then the longer pattern is more specific.
+func matchLevelOverride(overrides map[string]int, name string) (level int, ok bool) {
+	if v, exist := overrides[name]; exist {
+		return v, true
+	}
+	matched := ""
+	for pattern, v := range overrides {
+		if len(pattern) <= len(matched) || !matchLoggerName(pattern, name) {
+			continue
+		}
+		matched, level, ok = pattern, v, true
+	}
+	return
+}
+
+// matchLoggerName checks whether logger <name> matches <pattern>.
+// The pattern "x.*" also matches "x" itself.
+func matchLoggerName(pattern, name string) bool {
+	if pattern == name || pattern == "*" {
+		return true
+	}
+	if strings.HasSuffix(pattern, ".*") && pattern[:len(pattern)-2] == name {
+		return true
+	}
+	// As '/' is not used in logger names, '*' matches any characters including '.'.
+	matched, _ := path.Match(pattern, name)
+	return matched
+}
+
+// parentLoggerName returns the parent name of logger <name>, eg: "db" for "db.query".
+func parentLoggerName(name string) string {
+	if pos := strings.LastIndexByte(name, '.'); pos > 0 {
+		return name[:pos]
+	}
+	return ""
+}
@@ -12,10 +12,10 @@ import (
 	"fmt"
 	"github.com/gogf/gf/container/gtype"
 	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/net/gtrace"
 	"github.com/gogf/gf/os/gfpool"
 	"github.com/gogf/gf/os/gmlock"
 	"github.com/gogf/gf/os/gtimer"
-	"go.opentelemetry.io/otel/trace"
 	"io"
 	"os"
 	"strings"
@@ -31,10 +31,20 @@ import (
 
 // Logger is the struct for logging management.
 type Logger struct {
-	ctx    context.Context // Context for logging.
-	init   *gtype.Bool     // Initialized.
-	parent *Logger         // Parent logger, if it is not empty, it means the logger is used in chaining function.
-	config Config          // Logger configuration.
+	ctx      context.Context // Context for logging.
+	init     *gtype.Bool     // Initialized.
+	parent   *Logger         // Parent logger, if it is not empty, it means the logger is used in chaining function.
+	config   Config          // Logger configuration.
+	fields   []Field         // Structured fields bound by With.
+	name     string          // Instance name, which is not empty if the logger is created by Instance.
+	levelSet bool            // Whether the level is explicitly set, which is used for level inheritance.
+
+	levelOverrides map[string]int // Global level overrides registered by the "levels" configuration.
+	levelCache     *gtype.Uint64  // Cached effective level along with the level version.
+
+	sampling       Handler   // Sampling handler created by the "sampling" configuration.
+	deduper        *deduper  // Deduper created by the "dedupe" configuration.
+	configHandlers []Handler // Handlers created by configuration, which run before Config.Handlers.
 }
 
 const (
@@ -59,8 +69,9 @@ const (
 // New creates and returns a custom logger.
 func New() *Logger {
 	logger := &Logger{
-		init:   gtype.NewBool(),
-		config: DefaultConfig(),
+		init:       gtype.NewBool(),
+		config:     DefaultConfig(),
+		levelCache: gtype.NewUint64(),
 	}
 	return logger
 }
@@ -78,6 +89,14 @@ func (l *Logger) Clone() *Logger {
 	logger := New()
 	logger.ctx = l.ctx
 	logger.config = l.config
+	logger.fields = l.fields
+	logger.name = l.name
+	logger.levelSet = l.levelSet
+	logger.levelOverrides = l.levelOverrides
+	logger.levelCache.Set(l.levelCache.Val())
+	logger.sampling = l.sampling
+	logger.deduper = l.deduper
+	logger.configHandlers = l.configHandlers
 	logger.parent = l
 	return logger
 }
@@ -94,7 +113,9 @@ func (l *Logger) getFilePath(now time.Time) string {
 }
 
 // print prints <s> to defined writer, logging file or passed <std>.
-func (l *Logger) print(ctx context.Context, level int, values ...interface{}) {
+// The parameter <stack> is the caller stack for error logging, which is empty if no stack.
+// The parameter <template> is the format string of the content, which is empty if the content is not formatted.
+func (l *Logger) print(ctx context.Context, level int, stack, template string, values ...interface{}) {
 	// Lazy initialize for rotation feature.
 	// It uses atomic reading operation to enhance the performance checking.
 	// It here uses CAP for performance and concurrent safety.
@@ -104,20 +125,24 @@ func (l *Logger) print(ctx context.Context, level int, values ...interface{}) {
 	}
 	if !p.init.Val() && p.init.Cas(false, true) {
 		// It just initializes once for each logger.
-		if p.config.RotateSize > 0 || p.config.RotateExpire > 0 {
+		if p.config.RotateSize > 0 || p.config.RotateExpire > 0 || p.config.RotateInterval != "" {
 			gtimer.AddOnce(p.config.RotateCheckInterval, p.rotateChecksTimely)
 			intlog.Printf("logger rotation initialized: every %s", p.config.RotateCheckInterval.String())
 		}
+		if p.config.RotateInterval != "" {
+			p.rotateBoundaryTimely()
+		}
 	}
 
 	var (
 		now   = time.Now()
 		input = &HandlerInput{
-			logger: l,
-			index:  -1,
-			Ctx:    ctx,
-			Time:   now,
-			Level:  level,
+			logger:   l,
+			index:    -1,
+			Ctx:      ctx,
+			Time:     now,
+			Level:    level,
+			Template: template,
 		}
 	)
 	if l.config.HeaderPrint {
@@ -166,9 +191,8 @@ func (l *Logger) print(ctx context.Context, level int, values ...interface{}) {
 	// Convert value to string.
 	if ctx != nil {
 		// Tracing values.
-		spanCtx := trace.SpanContextFromContext(ctx)
-		if traceId := spanCtx.TraceID(); traceId.IsValid() {
-			input.CtxStr = "{TraceID:" + traceId.String() + "}"
+		if input.TraceId = gtrace.GetTraceId(ctx); input.TraceId != "" {
+			input.CtxStr = "{TraceID:" + input.TraceId + "}"
 		}
 		// Context values.
 		if len(l.config.CtxKeys) > 0 {
@@ -179,6 +203,7 @@ func (l *Logger) print(ctx context.Context, level int, values ...interface{}) {
 						ctxStr += ", "
 					}
 					ctxStr += fmt.Sprintf("%s: %+v", key, v)
+					input.Fields = append(input.Fields, Field{Key: gconv.String(key), Value: v})
 				}
 			}
 			if ctxStr != "" {
@@ -186,6 +211,16 @@ func (l *Logger) print(ctx context.Context, level int, values ...interface{}) {
 			}
 		}
 	}
+	// Structured fields, which are after the fields from context.
+	input.ctxFieldCount = len(input.Fields)
+	input.Fields = append(input.Fields, l.fields...)
+	// Stack is part of the content for text format, and is a separate field for others.
+	if stack != "" {
+		input.Stack = stack
+		if l.config.Format == "" || l.config.Format == FORMAT_TEXT {
+			values = append(values, "\nStack:\n"+stack)
+		}
+	}
 	var tempStr string
 	for _, v := range values {
 		tempStr = gconv.String(v)
@@ -232,11 +267,52 @@ func (l *Logger) printToWriter(ctx context.Context, input *HandlerInput) {
 			}
 		}
 	} else {
-		if _, err := l.config.Writer.Write(buffer.Bytes()); err != nil {
+		if _, err := l.writeLevel(l.config.Writer, input.Level, buffer.Bytes()); err != nil {
 			// panic(err)
 			intlog.Error(err)
 		}
 	}
+	// Output content to remote sinks.
+	var bareBuffer *bytes.Buffer
+	for _, sink := range l.config.Sinks {
+		content := buffer.Bytes()
+		if s, ok := sink.(bareSink); ok && s.bare() {
+			if bareBuffer == nil {
+				bareBuffer = input.bareBuffer()
+			}
+			content = bareBuffer.Bytes()
+		}
+		if _, err := l.writeLevel(sink, input.Level, content); err != nil {
+			intlog.Error(err)
+		}
+	}
+}
+
+// writeLevel writes <p> to <writer>, which passes <level> if the writer implements LevelWriter.
+func (l *Logger) writeLevel(writer io.Writer, level int, p []byte) (int, error) {
+	if w, ok := writer.(LevelWriter); ok {
+		return w.WriteLevel(level, p)
+	}
+	return writer.Write(p)
+}
+
+// Flush delivers the pending logging contents of all remote sinks.
+func (l *Logger) Flush() error {
+	var err error
+	for _, sink := range l.config.Sinks {
+		if e := sink.Flush(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// Close flushes and closes all remote sinks, which should be called on application shutdown.
+func (l *Logger) Close() error {
+	if l.deduper != nil {
+		l.deduper.close()
+	}
+	return closeSinks(l.config.Sinks)
 }
 
 // printToFile outputs logging content to disk file.
@@ -249,6 +325,9 @@ func (l *Logger) printToFile(t time.Time, buffer *bytes.Buffer) {
 	defer gmlock.Unlock(memoryLockKey)
 
 	// Rotation file size checks.
+	if l.config.RotateInterval != "" {
+		l.rotateFileByTime(t, logFilePath)
+	}
 	if l.config.RotateSize > 0 {
 		if gfile.Size(logFilePath) > l.config.RotateSize {
 			l.rotateFileBySize(t)
@@ -293,18 +372,31 @@ func (l *Logger) getCtx() context.Context {
 
 // printStd prints content <s> without stack.
 func (l *Logger) printStd(level int, value ...interface{}) {
-	l.print(l.getCtx(), level, value...)
+	l.print(l.getCtx(), level, "", "", value...)
+}
+
+// printfStd prints content formatted with <format> without stack.
+func (l *Logger) printfStd(level int, format string, value ...interface{}) {
+	l.print(l.getCtx(), level, "", format, l.format(format, value...))
 }
 
 // printStd prints content <s> with stack check.
 func (l *Logger) printErr(level int, value ...interface{}) {
+	// In matter of sequence, do not use stderr here, but use the same stdout.
+	l.print(l.getCtx(), level, l.getErrStack(), "", value...)
+}
+
+// printfErr prints content formatted with <format> with stack check.
+func (l *Logger) printfErr(level int, format string, value ...interface{}) {
+	l.print(l.getCtx(), level, l.getErrStack(), format, l.format(format, value...))
+}
+
+// getErrStack returns the caller stack for error logging if stack feature is enabled.
+func (l *Logger) getErrStack() string {
 	if l.config.StStatus == 1 {
-		if s := l.GetStack(); s != "" {
-			value = append(value, "\nStack:\n"+s)
-		}
+		return l.GetStack()
 	}
-	// In matter of sequence, do not use stderr here, but use the same stdout.
-	l.print(l.getCtx(), level, value...)
+	return ""
 }
 
 // format formats <values> using fmt.Sprintf.
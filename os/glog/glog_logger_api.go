@@ -20,7 +20,7 @@ func (l *Logger) Print(v ...interface{}) {
 // Printf prints <v> with format <format> using fmt.Sprintf.
 // The parameter <v> can be multiple variables.
 func (l *Logger) Printf(format string, v ...interface{}) {
-	l.printStd(LEVEL_NONE, l.format(format, v...))
+	l.printfStd(LEVEL_NONE, format, v...)
 }
 
 // Println is alias of Print.
@@ -37,7 +37,7 @@ func (l *Logger) Fatal(v ...interface{}) {
 
 // Fatalf prints the logging content with [FATA] header, custom format and newline, then exit the current process.
 func (l *Logger) Fatalf(format string, v ...interface{}) {
-	l.printErr(LEVEL_FATA, l.format(format, v...))
+	l.printfErr(LEVEL_FATA, format, v...)
 	os.Exit(1)
 }
 
@@ -49,7 +49,7 @@ func (l *Logger) Panic(v ...interface{}) {
 
 // Panicf prints the logging content with [PANI] header, custom format and newline, then panics.
 func (l *Logger) Panicf(format string, v ...interface{}) {
-	l.printErr(LEVEL_PANI, l.format(format, v...))
+	l.printfErr(LEVEL_PANI, format, v...)
 	panic(l.format(format, v...))
 }
 
@@ -63,7 +63,7 @@ func (l *Logger) Info(v ...interface{}) {
 // Infof prints the logging content with [INFO] header, custom format and newline.
 func (l *Logger) Infof(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_INFO) {
-		l.printStd(LEVEL_INFO, l.format(format, v...))
+		l.printfStd(LEVEL_INFO, format, v...)
 	}
 }
 
@@ -77,7 +77,7 @@ func (l *Logger) Debug(v ...interface{}) {
 // Debugf prints the logging content with [DEBU] header, custom format and newline.
 func (l *Logger) Debugf(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_DEBU) {
-		l.printStd(LEVEL_DEBU, l.format(format, v...))
+		l.printfStd(LEVEL_DEBU, format, v...)
 	}
 }
 
@@ -93,7 +93,7 @@ func (l *Logger) Notice(v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Noticef(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_NOTI) {
-		l.printStd(LEVEL_NOTI, l.format(format, v...))
+		l.printfStd(LEVEL_NOTI, format, v...)
 	}
 }
 
@@ -109,7 +109,7 @@ func (l *Logger) Warning(v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Warningf(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_WARN) {
-		l.printStd(LEVEL_WARN, l.format(format, v...))
+		l.printfStd(LEVEL_WARN, format, v...)
 	}
 }
 
@@ -125,7 +125,7 @@ func (l *Logger) Error(v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Errorf(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_ERRO) {
-		l.printErr(LEVEL_ERRO, l.format(format, v...))
+		l.printfErr(LEVEL_ERRO, format, v...)
 	}
 }
 
@@ -141,11 +141,11 @@ func (l *Logger) Critical(v ...interface{}) {
 // It also prints caller stack info if stack feature is enabled.
 func (l *Logger) Criticalf(format string, v ...interface{}) {
 	if l.checkLevel(LEVEL_CRIT) {
-		l.printErr(LEVEL_CRIT, l.format(format, v...))
+		l.printfErr(LEVEL_CRIT, format, v...)
 	}
 }
 
 // checkLevel checks whether the given <level> could be output.
 func (l *Logger) checkLevel(level int) bool {
-	return l.config.Level&level > 0
+	return l.GetEffectiveLevel()&level > 0
 }
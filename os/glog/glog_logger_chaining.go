@@ -12,6 +12,7 @@ import (
 	"io"
 
 	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/util/gconv"
 )
 
 // Ctx is a chaining function,
@@ -245,3 +246,27 @@ func (l *Logger) Async(enabled ...bool) *Logger {
 	}
 	return logger
 }
+
+// With is a chaining function,
+// which binds structured key-value fields for the current logging content output.
+// The parameter <keyValues> should be in pairs of key and value, eg: With("uid", 1, "name", "john").
+//
+// Note that it always returns a new logger, so the returned logger can be safely reused for
+// further With calls without affecting each other.
+func (l *Logger) With(keyValues ...interface{}) *Logger {
+	logger := l.Clone()
+	if l.parent != nil {
+		logger.parent = l.parent
+	}
+	fields := make([]Field, len(l.fields), len(l.fields)+(len(keyValues)+1)/2)
+	copy(fields, l.fields)
+	for i := 0; i < len(keyValues); i += 2 {
+		field := Field{Key: gconv.String(keyValues[i])}
+		if i+1 < len(keyValues) {
+			field.Value = keyValues[i+1]
+		}
+		fields = append(fields, field)
+	}
+	logger.fields = fields
+	return logger
+}
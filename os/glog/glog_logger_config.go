@@ -22,26 +22,33 @@ import (
 
 // Config is the configuration object for logger.
 type Config struct {
-	Handlers             []Handler      `json:"-"`                    // Logger handlers which implement feature similar as middleware.
-	Writer               io.Writer      `json:"-"`                    // Customized io.Writer.
-	Flags                int            `json:"flags"`                // Extra flags for logging output features.
-	Path                 string         `json:"path"`                 // Logging directory path.
-	File                 string         `json:"file"`                 // Format for logging file.
-	Level                int            `json:"level"`                // Output level.
-	Prefix               string         `json:"prefix"`               // Prefix string for every logging content.
-	StSkip               int            `json:"stSkip"`               // Skip count for stack.
-	StStatus             int            `json:"stStatus"`             // Stack status(1: enabled - default; 0: disabled)
-	StFilter             string         `json:"stFilter"`             // Stack string filter.
-	CtxKeys              []interface{}  `json:"ctxKeys"`              // Context keys for logging, which is used for value retrieving from context.
-	HeaderPrint          bool           `json:"header"`               // Print header or not(true in default).
-	StdoutPrint          bool           `json:"stdout"`               // Output to stdout or not(true in default).
-	LevelPrefixes        map[int]string `json:"levelPrefixes"`        // Logging level to its prefix string mapping.
-	RotateSize           int64          `json:"rotateSize"`           // Rotate the logging file if its size > 0 in bytes.
-	RotateExpire         time.Duration  `json:"rotateExpire"`         // Rotate the logging file if its mtime exceeds this duration.
-	RotateBackupLimit    int            `json:"rotateBackupLimit"`    // Max backup for rotated files, default is 0, means no backups.
-	RotateBackupExpire   time.Duration  `json:"rotateBackupExpire"`   // Max expire for rotated files, which is 0 in default, means no expiration.
-	RotateBackupCompress int            `json:"rotateBackupCompress"` // Compress level for rotated files using gzip algorithm. It's 0 in default, means no compression.
-	RotateCheckInterval  time.Duration  `json:"rotateCheckInterval"`  // Asynchronizely checks the backups and expiration at intervals. It's 1 hour in default.
+	Handlers             []Handler         `json:"-"`                    // Logger handlers which implement feature similar as middleware.
+	Writer               io.Writer         `json:"-"`                    // Customized io.Writer.
+	Sinks                []Sink            `json:"-"`                    // Remote sinks, which receive logging contents along with other outputs.
+	Flags                int               `json:"flags"`                // Extra flags for logging output features.
+	Format               string            `json:"format"`               // Output format: text(default), json, logfmt.
+	Path                 string            `json:"path"`                 // Logging directory path.
+	File                 string            `json:"file"`                 // Format for logging file.
+	Level                int               `json:"level"`                // Output level.
+	Prefix               string            `json:"prefix"`               // Prefix string for every logging content.
+	StSkip               int               `json:"stSkip"`               // Skip count for stack.
+	StStatus             int               `json:"stStatus"`             // Stack status(1: enabled - default; 0: disabled)
+	StFilter             string            `json:"stFilter"`             // Stack string filter.
+	CtxKeys              []interface{}     `json:"ctxKeys"`              // Context keys for logging, which is used for value retrieving from context.
+	HeaderPrint          bool              `json:"header"`               // Print header or not(true in default).
+	StdoutPrint          bool              `json:"stdout"`               // Output to stdout or not(true in default).
+	LevelPrefixes        map[int]string    `json:"levelPrefixes"`        // Logging level to its prefix string mapping.
+	Levels               map[string]string `json:"levels"`               // Global level overrides for named loggers, eg: {"db.*": "DEBUG"}.
+	RotateSize           int64             `json:"rotateSize"`           // Rotate the logging file if its size > 0 in bytes.
+	RotateExpire         time.Duration     `json:"rotateExpire"`         // Rotate the logging file if its mtime exceeds this duration.
+	RotateInterval       string            `json:"rotateInterval"`       // Rotate the logging file at calendar boundaries: hourly, daily. It's empty in default, means no time rotation.
+	RotatePattern        string            `json:"rotatePattern"`        // Time pattern in rotated file name for RotateInterval, eg: {Y-m-d}. It's {Y-m-d-H} for hourly and {Y-m-d} for daily in default.
+	RotateBackupLimit    int               `json:"rotateBackupLimit"`    // Max backup for rotated files, default is 0, means no backups if no other backup limitation set.
+	RotateBackupExpire   time.Duration     `json:"rotateBackupExpire"`   // Max expire for rotated files, which is 0 in default, means no expiration.
+	RotateBackupMaxSize  int64             `json:"rotateBackupMaxSize"`  // Max total size in bytes of rotated files for each logging file, which is 0 in default, means no limitation.
+	RotateBackupCompress int               `json:"rotateBackupCompress"` // Compress level for rotated files. It's 0 in default, means no compression.
+	RotateCompressType   string            `json:"rotateCompressType"`   // Compression algorithm for rotated files: gzip(default), zstd.
+	RotateCheckInterval  time.Duration     `json:"rotateCheckInterval"`  // Asynchronizely checks the backups and expiration at intervals. It's 1 hour in default.
 }
 
 // DefaultConfig returns the default configuration for logger.
@@ -49,6 +56,7 @@ func DefaultConfig() Config {
 	c := Config{
 		File:                defaultFileFormat,
 		Flags:               F_TIME_STD,
+		Format:              FORMAT_TEXT,
 		Level:               LEVEL_ALL,
 		StStatus:            1,
 		HeaderPrint:         true,
@@ -66,7 +74,17 @@ func DefaultConfig() Config {
 }
 
 // SetConfig set configurations for the logger.
+// Note that the level of <config> is treated as explicitly set, which stops the named logger
+// inheriting the level from its ancestors.
 func (l *Logger) SetConfig(config Config) error {
+	l.levelSet = true
+	return l.setConfig(config)
+}
+
+// setConfig set configurations for the logger without changing the level set status.
+func (l *Logger) setConfig(config Config) error {
+	// The configuration may change the effective levels.
+	defer levelVersion.Add(1)
 	l.config = config
 	// Necessary validation.
 	if config.Path != "" {
@@ -75,12 +93,36 @@ func (l *Logger) SetConfig(config Config) error {
 			return err
 		}
 	}
+	switch config.RotateInterval {
+	case "", ROTATE_HOURLY, ROTATE_DAILY:
+	default:
+		return errors.New(fmt.Sprintf(`invalid rotate interval: %s`, config.RotateInterval))
+	}
+	switch config.RotateCompressType {
+	case "", COMPRESS_GZIP, COMPRESS_ZSTD:
+	default:
+		return errors.New(fmt.Sprintf(`invalid rotate compress type: %s`, config.RotateCompressType))
+	}
+	if len(config.Levels) > 0 || len(l.levelOverrides) > 0 {
+		overrides, err := parseLevelOverrides(config.Levels)
+		if err != nil {
+			intlog.Error(err)
+			return err
+		}
+		l.registerLevelOverrides(overrides)
+	}
+	if config.Format != "" {
+		if err := l.SetFormat(strings.ToLower(config.Format)); err != nil {
+			intlog.Error(err)
+			return err
+		}
+	}
 	intlog.Printf("SetConfig: %+v", l.config)
 	return nil
 }
 
 // SetConfigWithMap set configurations with map for the logger.
-func (l *Logger) SetConfigWithMap(m map[string]interface{}) error {
+func (l *Logger) SetConfigWithMap(m map[string]interface{}) (err error) {
 	if m == nil || len(m) == 0 {
 		return errors.New("configuration cannot be empty")
 	}
@@ -92,6 +134,7 @@ func (l *Logger) SetConfigWithMap(m map[string]interface{}) error {
 	if levelValue != nil {
 		if level, ok := levelStringMap[strings.ToUpper(gconv.String(levelValue))]; ok {
 			m[levelKey] = level
+			l.levelSet = true
 		} else {
 			return errors.New(fmt.Sprintf(`invalid level string: %v`, levelValue))
 		}
@@ -104,11 +147,97 @@ func (l *Logger) SetConfigWithMap(m map[string]interface{}) error {
 			return errors.New(fmt.Sprintf(`invalid rotate size: %v`, rotateSizeValue))
 		}
 	}
-	err := gconv.Struct(m, &l.config)
-	if err != nil {
+	// Create sinks from configuration maps.
+	var (
+		sinks                []Sink
+		sinksKey, sinksValue = gutil.MapPossibleItemByKey(m, "Sinks")
+	)
+	if sinksValue != nil {
+		sinks = make([]Sink, 0)
+		// The created sinks are closed if the configuration fails.
+		defer func() {
+			if err != nil {
+				closeSinks(sinks)
+			}
+		}()
+		for _, item := range gconv.Maps(sinksValue) {
+			sink, err := NewSinkWithMap(item)
+			if err != nil {
+				return err
+			}
+			sinks = append(sinks, sink)
+		}
+		delete(m, sinksKey)
+	}
+	// Create sampling and dedupe handlers, which replace the ones created by previous configuration.
+	var (
+		sampling                   = l.sampling
+		deduper                    = l.deduper
+		samplingKey, samplingValue = gutil.MapPossibleItemByKey(m, "Sampling")
+		dedupeKey, dedupeValue     = gutil.MapPossibleItemByKey(m, "Dedupe")
+	)
+	if samplingValue != nil {
+		var options SamplingOptions
+		if err := gconv.Struct(samplingValue, &options); err != nil {
+			return err
+		}
+		delete(m, samplingKey)
+		sampling = NewSamplingHandler(options)
+	}
+	if dedupeValue != nil {
+		var options DedupeOptions
+		if dedupeMap := gconv.Map(dedupeValue); dedupeMap != nil {
+			if err := gconv.Struct(dedupeMap, &options); err != nil {
+				return err
+			}
+			deduper = newDeduper(options)
+		} else if gconv.Bool(dedupeValue) {
+			deduper = newDeduper(options)
+		} else {
+			deduper = nil
+		}
+		delete(m, dedupeKey)
+	}
+	// Change string configuration to int value for backup max size.
+	backupMaxSizeKey, backupMaxSizeValue := gutil.MapPossibleItemByKey(m, "RotateBackupMaxSize")
+	if backupMaxSizeValue != nil {
+		m[backupMaxSizeKey] = gfile.StrToSize(gconv.String(backupMaxSizeValue))
+		if m[backupMaxSizeKey] == -1 {
+			return errors.New(fmt.Sprintf(`invalid rotate backup max size: %v`, backupMaxSizeValue))
+		}
+	}
+	if err = gconv.Struct(m, &l.config); err != nil {
 		return err
 	}
-	return l.SetConfig(l.config)
+	// The sinks created by previous configuration are closed after replaced.
+	if sinks != nil {
+		oldSinks := l.config.Sinks
+		l.config.Sinks = sinks
+		sinks = nil
+		if closeErr := closeSinks(oldSinks); closeErr != nil {
+			intlog.Error(closeErr)
+		}
+	}
+	l.setConfigHandlers(sampling, deduper)
+	return l.setConfig(l.config)
+}
+
+// setConfigHandlers sets the sampling and dedupe handlers created by configuration,
+// which stops the timer of the replaced deduper.
+func (l *Logger) setConfigHandlers(sampling Handler, deduper *deduper) {
+	if l.deduper != nil && l.deduper != deduper {
+		l.deduper.close()
+	}
+	handlers := make([]Handler, 0, 2)
+	if sampling != nil {
+		handlers = append(handlers, sampling)
+	}
+	if deduper != nil {
+		handlers = append(handlers, deduper.handler)
+	}
+	l.sampling = sampling
+	l.deduper = deduper
+	l.configHandlers = handlers
 }
 
 // SetDebug enables/disables the debug level for logger.
@@ -119,6 +248,7 @@ func (l *Logger) SetDebug(debug bool) {
 	} else {
 		l.config.Level = l.config.Level & ^LEVEL_DEBU
 	}
+	levelVersion.Add(1)
 }
 
 // SetAsync enables/disables async logging output feature.
@@ -140,6 +270,22 @@ func (l *Logger) GetFlags() int {
 	return l.config.Flags
 }
 
+// SetFormat sets the output format for logger, which can be FORMAT_TEXT, FORMAT_JSON or FORMAT_LOGFMT.
+// It returns error if <format> is not supported.
+func (l *Logger) SetFormat(format string) error {
+	switch format {
+	case FORMAT_TEXT, FORMAT_JSON, FORMAT_LOGFMT:
+		l.config.Format = format
+		return nil
+	}
+	return errors.New(fmt.Sprintf(`invalid format: %s`, format))
+}
+
+// GetFormat returns the output format of logger.
+func (l *Logger) GetFormat() string {
+	return l.config.Format
+}
+
 // SetStack enables/disables the stack feature in failure logging outputs.
 func (l *Logger) SetStack(enabled bool) {
 	if enabled {
@@ -198,6 +344,25 @@ func (l *Logger) SetWriter(writer io.Writer) {
 	l.config.Writer = writer
 }
 
+// SetSinks sets the remote sinks for logging, which receive logging contents along with
+// the writer, file and stdout outputs.
+//
+// Note that multiple calls of this function will overwrite the previous set sinks,
+// and the previous sinks are not closed.
+func (l *Logger) SetSinks(sinks ...Sink) {
+	l.config.Sinks = sinks
+}
+
+// AddSink adds remote sink for logging.
+func (l *Logger) AddSink(sink Sink) {
+	l.config.Sinks = append(l.config.Sinks, sink)
+}
+
+// GetSinks returns the remote sinks of logger.
+func (l *Logger) GetSinks() []Sink {
+	return l.config.Sinks
+}
+
 // GetWriter returns the customized writer object, which implements the io.Writer interface.
 // It returns nil if no writer previously set.
 func (l *Logger) GetWriter() io.Writer {
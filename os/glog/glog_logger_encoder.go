@@ -0,0 +1,190 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/internal/json"
+	"github.com/gogf/gf/util/gconv"
+)
+
+// Output formats of logger.
+const (
+	FORMAT_TEXT   = "text"   // Human readable text, which is the default format.
+	FORMAT_JSON   = "json"   // One json object in a line.
+	FORMAT_LOGFMT = "logfmt" // Key-value pairs in a line, eg: level=info msg=hello uid=1.
+)
+
+const (
+	// jsonTimeFormat is the time format for structured output, which is RFC3339 with milliseconds.
+	jsonTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// Field is a key-value pair for structured logging.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// levelNames defines the level to its name mapping for structured output.
+var levelNames = map[int]string{
+	LEVEL_DEBU: "debug",
+	LEVEL_INFO: "info",
+	LEVEL_NOTI: "notice",
+	LEVEL_WARN: "warning",
+	LEVEL_ERRO: "error",
+	LEVEL_CRIT: "critical",
+	LEVEL_PANI: "panic",
+	LEVEL_FATA: "fatal",
+}
+
+// bufferJson encodes the logging content as a json object in one line:
+// {"time":"...","level":"info","caller":"...","trace_id":"...","msg":"...","fields":{...},"stack":"..."}
+func (i *HandlerInput) bufferJson() *bytes.Buffer {
+	buffer := bytes.NewBuffer(nil)
+	buffer.WriteByte('{')
+	i.addJsonToBuffer(buffer, "time", i.Time.Format(jsonTimeFormat))
+	if name := i.levelName(); name != "" {
+		i.addJsonToBuffer(buffer, "level", name)
+	}
+	if caller := i.caller(); caller != "" {
+		i.addJsonToBuffer(buffer, "caller", caller)
+	}
+	if i.CallerFunc != "" {
+		i.addJsonToBuffer(buffer, "func", strings.Trim(i.CallerFunc, "[]"))
+	}
+	if i.TraceId != "" {
+		i.addJsonToBuffer(buffer, "trace_id", i.TraceId)
+	}
+	if i.Prefix != "" {
+		i.addJsonToBuffer(buffer, "prefix", i.Prefix)
+	}
+	i.addJsonToBuffer(buffer, "msg", strings.TrimRight(i.Content, "\n"))
+	if len(i.Fields) > 0 {
+		fields := bytes.NewBuffer(nil)
+		fields.WriteByte('{')
+		for _, field := range i.Fields {
+			i.addJsonToBuffer(fields, field.Key, field.Value)
+		}
+		fields.WriteByte('}')
+		buffer.WriteString(`,"fields":`)
+		buffer.Write(fields.Bytes())
+	}
+	if i.Stack != "" {
+		i.addJsonToBuffer(buffer, "stack", i.Stack)
+	}
+	buffer.WriteString("}\n")
+	return buffer
+}
+
+// bufferLogfmt encodes the logging content as key-value pairs in one line:
+// time=... level=info caller=... trace_id=... msg=... uid=1 stack=...
+func (i *HandlerInput) bufferLogfmt() *bytes.Buffer {
+	buffer := bytes.NewBuffer(nil)
+	i.addLogfmtToBuffer(buffer, "time", i.Time.Format(jsonTimeFormat))
+	if name := i.levelName(); name != "" {
+		i.addLogfmtToBuffer(buffer, "level", name)
+	}
+	if caller := i.caller(); caller != "" {
+		i.addLogfmtToBuffer(buffer, "caller", caller)
+	}
+	if i.CallerFunc != "" {
+		i.addLogfmtToBuffer(buffer, "func", strings.Trim(i.CallerFunc, "[]"))
+	}
+	if i.TraceId != "" {
+		i.addLogfmtToBuffer(buffer, "trace_id", i.TraceId)
+	}
+	if i.Prefix != "" {
+		i.addLogfmtToBuffer(buffer, "prefix", i.Prefix)
+	}
+	i.addLogfmtToBuffer(buffer, "msg", strings.TrimRight(i.Content, "\n"))
+	for _, field := range i.Fields {
+		i.addLogfmtToBuffer(buffer, field.Key, field.Value)
+	}
+	if i.Stack != "" {
+		i.addLogfmtToBuffer(buffer, "stack", i.Stack)
+	}
+	buffer.WriteByte('\n')
+	return buffer
+}
+
+// logfmtFields returns the logfmt string of <fields>, which is used by text format.
+func (i *HandlerInput) logfmtFields(fields []Field) string {
+	buffer := bytes.NewBuffer(nil)
+	for _, field := range fields {
+		i.addLogfmtToBuffer(buffer, field.Key, field.Value)
+	}
+	return buffer.String()
+}
+
+// addJsonToBuffer writes json key-value pair to <buffer>, which should be a json object under writing.
+func (i *HandlerInput) addJsonToBuffer(buffer *bytes.Buffer, key string, value interface{}) {
+	if buffer.Len() > 1 {
+		buffer.WriteByte(',')
+	}
+	b, _ := json.Marshal(key)
+	buffer.Write(b)
+	buffer.WriteByte(':')
+	buffer.Write(i.jsonValue(value))
+}
+
+// addLogfmtToBuffer writes logfmt key-value pair to <buffer>.
+func (i *HandlerInput) addLogfmtToBuffer(buffer *bytes.Buffer, key string, value interface{}) {
+	if buffer.Len() > 0 {
+		buffer.WriteByte(' ')
+	}
+	buffer.WriteString(i.logfmtString(key))
+	buffer.WriteByte('=')
+	if value == nil {
+		return
+	}
+	if err, ok := value.(error); ok {
+		buffer.WriteString(i.logfmtString(err.Error()))
+		return
+	}
+	buffer.WriteString(i.logfmtString(gconv.String(value)))
+}
+
+// jsonValue returns the json bytes of <value>.
+// The error is encoded as its message, and the value that cannot be encoded as json
+// is encoded as its string.
+func (i *HandlerInput) jsonValue(value interface{}) []byte {
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		b, _ = json.Marshal(gconv.String(value))
+	}
+	return b
+}
+
+// logfmtString quotes <s> if it contains space, quote, equal sign or control characters, or it is empty.
+func (i *HandlerInput) logfmtString(s string) string {
+	if s == "" {
+		return `""`
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '"' || r == '=' || r == '\\' || r == 0x7f {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}
+
+// levelName returns the level name for structured output.
+func (i *HandlerInput) levelName() string {
+	return levelNames[i.Level]
+}
+
+// caller returns the caller path with line number for structured output.
+func (i *HandlerInput) caller() string {
+	return strings.TrimSuffix(i.CallerPath, ":")
+}
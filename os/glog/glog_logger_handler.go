@@ -15,19 +15,24 @@ import (
 type Handler func(ctx context.Context, input *HandlerInput)
 
 type HandlerInput struct {
-	logger      *Logger
-	index       int
-	Ctx         context.Context
-	Time        time.Time
-	TimeFormat  string
-	Level       int
-	LevelFormat string
-	CallerFunc  string
-	CallerPath  string
-	CtxStr      string
-	Prefix      string
-	Content     string
-	IsAsync     bool
+	logger        *Logger
+	index         int
+	ctxFieldCount int // Count of fields retrieved from context, which are at the front of Fields.
+	Ctx           context.Context
+	Time          time.Time
+	TimeFormat    string
+	Level         int
+	LevelFormat   string
+	CallerFunc    string
+	CallerPath    string
+	CtxStr        string
+	TraceId       string
+	Prefix        string
+	Content       string
+	Template      string  // Format string of the content, which is empty if the content is not formatted.
+	Fields        []Field // Structured fields from context keys and bound by With.
+	Stack         string  // Caller stack for error logging, which is also part of Content for text format.
+	IsAsync       bool
 }
 
 // defaultHandler is the default handler for logger.
@@ -42,11 +47,37 @@ func (i *HandlerInput) addStringToBuffer(buffer *bytes.Buffer, s string) {
 	buffer.WriteString(s)
 }
 
+// Buffer returns the logging content encoded in the format of the logger,
+// which is text in default.
 func (i *HandlerInput) Buffer() *bytes.Buffer {
+	switch i.logger.config.Format {
+	case FORMAT_JSON:
+		return i.bufferJson()
+	case FORMAT_LOGFMT:
+		return i.bufferLogfmt()
+	}
+	return i.bufferText(true)
+}
+
+// bareBuffer returns the logging content like Buffer, but without the time and level header
+// for text format, which is used by the sinks having their own time and level header.
+func (i *HandlerInput) bareBuffer() *bytes.Buffer {
+	switch i.logger.config.Format {
+	case FORMAT_JSON, FORMAT_LOGFMT:
+		return i.Buffer()
+	}
+	return i.bufferText(false)
+}
+
+// bufferText returns the logging content in text format, which contains the time and level
+// header if <header> is true.
+func (i *HandlerInput) bufferText(header bool) *bytes.Buffer {
 	buffer := bytes.NewBuffer(nil)
-	buffer.WriteString(i.TimeFormat)
-	if i.LevelFormat != "" {
-		i.addStringToBuffer(buffer, i.LevelFormat)
+	if header {
+		buffer.WriteString(i.TimeFormat)
+		if i.LevelFormat != "" {
+			i.addStringToBuffer(buffer, i.LevelFormat)
+		}
 	}
 	if i.CallerFunc != "" {
 		i.addStringToBuffer(buffer, i.CallerFunc)
@@ -60,6 +91,9 @@ func (i *HandlerInput) Buffer() *bytes.Buffer {
 	if i.CtxStr != "" {
 		i.addStringToBuffer(buffer, i.CtxStr)
 	}
+	if len(i.Fields) > i.ctxFieldCount {
+		i.addStringToBuffer(buffer, i.logfmtFields(i.Fields[i.ctxFieldCount:]))
+	}
 	if i.Content != "" {
 		i.addStringToBuffer(buffer, i.Content)
 	}
@@ -72,9 +106,15 @@ func (i *HandlerInput) String() string {
 }
 
 func (i *HandlerInput) Next() {
-	if len(i.logger.config.Handlers)-1 > i.index {
+	// The handlers created by configuration are in front of the handlers of Config.Handlers.
+	configHandlers := i.logger.configHandlers
+	if len(configHandlers)+len(i.logger.config.Handlers)-1 > i.index {
 		i.index++
-		i.logger.config.Handlers[i.index](i.Ctx, i)
+		if i.index < len(configHandlers) {
+			configHandlers[i.index](i.Ctx, i)
+		} else {
+			i.logger.config.Handlers[i.index-len(configHandlers)](i.Ctx, i)
+		}
 	} else {
 		// The last handler is the default handler.
 		defaultHandler(i.Ctx, i)
@@ -0,0 +1,131 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/os/gtimer"
+)
+
+// DedupeOptions is the options for dedupe handler.
+type DedupeOptions struct {
+	Interval time.Duration `json:"interval"` // Dedupe interval, which is 1 second in default.
+}
+
+const (
+	defaultDedupeInterval = time.Second
+)
+
+// deduper is the state of dedupe handler.
+type deduper struct {
+	mu       sync.Mutex
+	interval time.Duration
+	entries  map[string]*dedupeEntry // Logging contents output in current interval.
+	timer    *gtimer.Entry           // Timer flushing the summaries, which runs only if there're contents.
+	closed   bool                    // Whether the deduper is closed, which does not dedupe anymore.
+}
+
+// dedupeEntry is a logging content and its repeated times in current interval.
+type dedupeEntry struct {
+	count int           // Repeated times.
+	last  *HandlerInput // Last repeated logging input.
+}
+
+// NewDedupeHandler creates and returns a handler dropping the repeated logging contents, which
+// have the same level and content, in every interval. It outputs the first content, and outputs
+// a "message repeated X times" summary for the dropped ones at the end of the interval.
+//
+// Note that the summary is output along with the header of the last repeated content.
+// The interval timer starts along with the logging output, and stops if there's no logging
+// content in an interval, so the handler which is no longer used does not keep a timer running.
+func NewDedupeHandler(options DedupeOptions) Handler {
+	return newDeduper(options).handler
+}
+
+// newDeduper creates and returns a deduper.
+func newDeduper(options DedupeOptions) *deduper {
+	if options.Interval <= 0 {
+		options.Interval = defaultDedupeInterval
+	}
+	return &deduper{
+		interval: options.Interval,
+		entries:  make(map[string]*dedupeEntry),
+	}
+}
+
+// handler is the Handler of the deduper.
+func (d *deduper) handler(ctx context.Context, input *HandlerInput) {
+	if d.allow(input) {
+		input.Next()
+	}
+}
+
+// allow checks whether <input> is not repeated in current interval, and counts it if repeated.
+func (d *deduper) allow(input *HandlerInput) bool {
+	key := strconv.Itoa(input.Level) + ":" + input.Content
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return true
+	}
+	if entry, ok := d.entries[key]; ok {
+		entry.count++
+		entry.last = input
+		return false
+	}
+	d.entries[key] = &dedupeEntry{}
+	if d.timer == nil {
+		d.timer = gtimer.AddSingleton(d.interval, d.flush)
+	}
+	return true
+}
+
+// flush outputs the summaries of repeated contents, and starts a new interval.
+// It stops the timer if there's no logging content in the ending interval.
+func (d *deduper) flush() {
+	d.mu.Lock()
+	entries := d.entries
+	d.entries = make(map[string]*dedupeEntry)
+	if len(entries) == 0 && d.timer != nil {
+		d.timer.Close()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+	d.output(entries)
+}
+
+// close stops the timer and outputs the summaries of current interval.
+func (d *deduper) close() {
+	d.mu.Lock()
+	entries := d.entries
+	d.entries = make(map[string]*dedupeEntry)
+	d.closed = true
+	if d.timer != nil {
+		d.timer.Close()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+	d.output(entries)
+}
+
+// output outputs the summaries of repeated contents in <entries>.
+func (d *deduper) output(entries map[string]*dedupeEntry) {
+	for _, entry := range entries {
+		if entry.count == 0 {
+			continue
+		}
+		// It continues the handler chain from the dedupe handler using a copy of the last input.
+		summary := *entry.last
+		summary.Content = fmt.Sprintf(`message repeated %d times: %s`, entry.count, entry.last.Content)
+		summary.Next()
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SamplingOptions is the options for sampling handler.
+type SamplingOptions struct {
+	Interval   time.Duration `json:"interval"`   // Sampling interval, which is 1 second in default.
+	First      int           `json:"first"`      // Count of logging contents output for each key in every interval, which is 100 in default.
+	Thereafter int           `json:"thereafter"` // Output 1 of every Thereafter contents after First in the interval, 0 means dropping all.
+	Levels     int           `json:"levels"`     // Levels to be sampled, which is all levels in default.
+}
+
+const (
+	defaultSamplingInterval = time.Second
+	defaultSamplingFirst    = 100
+)
+
+// sampler is the counter of sampling handler.
+type sampler struct {
+	mu          sync.Mutex
+	options     SamplingOptions
+	counts      map[string]int // Count of logging contents for each key in current interval.
+	windowStart time.Time      // Start time of current interval.
+}
+
+// NewSamplingHandler creates and returns a handler sampling the logging contents, which outputs
+// the first <First> contents and then 1 of every <Thereafter> contents for each key in every
+// interval, and drops the others. The key is the level along with the format string for
+// formatted contents, eg: Errorf("query %s failed", table), or the content itself.
+//
+// It should be the first handler in Config.Handlers, so that the dropped contents do not
+// pass through the following handlers.
+func NewSamplingHandler(options SamplingOptions) Handler {
+	if options.Interval <= 0 {
+		options.Interval = defaultSamplingInterval
+	}
+	if options.First <= 0 {
+		options.First = defaultSamplingFirst
+	}
+	if options.Levels == 0 {
+		options.Levels = LEVEL_ALL | LEVEL_PANI | LEVEL_FATA
+	}
+	s := &sampler{
+		options: options,
+		counts:  make(map[string]int),
+	}
+	return func(ctx context.Context, input *HandlerInput) {
+		if s.allow(input) {
+			input.Next()
+		}
+	}
+}
+
+// allow checks and counts whether <input> can be output.
+func (s *sampler) allow(input *HandlerInput) bool {
+	// The contents without level, which are printed by Print*, are not sampled.
+	if input.Level&s.options.Levels == 0 {
+		return true
+	}
+	key := input.Template
+	if key == "" {
+		key = input.Content
+	}
+	key = strconv.Itoa(input.Level) + ":" + key
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now := time.Now(); now.Sub(s.windowStart) >= s.options.Interval {
+		s.counts = make(map[string]int)
+		s.windowStart = now
+	}
+	s.counts[key]++
+	n := s.counts[key]
+	if n <= s.options.First {
+		return true
+	}
+	return s.options.Thereafter > 0 && (n-s.options.First)%s.options.Thereafter == 0
+}
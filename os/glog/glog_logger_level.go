@@ -59,11 +59,14 @@ var levelStringMap = map[string]int{
 	"ERROR":    LEVEL_ERRO | LEVEL_CRIT,
 	"CRIT":     LEVEL_CRIT,
 	"CRITICAL": LEVEL_CRIT,
+	"NONE":     LEVEL_NONE,
 }
 
 // SetLevel sets the logging level.
 func (l *Logger) SetLevel(level int) {
 	l.config.Level = level
+	l.levelSet = true
+	levelVersion.Add(1)
 }
 
 // GetLevel returns the logging level value.
@@ -74,7 +77,7 @@ func (l *Logger) GetLevel() int {
 // SetLevelStr sets the logging level by level string.
 func (l *Logger) SetLevelStr(levelStr string) error {
 	if level, ok := levelStringMap[strings.ToUpper(levelStr)]; ok {
-		l.config.Level = level
+		l.SetLevel(level)
 	} else {
 		return errors.New(fmt.Sprintf(`invalid level string: %s`, levelStr))
 	}
@@ -8,15 +8,77 @@ package glog
 
 import (
 	"fmt"
-	"github.com/gogf/gf/container/garray"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/gogf/gf/encoding/gcompress"
 	"github.com/gogf/gf/internal/intlog"
 	"github.com/gogf/gf/os/gfile"
 	"github.com/gogf/gf/os/gmlock"
 	"github.com/gogf/gf/os/gtime"
 	"github.com/gogf/gf/os/gtimer"
-	"github.com/gogf/gf/text/gregex"
-	"time"
+)
+
+// Rotation intervals for Config.RotateInterval.
+const (
+	ROTATE_HOURLY = "hourly" // Rotate the logging file at the beginning of every hour.
+	ROTATE_DAILY  = "daily"  // Rotate the logging file at the beginning of every day.
+)
+
+// Compression algorithms for Config.RotateCompressType.
+const (
+	COMPRESS_GZIP = "gzip"
+	COMPRESS_ZSTD = "zstd"
+)
+
+const (
+	// rotateLockFileName is the lock file name in logging directory, which is locked
+	// across processes to make sure only one process rotates the logging files.
+	rotateLockFileName = ".glog.rotate.lock"
+	// rotateBoundaryDelay is the delay after calendar boundary for rotation timer,
+	// which makes sure the rotation happens in the new period.
+	rotateBoundaryDelay = 10 * time.Millisecond
+)
+
+var (
+	// rotatePatternFormatRegex is the regular expression of each gtime format character,
+	// which is used for matching the time stamp in rotated file names.
+	rotatePatternFormatRegex = map[rune]string{
+		'd': `\d{2}`,
+		'D': `[A-Za-z]{3}`,
+		'w': `\d`,
+		'N': `\d`,
+		'j': `\d{1,2}`,
+		'S': `(?:st|nd|rd|th)`,
+		'l': `[A-Za-z]+`,
+		'z': `\d{1,3}`,
+		'W': `\d{1,2}`,
+		'F': `[A-Za-z]+`,
+		'm': `\d{2}`,
+		'M': `[A-Za-z]{3}`,
+		'n': `\d{1,2}`,
+		't': `\d{2}`,
+		'Y': `\d{4}`,
+		'y': `\d{2}`,
+		'a': `(?:am|pm)`,
+		'A': `(?:AM|PM)`,
+		'g': `\d{1,2}`,
+		'G': `\d{1,2}`,
+		'h': `\d{2}`,
+		'H': `\d{2}`,
+		'i': `\d{2}`,
+		's': `\d{2}`,
+		'u': `\d{3}`,
+		'U': `\d+`,
+		'O': `[+-]\d{4}`,
+		'P': `[+-]\d{2}:\d{2}`,
+		'T': `[A-Za-z0-9+-]+`,
+		'c': `\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:[+-]\d{2}:\d{2}|Z)`,
+		'r': `[A-Za-z]{3}, \d{2} [A-Za-z]{3} \d{2} \d{2}:\d{2} [A-Za-z0-9+-]+`,
+	}
 )
 
 // rotateFileBySize rotates the current logging file according to the
@@ -25,26 +87,201 @@ func (l *Logger) rotateFileBySize(now time.Time) {
 	if l.config.RotateSize <= 0 {
 		return
 	}
-	if err := l.doRotateFile(l.getFilePath(now)); err != nil {
+	filePath := l.getFilePath(now)
+	err := l.doRotateFile(filePath, "", func() bool {
+		// It might be rotated by another process.
+		return gfile.Size(filePath) > l.config.RotateSize
+	})
+	if err != nil {
 		// panic(err)
 		intlog.Error(err)
 	}
 }
 
+// rotateFileByTime rotates logging file <filePath> if it was written in the previous
+// rotation period of <now>. The rotated file is named with the time of its period,
+// eg: access.log -> access.2020-03-26.log.
+func (l *Logger) rotateFileByTime(now time.Time, filePath string) {
+	if !gfile.Exists(filePath) {
+		return
+	}
+	var (
+		periodStart = l.rotatePeriodStart(now)
+		mtime       = gfile.MTime(filePath)
+	)
+	if !mtime.Before(periodStart) {
+		return
+	}
+	stamp := l.formatRotatePattern(l.rotatePeriodStart(mtime))
+	err := l.doRotateFile(filePath, stamp, func() bool {
+		// It might be rotated by another process.
+		return gfile.MTime(filePath).Before(periodStart)
+	})
+	if err != nil {
+		intlog.Error(err)
+	}
+}
+
+// rotateBoundaryTimely rotates the current logging file at every calendar boundary,
+// so that the idle logging file is also rotated on time.
+func (l *Logger) rotateBoundaryTimely() {
+	// The timer stops if the rotation interval is not configured any more.
+	if l.config.RotateInterval == "" {
+		return
+	}
+	var (
+		now           = time.Now()
+		filePath      = l.getFilePath(now)
+		memoryLockKey = "glog.printToFile:" + filePath
+	)
+	gmlock.Lock(memoryLockKey)
+	l.rotateFileByTime(now, filePath)
+	gmlock.Unlock(memoryLockKey)
+	next := l.rotateNextBoundary(now).Add(rotateBoundaryDelay)
+	gtimer.AddOnce(next.Sub(now), l.rotateBoundaryTimely)
+}
+
+// rotatePeriodStart returns the start time of the rotation period containing <t>.
+func (l *Logger) rotatePeriodStart(t time.Time) time.Time {
+	switch l.config.RotateInterval {
+	case ROTATE_HOURLY:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+// rotateNextBoundary returns the start time of the next rotation period of <t>.
+func (l *Logger) rotateNextBoundary(t time.Time) time.Time {
+	start := l.rotatePeriodStart(t)
+	switch l.config.RotateInterval {
+	case ROTATE_HOURLY:
+		return start.Add(time.Hour)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// getRotatePattern returns the time pattern in rotated file name for time rotation.
+func (l *Logger) getRotatePattern() string {
+	if l.config.RotatePattern != "" {
+		return l.config.RotatePattern
+	}
+	if l.config.RotateInterval == ROTATE_HOURLY {
+		return "{Y-m-d-H}"
+	}
+	return "{Y-m-d}"
+}
+
+// formatRotatePattern formats the rotate pattern with time <t>.
+func (l *Logger) formatRotatePattern(t time.Time) string {
+	return formatTimePattern(l.getRotatePattern(), t)
+}
+
+// formatTimePattern formats the content containing "{}" in <pattern> using gtime.
+func formatTimePattern(pattern string, t time.Time) string {
+	var (
+		result = strings.Builder{}
+		gt     = gtime.New(t)
+	)
+	for {
+		start := strings.IndexByte(pattern, '{')
+		end := strings.IndexByte(pattern, '}')
+		if start == -1 || end < start {
+			result.WriteString(pattern)
+			return result.String()
+		}
+		result.WriteString(pattern[:start])
+		result.WriteString(gt.Format(pattern[start+1 : end]))
+		pattern = pattern[end+1:]
+	}
+}
+
+// rotatePatternRegex returns the regular expression matching the time stamp formatted
+// by the rotate pattern, in which every format character matches exactly its output.
+func (l *Logger) rotatePatternRegex() string {
+	var (
+		pattern = l.getRotatePattern()
+		result  = strings.Builder{}
+	)
+	for {
+		start := strings.IndexByte(pattern, '{')
+		end := strings.IndexByte(pattern, '}')
+		if start == -1 || end < start {
+			result.WriteString(regexp.QuoteMeta(pattern))
+			return result.String()
+		}
+		result.WriteString(regexp.QuoteMeta(pattern[:start]))
+		format := []rune(pattern[start+1 : end])
+		for i := 0; i < len(format); i++ {
+			if format[i] == '\\' && i < len(format)-1 {
+				// Escaped character is output as it is.
+				i++
+				result.WriteString(regexp.QuoteMeta(string(format[i])))
+			} else if v, ok := rotatePatternFormatRegex[format[i]]; ok {
+				result.WriteString(v)
+			} else {
+				result.WriteString(regexp.QuoteMeta(string(format[i])))
+			}
+		}
+		pattern = pattern[end+1:]
+	}
+}
+
+// lockRotation acquires the rotation lock of logging directory <dirPath> across processes.
+// It returns a nil unlock function if the lock is held by another process.
+func (l *Logger) lockRotation(dirPath string) (unlock func(), err error) {
+	file, err := os.OpenFile(gfile.Join(dirPath, rotateLockFileName), os.O_CREATE|os.O_RDWR, defaultFilePerm)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := tryLockFile(file)
+	if !locked {
+		file.Close()
+		return nil, err
+	}
+	return func() {
+		if err := unlockFile(file); err != nil {
+			intlog.Error(err)
+		}
+		file.Close()
+	}, nil
+}
+
 // doRotateFile rotates the given logging file.
-func (l *Logger) doRotateFile(filePath string) error {
+// The parameter <stamp> is the time stamp in rotated file name, which uses the current time
+// to microseconds if it is empty. The optional parameter <needRotate> double checks whether the
+// file still needs rotation after the rotation lock acquired.
+func (l *Logger) doRotateFile(filePath string, stamp string, needRotate ...func() bool) error {
 	memoryLockKey := "glog.doRotateFile:" + filePath
 	if !gmlock.TryLock(memoryLockKey) {
 		return nil
 	}
 	defer gmlock.Unlock(memoryLockKey)
 
+	// Only one process rotates the logging file.
+	unlock, err := l.lockRotation(gfile.Dir(filePath))
+	if err != nil {
+		return err
+	}
+	if unlock == nil {
+		intlog.Printf(`rotation is locked by another process, ignore: %s`, filePath)
+		return nil
+	}
+	defer unlock()
+	if !gfile.Exists(filePath) {
+		return nil
+	}
+	if len(needRotate) > 0 && needRotate[0] != nil && !needRotate[0]() {
+		return nil
+	}
+
 	// No backups, it then just removes the current logging file.
-	if l.config.RotateBackupLimit == 0 {
+	if l.config.RotateBackupLimit == 0 && l.config.RotateBackupExpire == 0 && l.config.RotateBackupMaxSize == 0 {
 		if err := gfile.Remove(filePath); err != nil {
 			return err
 		}
-		intlog.Printf(`%d size exceeds, no backups set, remove original logging file: %s`, l.config.RotateSize, filePath)
+		intlog.Printf(`rotation happens, no backups set, remove original logging file: %s`, filePath)
 		return nil
 	}
 	// Else it creates new backup files.
@@ -54,32 +291,48 @@ func (l *Logger) doRotateFile(filePath string) error {
 		fileExtName = gfile.ExtName(filePath)
 		newFilePath = ""
 	)
-	// Rename the logging file by adding extra datetime information to microseconds, like:
-	// access.log          -> access.20200326101301899002.log
-	// access.20200326.log -> access.20200326.20200326101301899002.log
-	for {
-		var (
-			now   = gtime.Now()
-			micro = now.Microsecond() % 1000
-		)
-		if micro == 0 {
-			micro = 101
-		} else {
-			for micro < 100 {
-				micro *= 10
+	if stamp != "" {
+		// Rename the logging file by adding the time stamp of its period, like:
+		// access.log -> access.2020-03-26.log
+		// access.log -> access.2020-03-26.1.log, if access.2020-03-26.log exists.
+		for i := 0; ; i++ {
+			if i == 0 {
+				newFilePath = gfile.Join(dirPath, fmt.Sprintf(`%s.%s.%s`, fileName, stamp, fileExtName))
+			} else {
+				newFilePath = gfile.Join(dirPath, fmt.Sprintf(`%s.%s.%d.%s`, fileName, stamp, i, fileExtName))
+			}
+			if !l.backupExists(newFilePath) {
+				break
 			}
 		}
-		newFilePath = gfile.Join(
-			dirPath,
-			fmt.Sprintf(
-				`%s.%s%d.%s`,
-				fileName, now.Format("YmdHisu"), micro, fileExtName,
-			),
-		)
-		if !gfile.Exists(newFilePath) {
-			break
-		} else {
-			intlog.Printf(`rotation file exists, continue: %s`, newFilePath)
+	} else {
+		// Rename the logging file by adding extra datetime information to microseconds, like:
+		// access.log          -> access.20200326101301899002.log
+		// access.20200326.log -> access.20200326.20200326101301899002.log
+		for {
+			var (
+				now   = gtime.Now()
+				micro = now.Microsecond() % 1000
+			)
+			if micro == 0 {
+				micro = 101
+			} else {
+				for micro < 100 {
+					micro *= 10
+				}
+			}
+			newFilePath = gfile.Join(
+				dirPath,
+				fmt.Sprintf(
+					`%s.%s%d.%s`,
+					fileName, now.Format("YmdHisu"), micro, fileExtName,
+				),
+			)
+			if !l.backupExists(newFilePath) {
+				break
+			} else {
+				intlog.Printf(`rotation file exists, continue: %s`, newFilePath)
+			}
 		}
 	}
 	if err := gfile.Rename(filePath, newFilePath); err != nil {
@@ -88,14 +341,29 @@ func (l *Logger) doRotateFile(filePath string) error {
 	return nil
 }
 
+// backupExists checks whether backup file <path> exists in original or compressed.
+func (l *Logger) backupExists(path string) bool {
+	return gfile.Exists(path) || gfile.Exists(path+".gz") || gfile.Exists(path+".zst")
+}
+
+// backupFileRegex returns the regular expression matching backup file names, in which the
+// first sub match is the name of the original logging file without extension.
+func (l *Logger) backupFileRegex() *regexp.Regexp {
+	stamp := `\d{20}`
+	if l.config.RotateInterval != "" {
+		stamp = `(?:\d{20}|` + l.rotatePatternRegex() + `(?:\.\d+)?)`
+	}
+	return regexp.MustCompile(`^(.+)\.` + stamp + `\.(\w+)(?:\.gz|\.zst)?$`)
+}
+
 // rotateChecksTimely timely checks the backups expiration and the compression.
 func (l *Logger) rotateChecksTimely() {
 	defer gtimer.AddOnce(l.config.RotateCheckInterval, l.rotateChecksTimely)
 	// Checks whether file rotation not enabled.
-	if l.config.RotateSize <= 0 && l.config.RotateExpire == 0 {
+	if l.config.RotateSize <= 0 && l.config.RotateExpire == 0 && l.config.RotateInterval == "" {
 		intlog.Printf(
-			"logging rotation ignore checks: RotateSize: %d, RotateExpire: %s",
-			l.config.RotateSize, l.config.RotateExpire.String(),
+			"logging rotation ignore checks: RotateSize: %d, RotateExpire: %s, RotateInterval: %s",
+			l.config.RotateSize, l.config.RotateExpire.String(), l.config.RotateInterval,
 		)
 		return
 	}
@@ -108,10 +376,15 @@ func (l *Logger) rotateChecksTimely() {
 	defer gmlock.Unlock(memoryLockKey)
 
 	var (
-		now      = time.Now()
-		pattern  = "*.log, *.gz"
-		files, _ = gfile.ScanDirFile(l.config.Path, pattern, true)
+		now         = time.Now()
+		pattern     = "*.log, *.gz, *.zst"
+		backupRegex = l.backupFileRegex()
+		currentPath = l.getFilePath(now)
+		files, _    = gfile.ScanDirFile(l.config.Path, pattern, true)
 	)
+	isBackup := func(file string) bool {
+		return file != currentPath && backupRegex.MatchString(gfile.Basename(file))
+	}
 	intlog.Printf("logging rotation start checks: %+v", files)
 	// =============================================================
 	// Rotation of expired file checks.
@@ -123,7 +396,7 @@ func (l *Logger) rotateChecksTimely() {
 			expireRotated bool
 		)
 		for _, file := range files {
-			if gfile.ExtName(file) == "gz" {
+			if ext := gfile.ExtName(file); ext == "gz" || ext == "zst" || isBackup(file) {
 				continue
 			}
 			mtime = gfile.MTime(file)
@@ -134,7 +407,12 @@ func (l *Logger) rotateChecksTimely() {
 					`%v - %v = %v > %v, rotation expire logging file: %s`,
 					now, mtime, subDuration, l.config.RotateExpire, file,
 				)
-				if err := l.doRotateFile(file); err != nil {
+				filePath := file
+				err := l.doRotateFile(filePath, "", func() bool {
+					// It might be rotated by another process.
+					return time.Since(gfile.MTime(filePath)) > l.config.RotateExpire
+				})
+				if err != nil {
 					intlog.Error(err)
 				}
 			}
@@ -145,104 +423,109 @@ func (l *Logger) rotateChecksTimely() {
 		}
 	}
 
+	// Only one process does the compression and cleaning of backups.
+	unlock, err := l.lockRotation(l.config.Path)
+	if err != nil {
+		intlog.Error(err)
+		return
+	}
+	if unlock == nil {
+		return
+	}
+	defer unlock()
+
 	// =============================================================
 	// Rotated file compression.
 	// =============================================================
-	needCompressFileArray := garray.NewStrArray()
 	if l.config.RotateBackupCompress > 0 {
+		compressed := false
 		for _, file := range files {
 			// Eg: access.20200326101301899002.log.gz
-			if gfile.ExtName(file) == "gz" {
+			if ext := gfile.ExtName(file); ext == "gz" || ext == "zst" || !isBackup(file) {
 				continue
 			}
 			// Eg:
 			// access.20200326101301899002.log
-			if gregex.IsMatchString(`.+\.\d{20}\.log`, gfile.Basename(file)) {
-				needCompressFileArray.Append(file)
-			}
-		}
-		if needCompressFileArray.Len() > 0 {
-			needCompressFileArray.Iterator(func(_ int, path string) bool {
-				err := gcompress.GzipFile(path, path+".gz")
-				if err == nil {
-					intlog.Printf(`compressed done, remove original logging file: %s`, path)
-					if err = gfile.Remove(path); err != nil {
-						intlog.Print(err)
-					}
-				} else {
+			// access.2020-03-26.log
+			if err = l.compressBackupFile(file); err == nil {
+				intlog.Printf(`compressed done, remove original logging file: %s`, file)
+				if err = gfile.Remove(file); err != nil {
 					intlog.Print(err)
 				}
-				return true
-			})
+			} else {
+				intlog.Print(err)
+			}
+			compressed = true
+		}
+		if compressed {
 			// Update the files array.
 			files, _ = gfile.ScanDirFile(l.config.Path, pattern, true)
 		}
 	}
 
 	// =============================================================
-	// Backups count limitation and expiration checks.
+	// Backups count, expiration and total size limitation checks.
 	// =============================================================
-	var (
-		backupFilesMap          = make(map[string]*garray.SortedArray)
-		originalLoggingFilePath = ""
-	)
-	if l.config.RotateBackupLimit > 0 || l.config.RotateBackupExpire > 0 {
+	if l.config.RotateBackupLimit > 0 || l.config.RotateBackupExpire > 0 || l.config.RotateBackupMaxSize > 0 {
+		backupFilesMap := make(map[string][]string)
 		for _, file := range files {
-			originalLoggingFilePath, _ = gregex.ReplaceString(`\.\d{20}`, "", file)
-			if backupFilesMap[originalLoggingFilePath] == nil {
-				backupFilesMap[originalLoggingFilePath] = garray.NewSortedArray(func(a, b interface{}) int {
-					// Sorted by rotated/backup file mtime.
-					// The old rotated/backup file is put in the head of array.
-					file1 := a.(string)
-					file2 := b.(string)
-					result := gfile.MTimestampMilli(file1) - gfile.MTimestampMilli(file2)
-					if result <= 0 {
-						return -1
-					}
-					return 1
-				})
-			}
-			// Check if this file a rotated/backup file.
-			if gregex.IsMatchString(`.+\.\d{20}\.log`, gfile.Basename(file)) {
-				backupFilesMap[originalLoggingFilePath].Add(file)
+			if !isBackup(file) {
+				continue
 			}
+			match := backupRegex.FindStringSubmatch(gfile.Basename(file))
+			// The original logging file, eg: access.log.
+			originalLoggingFilePath := gfile.Join(gfile.Dir(file), match[1]+"."+match[2])
+			backupFilesMap[originalLoggingFilePath] = append(backupFilesMap[originalLoggingFilePath], file)
 		}
 		intlog.Printf(`calculated backup files map: %+v`, backupFilesMap)
-		for _, array := range backupFilesMap {
-			diff := array.Len() - l.config.RotateBackupLimit
-			for i := 0; i < diff; i++ {
-				path, _ := array.PopLeft()
-				intlog.Printf(`remove exceeded backup limit file: %s`, path)
-				if err := gfile.Remove(path.(string)); err != nil {
-					intlog.Print(err)
-				}
-			}
+		for _, backups := range backupFilesMap {
+			l.cleanBackupFiles(now, backups)
 		}
-		// Backup expiration checks.
-		if l.config.RotateBackupExpire > 0 {
-			var (
-				mtime       time.Time
-				subDuration time.Duration
-			)
-			for _, array := range backupFilesMap {
-				array.Iterator(func(_ int, v interface{}) bool {
-					path := v.(string)
-					mtime = gfile.MTime(path)
-					subDuration = now.Sub(mtime)
-					if subDuration > l.config.RotateBackupExpire {
-						intlog.Printf(
-							`%v - %v = %v > %v, remove expired backup file: %s`,
-							now, mtime, subDuration, l.config.RotateBackupExpire, path,
-						)
-						if err := gfile.Remove(path); err != nil {
-							intlog.Print(err)
-						}
-						return true
-					} else {
-						return false
-					}
-				})
-			}
+	}
+}
+
+// compressBackupFile compresses backup file <path> using the configured compression algorithm.
+func (l *Logger) compressBackupFile(path string) error {
+	if l.config.RotateCompressType == COMPRESS_ZSTD {
+		return gcompress.ZstdFile(path, path+".zst", l.config.RotateBackupCompress)
+	}
+	return gcompress.GzipFile(path, path+".gz", l.config.RotateBackupCompress)
+}
+
+// cleanBackupFiles removes the <backups> of a logging file exceeding the backup count,
+// expiration or total size limitation, in which the older backups are removed first.
+func (l *Logger) cleanBackupFiles(now time.Time, backups []string) {
+	// Sorted by rotated/backup file mtime.
+	// The old rotated/backup file is put in the head of array.
+	sort.SliceStable(backups, func(i, j int) bool {
+		return gfile.MTimestampMilli(backups[i]) < gfile.MTimestampMilli(backups[j])
+	})
+	var totalSize int64
+	for _, path := range backups {
+		totalSize += gfile.Size(path)
+	}
+	for i, path := range backups {
+		var (
+			remaining = len(backups) - i
+			reason    = ""
+		)
+		switch {
+		case l.config.RotateBackupLimit > 0 && remaining > l.config.RotateBackupLimit:
+			reason = "exceeded backup limit"
+		case l.config.RotateBackupExpire > 0 && now.Sub(gfile.MTime(path)) > l.config.RotateBackupExpire:
+			reason = "expired backup"
+		case l.config.RotateBackupMaxSize > 0 && totalSize > l.config.RotateBackupMaxSize:
+			reason = "exceeded backup max size"
+		default:
+			// The newer backups are within all limitations.
+			return
+		}
+		intlog.Printf(`remove %s file: %s`, reason, path)
+		size := gfile.Size(path)
+		if err := gfile.Remove(path); err != nil {
+			intlog.Print(err)
+			continue
 		}
+		totalSize -= size
 	}
 }
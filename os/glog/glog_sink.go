@@ -0,0 +1,299 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/container/gtype"
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/util/gconv"
+)
+
+// Sink is a remote logging destination, which delivers logging contents asynchronously.
+// The application should call Flush or Close on shutdown to deliver the pending contents.
+type Sink interface {
+	io.Writer
+	// Flush delivers all the pending contents, blocking until they are delivered or dropped.
+	Flush() error
+	// Close flushes the pending contents and closes the sink. It cannot be written after closed.
+	Close() error
+}
+
+// LevelWriter is the writer that can receive the logging level along with the content.
+// The logger calls WriteLevel instead of Write if the writer or sink implements it.
+type LevelWriter interface {
+	WriteLevel(level int, p []byte) (n int, err error)
+}
+
+// bareSink is the sink having its own time and level header, which receives the logging
+// content without the time and level header of the logger if bare returns true.
+type bareSink interface {
+	bare() bool
+}
+
+// SinkOptions is the common delivery options for sinks.
+type SinkOptions struct {
+	QueueSize     int           `json:"queueSize"`     // Max count of pending contents in queue, which is 1024 in default.
+	BatchSize     int           `json:"batchSize"`     // Max count of contents delivered in a batch, which is 100 in default.
+	FlushInterval time.Duration `json:"flushInterval"` // Interval delivering the pending contents, which is 1 second in default.
+	Block         bool          `json:"block"`         // Block the writing if queue is full, or else drop the content(default).
+	MaxRetries    int           `json:"maxRetries"`    // Max retries for a failed delivery, which is 3 in default, -1 means no retry.
+	RetryInterval time.Duration `json:"retryInterval"` // Initial retry interval, which is doubled for each retry. It's 100ms in default.
+}
+
+const (
+	defaultSinkQueueSize        = 1024
+	defaultSinkBatchSize        = 100
+	defaultSinkFlushInterval    = time.Second
+	defaultSinkMaxRetries       = 3
+	defaultSinkRetryInterval    = 100 * time.Millisecond
+	defaultSinkMaxRetryInterval = 10 * time.Second
+	defaultSinkDialTimeout      = 5 * time.Second
+)
+
+var (
+	// ErrorSinkClosed is returned when writing to a closed sink.
+	ErrorSinkClosed = errors.New("sink is closed")
+)
+
+// sinkEntry is a logging content in sink queue.
+type sinkEntry struct {
+	level int       // Logging level.
+	time  time.Time // Logging time.
+	data  []byte    // Logging content.
+}
+
+// sinkQueue is the bounded queue delivering contents in batch asynchronously with retries,
+// which is shared by all sinks.
+type sinkQueue struct {
+	options SinkOptions
+	deliver sinkDeliverFunc    // Delivers a batch of contents.
+	queue   chan sinkEntry     // Pending contents.
+	flushCh chan chan struct{} // Flush requests.
+	closeCh chan struct{}      // Closed when the queue is closing.
+	doneCh  chan struct{}      // Closed when the worker exits.
+	closed  *gtype.Bool        // Whether the queue is closed.
+	dropped *gtype.Uint64      // Count of dropped contents.
+}
+
+// sinkDeliverFunc delivers a batch of contents in order, and returns the count of the
+// contents delivered, which are not delivered again in retries of the failed delivery.
+type sinkDeliverFunc func(entries []sinkEntry) (delivered int, err error)
+
+// newSinkQueue creates and returns a sink queue, which starts the delivering worker in background.
+func newSinkQueue(options SinkOptions, deliver sinkDeliverFunc) *sinkQueue {
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaultSinkQueueSize
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultSinkBatchSize
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = defaultSinkFlushInterval
+	}
+	if options.MaxRetries == 0 {
+		options.MaxRetries = defaultSinkMaxRetries
+	}
+	if options.RetryInterval <= 0 {
+		options.RetryInterval = defaultSinkRetryInterval
+	}
+	q := &sinkQueue{
+		options: options,
+		deliver: deliver,
+		queue:   make(chan sinkEntry, options.QueueSize),
+		flushCh: make(chan chan struct{}),
+		closeCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+		closed:  gtype.NewBool(),
+		dropped: gtype.NewUint64(),
+	}
+	go q.run()
+	return q
+}
+
+// Write implements the io.Writer interface, which puts <p> to the queue.
+func (q *sinkQueue) Write(p []byte) (n int, err error) {
+	return q.WriteLevel(LEVEL_NONE, p)
+}
+
+// WriteLevel implements the LevelWriter interface, which puts <p> with <level> to the queue.
+// It drops <p> if the queue is full and the queue is not blocking.
+func (q *sinkQueue) WriteLevel(level int, p []byte) (n int, err error) {
+	if q.closed.Val() {
+		return 0, ErrorSinkClosed
+	}
+	entry := sinkEntry{
+		level: level,
+		time:  time.Now(),
+		data:  make([]byte, len(p)),
+	}
+	copy(entry.data, p)
+	if q.options.Block {
+		select {
+		case q.queue <- entry:
+		case <-q.closeCh:
+			return 0, ErrorSinkClosed
+		}
+	} else {
+		select {
+		case q.queue <- entry:
+		default:
+			q.dropped.Add(1)
+		}
+	}
+	return len(p), nil
+}
+
+// Flush delivers all the pending contents in queue.
+func (q *sinkQueue) Flush() error {
+	if q.closed.Val() {
+		return nil
+	}
+	ch := make(chan struct{})
+	select {
+	case q.flushCh <- ch:
+		<-ch
+	case <-q.doneCh:
+	}
+	return nil
+}
+
+// Close delivers all the pending contents and stops the worker.
+func (q *sinkQueue) Close() error {
+	if q.closed.Cas(false, true) {
+		close(q.closeCh)
+	}
+	<-q.doneCh
+	return nil
+}
+
+// Dropped returns the count of contents dropped for full queue.
+func (q *sinkQueue) Dropped() uint64 {
+	return q.dropped.Val()
+}
+
+// run is the worker delivering contents in batch.
+func (q *sinkQueue) run() {
+	var (
+		ticker  = time.NewTicker(q.options.FlushInterval)
+		entries = make([]sinkEntry, 0, q.options.BatchSize)
+	)
+	defer ticker.Stop()
+	defer close(q.doneCh)
+	send := func() {
+		if len(entries) > 0 {
+			q.send(entries)
+			entries = entries[:0]
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case entry := <-q.queue:
+				entries = append(entries, entry)
+				if len(entries) >= q.options.BatchSize {
+					send()
+				}
+			default:
+				send()
+				return
+			}
+		}
+	}
+	for {
+		select {
+		case entry := <-q.queue:
+			entries = append(entries, entry)
+			if len(entries) >= q.options.BatchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case ch := <-q.flushCh:
+			drain()
+			close(ch)
+		case <-q.closeCh:
+			drain()
+			return
+		}
+	}
+}
+
+// send delivers <entries> with retries, and drops them if all retries fail.
+// The retries only deliver the contents not delivered yet.
+func (q *sinkQueue) send(entries []sinkEntry) {
+	interval := q.options.RetryInterval
+	for i := 0; ; i++ {
+		delivered, err := q.deliver(entries)
+		if delivered > 0 {
+			entries = entries[delivered:]
+		}
+		if err == nil || len(entries) == 0 {
+			return
+		}
+		if q.options.MaxRetries < 0 || i >= q.options.MaxRetries {
+			intlog.Errorf(`sink delivery failed after %d retries, %d contents dropped: %v`, i, len(entries), err)
+			q.dropped.Add(uint64(len(entries)))
+			return
+		}
+		time.Sleep(interval)
+		if interval *= 2; interval > defaultSinkMaxRetryInterval {
+			interval = defaultSinkMaxRetryInterval
+		}
+	}
+}
+
+// closeSinks closes all <sinks>, which returns the first error if any.
+func closeSinks(sinks []Sink) error {
+	var err error
+	for _, sink := range sinks {
+		if e := sink.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// NewSinkWithMap creates and returns a sink with configuration map, which is used for
+// configuring sinks in the "sinks" item of logger configuration, eg:
+// {"type": "syslog", "network": "udp", "address": "127.0.0.1:514", "appName": "app"}.
+// The "type" item can be "syslog", "http" or "tcp", and the other items are the options of the sink.
+func NewSinkWithMap(m map[string]interface{}) (Sink, error) {
+	var sinkType string
+	for k, v := range m {
+		if strings.EqualFold(k, "type") {
+			sinkType = strings.ToLower(gconv.String(v))
+			break
+		}
+	}
+	switch sinkType {
+	case "syslog":
+		var options SinkSyslogOptions
+		if err := gconv.Struct(m, &options); err != nil {
+			return nil, err
+		}
+		return NewSinkSyslog(options)
+	case "http":
+		var options SinkHttpOptions
+		if err := gconv.Struct(m, &options); err != nil {
+			return nil, err
+		}
+		return NewSinkHttp(options)
+	case "tcp":
+		var options SinkTcpOptions
+		if err := gconv.Struct(m, &options); err != nil {
+			return nil, err
+		}
+		return NewSinkTcp(options)
+	}
+	return nil, errors.New(fmt.Sprintf(`invalid sink type: %s`, sinkType))
+}
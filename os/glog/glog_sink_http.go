@@ -0,0 +1,99 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/internal/intlog"
+)
+
+// SinkHttp is the sink delivering contents in batch to HTTP server, in which the request body
+// contains the contents of a batch separated by newline, eg: ndjson for json format.
+type SinkHttp struct {
+	*sinkQueue
+	options SinkHttpOptions
+	client  *http.Client
+}
+
+// SinkHttpOptions is the options for HTTP sink.
+type SinkHttpOptions struct {
+	SinkOptions
+	Url         string            `json:"url"`         // URL of HTTP server.
+	Method      string            `json:"method"`      // Request method, which is POST in default.
+	ContentType string            `json:"contentType"` // Content type, which is "application/x-ndjson" in default.
+	Headers     map[string]string `json:"headers"`     // Extra request headers, eg: Authorization.
+	Timeout     time.Duration     `json:"timeout"`     // Request timeout, which is 10 seconds in default.
+}
+
+const (
+	defaultSinkHttpContentType = "application/x-ndjson"
+	defaultSinkHttpTimeout     = 10 * time.Second
+)
+
+// NewSinkHttp creates and returns a HTTP sink.
+func NewSinkHttp(options SinkHttpOptions) (*SinkHttp, error) {
+	if options.Url == "" {
+		return nil, errors.New("http sink url cannot be empty")
+	}
+	if options.Method == "" {
+		options.Method = http.MethodPost
+	}
+	if options.ContentType == "" {
+		options.ContentType = defaultSinkHttpContentType
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultSinkHttpTimeout
+	}
+	s := &SinkHttp{
+		options: options,
+		client:  &http.Client{Timeout: options.Timeout},
+	}
+	s.sinkQueue = newSinkQueue(options.SinkOptions, s.deliver)
+	return s, nil
+}
+
+// deliver sends <entries> in one request, which are all delivered or not delivered.
+// It retries for network error, 429 and 5xx status, and drops the contents for other failed status.
+func (s *SinkHttp) deliver(entries []sinkEntry) (int, error) {
+	body := bytes.NewBuffer(nil)
+	for _, entry := range entries {
+		body.Write(entry.data)
+		if n := len(entry.data); n == 0 || entry.data[n-1] != '\n' {
+			body.WriteByte('\n')
+		}
+	}
+	req, err := http.NewRequest(s.options.Method, s.options.Url, body)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", s.options.ContentType)
+	for k, v := range s.options.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	switch {
+	case resp.StatusCode < 300:
+		return len(entries), nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return 0, errors.New(fmt.Sprintf(`http sink got status: %s`, resp.Status))
+	default:
+		intlog.Errorf(`http sink got status %s, %d contents dropped`, resp.Status, len(entries))
+		return len(entries), nil
+	}
+}
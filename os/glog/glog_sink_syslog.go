@@ -0,0 +1,186 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SinkSyslog is the sink delivering contents to syslog server in RFC5424 format over UDP or TCP.
+// The messages over TCP are framed using octet counting of RFC6587.
+type SinkSyslog struct {
+	*sinkQueue
+	options  SinkSyslogOptions
+	facility int
+	conn     net.Conn
+}
+
+// SinkSyslogOptions is the options for syslog sink.
+type SinkSyslogOptions struct {
+	SinkOptions
+	Network  string `json:"network"`  // Network of syslog server: udp(default), tcp.
+	Address  string `json:"address"`  // Address of syslog server, eg: 127.0.0.1:514.
+	Facility string `json:"facility"` // Syslog facility name or code, eg: kern, user, local0 or 16, which is "user" in default.
+	AppName  string `json:"appName"`  // Application name, which is the process name in default.
+	Hostname string `json:"hostname"` // Hostname, which is the hostname of the machine in default.
+}
+
+// syslogSeverities defines the level to syslog severity mapping.
+var syslogSeverities = map[int]int{
+	LEVEL_FATA: 0, // Emergency.
+	LEVEL_PANI: 1, // Alert.
+	LEVEL_CRIT: 2, // Critical.
+	LEVEL_ERRO: 3, // Error.
+	LEVEL_WARN: 4, // Warning.
+	LEVEL_NOTI: 5, // Notice.
+	LEVEL_INFO: 6, // Informational.
+	LEVEL_DEBU: 7, // Debug.
+}
+
+// syslogFacilities defines the syslog facility name to code mapping.
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+const (
+	defaultSyslogFacility = "user"
+	defaultSyslogSeverity = 6
+	maxSyslogFacility     = 23
+)
+
+// NewSinkSyslog creates and returns a syslog sink.
+func NewSinkSyslog(options SinkSyslogOptions) (*SinkSyslog, error) {
+	if options.Address == "" {
+		return nil, errors.New("syslog address cannot be empty")
+	}
+	options.Network = strings.ToLower(options.Network)
+	switch options.Network {
+	case "":
+		options.Network = "udp"
+	case "udp", "tcp":
+	default:
+		return nil, errors.New(fmt.Sprintf(`invalid syslog network: %s`, options.Network))
+	}
+	if options.Facility == "" {
+		options.Facility = defaultSyslogFacility
+	}
+	facility, ok := syslogFacilities[strings.ToLower(options.Facility)]
+	if !ok {
+		code, err := strconv.Atoi(options.Facility)
+		if err != nil || code < 0 || code > maxSyslogFacility {
+			return nil, errors.New(fmt.Sprintf(`invalid syslog facility: %s`, options.Facility))
+		}
+		facility = code
+	}
+	if options.AppName == "" {
+		options.AppName = filepath.Base(os.Args[0])
+	}
+	if options.Hostname == "" {
+		options.Hostname, _ = os.Hostname()
+	}
+	s := &SinkSyslog{
+		options:  options,
+		facility: facility,
+	}
+	s.sinkQueue = newSinkQueue(options.SinkOptions, s.deliver)
+	return s, nil
+}
+
+// Close flushes the pending contents and closes the connection.
+func (s *SinkSyslog) Close() error {
+	err := s.sinkQueue.Close()
+	if s.conn != nil {
+		if closeErr := s.conn.Close(); err == nil {
+			err = closeErr
+		}
+		s.conn = nil
+	}
+	return err
+}
+
+// bare implements the bareSink interface, as the time and level are in the syslog header.
+func (s *SinkSyslog) bare() bool {
+	return true
+}
+
+// deliver sends <entries> to syslog server one by one, which reconnects the server if it fails.
+// It returns the count of the entries sent if the sending fails.
+// Note that it is only called in the worker goroutine of the queue.
+func (s *SinkSyslog) deliver(entries []sinkEntry) (int, error) {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.options.Network, s.options.Address, defaultSinkDialTimeout)
+		if err != nil {
+			return 0, err
+		}
+		s.conn = conn
+	}
+	for i, entry := range entries {
+		message := s.format(entry)
+		if s.options.Network == "tcp" {
+			message = append([]byte(fmt.Sprintf("%d ", len(message))), message...)
+		}
+		if _, err := s.conn.Write(message); err != nil {
+			s.conn.Close()
+			s.conn = nil
+			return i, err
+		}
+	}
+	return len(entries), nil
+}
+
+// format returns the RFC5424 message of <entry> with its logging time:
+// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID - - MSG
+func (s *SinkSyslog) format(entry sinkEntry) []byte {
+	severity, ok := syslogSeverities[entry.level]
+	if !ok {
+		severity = defaultSyslogSeverity
+	}
+	return []byte(fmt.Sprintf(
+		"<%d>1 %s %s %s %d - - %s",
+		s.facility*8+severity,
+		entry.time.Format(time.RFC3339Nano),
+		syslogHeaderValue(s.options.Hostname),
+		syslogHeaderValue(s.options.AppName),
+		os.Getpid(),
+		bytes.TrimRight(entry.data, "\r\n"),
+	))
+}
+
+// syslogHeaderValue returns <s> as a syslog header value, which cannot be empty or contain spaces.
+func syslogHeaderValue(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return strings.Replace(s, " ", "_", -1)
+}
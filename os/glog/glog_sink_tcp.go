@@ -0,0 +1,86 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"bytes"
+	"errors"
+	"net"
+)
+
+// SinkTcp is the sink delivering contents to TCP server line by line,
+// which is commonly used with json format for log collectors like logstash and fluentd.
+type SinkTcp struct {
+	*sinkQueue
+	options SinkTcpOptions
+	conn    net.Conn
+}
+
+// SinkTcpOptions is the options for TCP sink.
+type SinkTcpOptions struct {
+	SinkOptions
+	Address string `json:"address"` // Address of TCP server, eg: 127.0.0.1:5170.
+}
+
+// NewSinkTcp creates and returns a TCP sink.
+func NewSinkTcp(options SinkTcpOptions) (*SinkTcp, error) {
+	if options.Address == "" {
+		return nil, errors.New("tcp sink address cannot be empty")
+	}
+	s := &SinkTcp{
+		options: options,
+	}
+	s.sinkQueue = newSinkQueue(options.SinkOptions, s.deliver)
+	return s, nil
+}
+
+// Close flushes the pending contents and closes the connection.
+func (s *SinkTcp) Close() error {
+	err := s.sinkQueue.Close()
+	if s.conn != nil {
+		if closeErr := s.conn.Close(); err == nil {
+			err = closeErr
+		}
+		s.conn = nil
+	}
+	return err
+}
+
+// deliver writes <entries> to TCP server in one write, which reconnects the server if it fails.
+// It returns the count of the entries completely written if the writing fails.
+// Note that it is only called in the worker goroutine of the queue.
+func (s *SinkTcp) deliver(entries []sinkEntry) (int, error) {
+	if s.conn == nil {
+		conn, err := net.DialTimeout("tcp", s.options.Address, defaultSinkDialTimeout)
+		if err != nil {
+			return 0, err
+		}
+		s.conn = conn
+	}
+	var (
+		buffer = bytes.NewBuffer(nil)
+		ends   = make([]int, len(entries))
+	)
+	for i, entry := range entries {
+		buffer.Write(entry.data)
+		if n := len(entry.data); n == 0 || entry.data[n-1] != '\n' {
+			buffer.WriteByte('\n')
+		}
+		ends[i] = buffer.Len()
+	}
+	written, err := s.conn.Write(buffer.Bytes())
+	if err == nil {
+		return len(entries), nil
+	}
+	s.conn.Close()
+	s.conn = nil
+	delivered := 0
+	for delivered < len(ends) && ends[delivered] <= written {
+		delivered++
+	}
+	return delivered, err
+}
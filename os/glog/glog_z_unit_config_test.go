@@ -11,6 +11,7 @@ import (
 	"github.com/gogf/gf/test/gtest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Test_SetConfigWithMap(t *testing.T) {
@@ -63,3 +64,46 @@ func Test_SetConfigWithMap_LevelStr(t *testing.T) {
 		t.Assert(strings.Contains(buffer.String(), "WARN"), true)
 	})
 }
+
+func Test_SetConfigWithMap_Handlers(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l = New()
+			m = map[string]interface{}{
+				"stdout":   false,
+				"sampling": map[string]interface{}{"first": 2},
+				"dedupe":   true,
+			}
+		)
+		for i := 0; i < 3; i++ {
+			t.Assert(l.SetConfigWithMap(m), nil)
+		}
+		t.Assert(len(l.configHandlers), 2)
+		t.Assert(len(l.config.Handlers), 0)
+
+		// The timer of replaced deduper is stopped.
+		l.Error("error")
+		deduper := l.deduper
+		deduper.mu.Lock()
+		t.AssertNE(deduper.timer, nil)
+		deduper.mu.Unlock()
+		t.Assert(l.SetConfigWithMap(map[string]interface{}{"dedupe": false}), nil)
+		deduper.mu.Lock()
+		t.Assert(deduper.timer, nil)
+		deduper.mu.Unlock()
+		t.Assert(l.deduper, nil)
+		t.Assert(len(l.configHandlers), 1)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// The timer stops if there's no logging content in an interval.
+		d := newDeduper(DedupeOptions{Interval: 50 * time.Millisecond})
+		t.Assert(d.allow(&HandlerInput{Content: "a"}), true)
+		d.mu.Lock()
+		t.AssertNE(d.timer, nil)
+		d.mu.Unlock()
+		time.Sleep(300 * time.Millisecond)
+		d.mu.Lock()
+		t.Assert(d.timer, nil)
+		d.mu.Unlock()
+	})
+}
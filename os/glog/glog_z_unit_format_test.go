@@ -0,0 +1,94 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gogf/gf/internal/json"
+	"github.com/gogf/gf/os/glog"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/text/gstr"
+)
+
+func Test_With_Text(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		l.With("uid", 1, "name", "john doe").Info("hello")
+		t.Assert(gstr.Contains(w.String(), `uid=1 name="john doe" hello`), true)
+		t.Assert(gstr.Contains(w.String(), "[INFO]"), true)
+	})
+	// With does not affect each other.
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w).With("a", 1)
+		l.With("b", 2).Print("1")
+		l.With("c", 3).Print("2")
+		l.Print("3")
+		lines := gstr.SplitAndTrim(w.String(), "\n")
+		t.Assert(len(lines), 3)
+		t.Assert(gstr.Contains(lines[0], "a=1 b=2 1"), true)
+		t.Assert(gstr.Contains(lines[1], "a=1 c=3 2"), true)
+		t.Assert(gstr.Contains(lines[2], "a=1 3"), true)
+	})
+}
+
+func Test_Format_Json(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		t.Assert(l.SetFormat(glog.FORMAT_JSON), nil)
+		l.SetCtxKeys("RequestId")
+		ctx := context.WithValue(context.Background(), "RequestId", "123456")
+		l.Ctx(ctx).With("uid", 1, "err", errors.New("failed")).Line().Info("hello", "world")
+
+		var m map[string]interface{}
+		t.Assert(json.Unmarshal(w.Bytes(), &m), nil)
+		t.Assert(m["level"], "info")
+		t.Assert(m["msg"], "hello world")
+		t.Assert(gstr.Contains(m["caller"].(string), ".go:"), true)
+		t.AssertNE(m["time"], "")
+		fields := m["fields"].(map[string]interface{})
+		t.Assert(fields["RequestId"], "123456")
+		t.Assert(fields["uid"], 1)
+		t.Assert(fields["err"], "failed")
+		t.Assert(m["stack"], nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		t.Assert(l.SetConfigWithMap(map[string]interface{}{"format": "json"}), nil)
+		l.Error("error")
+
+		var m map[string]interface{}
+		t.Assert(json.Unmarshal(w.Bytes(), &m), nil)
+		t.Assert(m["level"], "error")
+		t.Assert(m["msg"], "error")
+		t.AssertNE(m["stack"], nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		t.AssertNE(l.SetFormat("xml"), nil)
+		t.Assert(l.GetFormat(), glog.FORMAT_TEXT)
+	})
+}
+
+func Test_Format_Logfmt(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		t.Assert(l.SetFormat(glog.FORMAT_LOGFMT), nil)
+		l.With("uid", 1, "name", `john "doe"`).Warning("hello world")
+		t.Assert(gstr.HasPrefix(w.String(), "time="), true)
+		t.Assert(gstr.Contains(w.String(), ` level=warning msg="hello world" uid=1 name="john \"doe\""`), true)
+		t.Assert(gstr.HasSuffix(w.String(), "\n"), true)
+	})
+}
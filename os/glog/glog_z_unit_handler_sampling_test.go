@@ -0,0 +1,125 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/os/glog"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/text/gstr"
+)
+
+func Test_SamplingHandler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		l.SetStack(false)
+		l.SetHandlers(glog.NewSamplingHandler(glog.SamplingOptions{
+			Interval:   time.Second,
+			First:      3,
+			Thereafter: 10,
+		}))
+		// Keyed by format string.
+		for i := 0; i < 100; i++ {
+			l.Errorf("query %d failed", i)
+		}
+		// 3 first + 1 of every 10 in the following 97.
+		t.Assert(gstr.Count(w.String(), "[ERRO]"), 3+9)
+		t.Assert(gstr.Contains(w.String(), "query 12 failed"), true)
+		t.Assert(gstr.Contains(w.String(), "query 13 failed"), false)
+
+		// Different levels and contents have different keys.
+		w.Reset()
+		for i := 0; i < 5; i++ {
+			l.Info("a")
+			l.Warning("a")
+			l.Info("b")
+		}
+		t.Assert(gstr.Count(w.String(), "[INFO] a"), 3)
+		t.Assert(gstr.Count(w.String(), "[WARN] a"), 3)
+		t.Assert(gstr.Count(w.String(), "[INFO] b"), 3)
+
+		// Print is not sampled.
+		w.Reset()
+		for i := 0; i < 5; i++ {
+			l.Print("a")
+		}
+		t.Assert(gstr.Count(w.String(), "a"), 5)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		l.SetHandlers(glog.NewSamplingHandler(glog.SamplingOptions{
+			Interval: 200 * time.Millisecond,
+			First:    1,
+		}))
+		l.Info("a")
+		l.Info("a")
+		t.Assert(gstr.Count(w.String(), "a"), 1)
+		time.Sleep(300 * time.Millisecond)
+		l.Info("a")
+		t.Assert(gstr.Count(w.String(), "a"), 2)
+	})
+}
+
+// safeBuffer is a concurrent-safe buffer, as the dedupe summaries are output in another goroutine.
+type safeBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *safeBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *safeBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.String()
+}
+
+func Test_DedupeHandler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		w := &safeBuffer{}
+		l := glog.NewWithWriter(w)
+		l.SetStack(false)
+		t.Assert(l.SetConfigWithMap(map[string]interface{}{
+			"dedupe": map[string]interface{}{"interval": "500ms"},
+		}), nil)
+		for i := 0; i < 10; i++ {
+			l.Error("connection refused")
+		}
+		l.Error("timeout")
+		t.Assert(gstr.Count(w.String(), "connection refused"), 1)
+		t.Assert(gstr.Count(w.String(), "timeout"), 1)
+
+		time.Sleep(1200 * time.Millisecond)
+		t.Assert(gstr.Count(w.String(), "message repeated 9 times: connection refused"), 1)
+		t.Assert(gstr.Count(w.String(), "timeout"), 1)
+
+		// New interval outputs the content again.
+		l.Error("connection refused")
+		t.Assert(gstr.Count(w.String(), "connection refused"), 3)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		w := bytes.NewBuffer(nil)
+		l := glog.NewWithWriter(w)
+		t.Assert(l.SetConfigWithMap(map[string]interface{}{
+			"sampling": map[string]interface{}{"first": 2},
+		}), nil)
+		for i := 0; i < 10; i++ {
+			l.Info("a")
+		}
+		t.Assert(gstr.Count(w.String(), "[INFO] a"), 2)
+	})
+}
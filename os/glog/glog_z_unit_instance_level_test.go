@@ -0,0 +1,152 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogf/gf/os/glog"
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/text/gstr"
+)
+
+func Test_Instance_LevelInheritance(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			name   = "inherit" + gtime.TimestampNanoStr()
+			parent = glog.Instance(name)
+			child  = glog.Instance(name + ".child.leaf")
+		)
+		t.Assert(child.GetName(), name+".child.leaf")
+		t.Assert(child.GetEffectiveLevel(), parent.GetEffectiveLevel())
+
+		parent.SetLevelStr("WARN")
+		t.Assert(child.GetEffectiveLevel(), glog.LEVEL_WARN|glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+
+		w := bytes.NewBuffer(nil)
+		child.SetWriter(w)
+		child.Info("info")
+		child.Warning("warning")
+		t.Assert(gstr.Contains(w.String(), "info"), false)
+		t.Assert(gstr.Contains(w.String(), "warning"), true)
+
+		// Own level takes precedence over the inherited one.
+		child.SetLevelStr("ALL")
+		t.Assert(child.GetEffectiveLevel(), glog.LEVEL_ALL)
+	})
+}
+
+func Test_Instance_LevelOverride(t *testing.T) {
+	defer glog.RemoveLevelOverride("override.*")
+	defer glog.RemoveLevelOverride("override.a.*")
+	defer glog.RemoveLevelOverride("override.a.b")
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			a  = glog.Instance("override.a")
+			ab = glog.Instance("override.a.b")
+			c  = glog.Instance("override.c")
+		)
+		a.SetLevelStr("ERROR")
+		t.Assert(glog.SetLevelOverrides(map[string]string{"override.*": "DEBUG"}), nil)
+		t.Assert(a.GetEffectiveLevel(), glog.LEVEL_ALL)
+		t.Assert(c.GetEffectiveLevel(), glog.LEVEL_ALL)
+
+		// The more specific pattern takes precedence.
+		glog.SetLevelOverride("override.a.*", glog.LEVEL_CRIT)
+		t.Assert(a.GetEffectiveLevel(), glog.LEVEL_CRIT)
+		t.Assert(ab.GetEffectiveLevel(), glog.LEVEL_CRIT)
+		t.Assert(c.GetEffectiveLevel(), glog.LEVEL_ALL)
+		t.Assert(glog.SetLevelOverrideStr("override.a.b", "NONE"), nil)
+		t.Assert(ab.GetEffectiveLevel(), glog.LEVEL_NONE)
+		t.AssertNE(glog.SetLevelOverrideStr("override.a.b", "INVALID"), nil)
+
+		glog.RemoveLevelOverride("override.a.*")
+		glog.RemoveLevelOverride("override.a.b")
+		t.Assert(a.GetEffectiveLevel(), glog.LEVEL_ALL)
+		glog.RemoveLevelOverride("override.*")
+		t.Assert(a.GetEffectiveLevel(), glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+		t.Assert(ab.GetEffectiveLevel(), glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			prefix = "override" + gtime.TimestampNanoStr()
+			l      = glog.Instance(prefix + ".config")
+			query  = glog.Instance(prefix + ".db.query")
+			other  = glog.Instance(prefix + ".other")
+		)
+		defer l.SetConfigWithMap(map[string]interface{}{"levels": map[string]interface{}{}})
+		t.Assert(l.SetConfigWithMap(map[string]interface{}{
+			"levels": map[string]interface{}{prefix + ".db.*": "ERROR"},
+		}), nil)
+		// The configured overrides are global, which apply to any logger matching the pattern.
+		t.Assert(query.GetEffectiveLevel(), glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+		t.Assert(glog.LevelToString(query.GetEffectiveLevel()), "ERROR")
+		t.Assert(glog.GetLevelOverrides()[prefix+".db.*"], glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+		t.Assert(l.GetEffectiveLevel(), l.GetLevel())
+		t.Assert(other.GetEffectiveLevel(), other.GetLevel())
+
+		// The overrides of previous configuration are replaced.
+		t.Assert(l.SetConfigWithMap(map[string]interface{}{
+			"levels": map[string]interface{}{prefix + ".other": "CRIT"},
+		}), nil)
+		t.Assert(query.GetEffectiveLevel(), query.GetLevel())
+		t.Assert(other.GetEffectiveLevel(), glog.LEVEL_CRIT)
+		_, ok := glog.GetLevelOverrides()[prefix+".db.*"]
+		t.Assert(ok, false)
+
+		t.AssertNE(l.SetConfigWithMap(map[string]interface{}{
+			"levels": map[string]interface{}{prefix + ".*": "INVALID"},
+		}), nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// The configuration of the default logger applies to named loggers.
+		var (
+			prefix = "override" + gtime.TimestampNanoStr()
+			query  = glog.Instance(prefix + ".db.query")
+		)
+		defer glog.Instance().SetConfigWithMap(map[string]interface{}{"levels": map[string]interface{}{}})
+		t.Assert(glog.Instance().SetConfigWithMap(map[string]interface{}{
+			"levels": map[string]interface{}{prefix + ".db.*": "DEBUG"},
+		}), nil)
+		t.Assert(query.GetEffectiveLevel(), glog.LEVEL_ALL)
+	})
+}
+
+func Test_Instance_LevelSetConfig(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			name   = "config" + gtime.TimestampNanoStr()
+			parent = glog.Instance(name)
+			child  = glog.Instance(name + ".child")
+		)
+		parent.SetLevelStr("ERROR")
+		t.Assert(child.GetEffectiveLevel(), glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+
+		// The level of configuration is explicitly set.
+		config := glog.DefaultConfig()
+		config.Level = glog.LEVEL_WARN | glog.LEVEL_ERRO | glog.LEVEL_CRIT
+		t.Assert(child.SetConfig(config), nil)
+		t.Assert(child.GetEffectiveLevel(), glog.LEVEL_WARN|glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+
+		// The cached level is refreshed when the ancestor changes.
+		leaf := glog.Instance(name + ".child.leaf")
+		t.Assert(leaf.GetEffectiveLevel(), glog.LEVEL_WARN|glog.LEVEL_ERRO|glog.LEVEL_CRIT)
+		child.SetLevel(glog.LEVEL_CRIT)
+		t.Assert(leaf.GetEffectiveLevel(), glog.LEVEL_CRIT)
+	})
+}
+
+func Test_LevelToString(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(glog.LevelToString(glog.LEVEL_ALL), "DEBUG")
+		t.Assert(glog.LevelToString(glog.LEVEL_NONE), "NONE")
+		t.Assert(glog.LevelToString(glog.LEVEL_WARN|glog.LEVEL_ERRO|glog.LEVEL_CRIT), "WARN")
+		t.Assert(glog.LevelToString(glog.LEVEL_CRIT), "CRITICAL")
+	})
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/gogf/gf/os/gtime"
 	"github.com/gogf/gf/test/gtest"
 	"github.com/gogf/gf/text/gstr"
+	"github.com/gogf/gf/util/grand"
+	"os"
 	"testing"
 	"time"
 )
@@ -99,3 +101,125 @@ func Test_Rotate_Expire(t *testing.T) {
 		t.Assert(len(files), 0)
 	})
 }
+
+func Test_Rotate_Interval(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		p := gfile.TempDir(gtime.TimestampNanoStr())
+		err := l.SetConfigWithMap(g.Map{
+			"Path":              p,
+			"File":              "access.log",
+			"StdoutPrint":       false,
+			"RotateInterval":    "daily",
+			"RotateBackupLimit": 7,
+		})
+		t.Assert(err, nil)
+		defer gfile.Remove(p)
+
+		// The logging file was written yesterday.
+		var (
+			filePath  = gfile.Join(p, "access.log")
+			yesterday = time.Now().AddDate(0, 0, -1)
+		)
+		t.Assert(gfile.PutContents(filePath, "yesterday\n"), nil)
+		t.Assert(os.Chtimes(filePath, yesterday, yesterday), nil)
+
+		l.Print("today")
+		backupPath := gfile.Join(p, fmt.Sprintf("access.%s.log", yesterday.Format("2006-01-02")))
+		t.Assert(gfile.GetContents(backupPath), "yesterday\n")
+		t.Assert(gstr.Contains(gfile.GetContents(filePath), "today"), true)
+		t.Assert(gstr.Contains(gfile.GetContents(filePath), "yesterday"), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		t.AssertNE(l.SetConfigWithMap(g.Map{"RotateInterval": "weekly"}), nil)
+		t.AssertNE(l.SetConfigWithMap(g.Map{"RotateCompressType": "lz4"}), nil)
+	})
+}
+
+func Test_Rotate_Interval_UnrelatedFile(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		p := gfile.TempDir(gtime.TimestampNanoStr())
+		err := l.SetConfigWithMap(g.Map{
+			"Path":                 p,
+			"File":                 "access.log",
+			"StdoutPrint":          false,
+			"RotateInterval":       "daily",
+			"RotatePattern":        "{Ymd}",
+			"RotateBackupLimit":    1,
+			"RotateBackupCompress": 9,
+			"RotateCheckInterval":  time.Second, // For unit testing only.
+		})
+		t.Assert(err, nil)
+		defer gfile.Remove(p)
+
+		for i, name := range []string{"access.20200325.log", "access.20200326.log", "access.error.log"} {
+			var (
+				path  = gfile.Join(p, name)
+				mtime = time.Now().Add(time.Duration(i-10) * time.Minute)
+			)
+			t.Assert(gfile.PutContents(path, name), nil)
+			t.Assert(os.Chtimes(path, mtime, mtime), nil)
+		}
+		l.Print("today")
+
+		time.Sleep(time.Second * 2)
+
+		// The unrelated logging file is neither compressed nor removed.
+		t.Assert(gfile.GetContents(gfile.Join(p, "access.error.log")), "access.error.log")
+		t.Assert(gfile.Exists(gfile.Join(p, "access.error.log.gz")), false)
+		// The older backup is removed and the newer one is compressed.
+		t.Assert(gfile.Exists(gfile.Join(p, "access.20200325.log")), false)
+		t.Assert(gfile.Exists(gfile.Join(p, "access.20200325.log.gz")), false)
+		t.Assert(gfile.Exists(gfile.Join(p, "access.20200326.log.gz")), true)
+	})
+}
+
+func Test_Rotate_Zstd_MaxSize(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := glog.New()
+		p := gfile.TempDir(gtime.TimestampNanoStr())
+		err := l.SetConfigWithMap(g.Map{
+			"Path":                 p,
+			"File":                 "access.log",
+			"StdoutPrint":          false,
+			"RotateSize":           10,
+			"RotateBackupMaxSize":  "1K",
+			"RotateBackupCompress": 9,
+			"RotateCompressType":   "zstd",
+			"RotateCheckInterval":  time.Second, // For unit testing only.
+		})
+		t.Assert(err, nil)
+		defer gfile.Remove(p)
+
+		// Backups exceeding the max size, which are written in the past.
+		for i := 0; i < 3; i++ {
+			var (
+				path  = gfile.Join(p, fmt.Sprintf("access.2020032610130189900%d.log", i))
+				mtime = time.Now().Add(time.Duration(i-10) * time.Minute)
+			)
+			t.Assert(gfile.PutContents(path, grand.S(600)), nil)
+			t.Assert(os.Chtimes(path, mtime, mtime), nil)
+		}
+		l.Print("1234567890abcdefg")
+		l.Print("1234567890abcdefg")
+
+		time.Sleep(time.Second * 2)
+
+		files, err := gfile.ScanDirFile(p, "*.log")
+		t.Assert(err, nil)
+		t.Assert(len(files), 1)
+		files, err = gfile.ScanDirFile(p, "*.zst")
+		t.Assert(err, nil)
+		t.AssertGT(len(files), 0)
+		t.AssertLT(len(files), 4)
+		// The oldest backup is removed first.
+		t.Assert(gfile.Exists(gfile.Join(p, "access.20200326101301899000.log.zst")), false)
+		var totalSize int64
+		for _, file := range files {
+			totalSize += gfile.Size(file)
+		}
+		t.AssertLE(totalSize, 1024)
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_SinkQueue_PartialDelivery(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			delivered = make([]string, 0)
+			failed    = false
+		)
+		// The first delivery fails after delivering the first entry.
+		q := newSinkQueue(SinkOptions{RetryInterval: time.Millisecond}, func(entries []sinkEntry) (int, error) {
+			for i, entry := range entries {
+				if !failed && i == 1 {
+					failed = true
+					return i, errors.New("broken")
+				}
+				delivered = append(delivered, string(entry.data))
+			}
+			return len(entries), nil
+		})
+		q.Write([]byte("1"))
+		q.Write([]byte("2"))
+		q.Write([]byte("3"))
+		t.Assert(q.Close(), nil)
+		t.Assert(delivered, []string{"1", "2", "3"})
+		t.Assert(q.Dropped(), 0)
+	})
+}
+
+func Test_SinkSyslog_Format(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		sink, err := NewSinkSyslog(SinkSyslogOptions{
+			Address:  "127.0.0.1:514",
+			Facility: "kern",
+			AppName:  "app",
+			Hostname: "host",
+		})
+		t.Assert(err, nil)
+		defer sink.Close()
+
+		logTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		message := string(sink.format(sinkEntry{level: LEVEL_ERRO, time: logTime, data: []byte("hello\n")}))
+		// kern(0) * 8 + error(3) = 3
+		t.Assert(strings.HasPrefix(message, "<3>1 2020-01-02T03:04:05Z host app "), true)
+		t.Assert(strings.HasSuffix(message, " - - hello"), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		sink, err := NewSinkSyslog(SinkSyslogOptions{Address: "127.0.0.1:514"})
+		t.Assert(err, nil)
+		t.Assert(sink.facility, 1)
+		sink.Close()
+
+		sink, err = NewSinkSyslog(SinkSyslogOptions{Address: "127.0.0.1:514", Facility: "0"})
+		t.Assert(err, nil)
+		t.Assert(sink.facility, 0)
+		sink.Close()
+
+		_, err = NewSinkSyslog(SinkSyslogOptions{Address: "127.0.0.1:514", Facility: "unknown"})
+		t.AssertNE(err, nil)
+		_, err = NewSinkSyslog(SinkSyslogOptions{Address: "127.0.0.1:514", Facility: "24"})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_SetConfigWithMap_Sinks(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l = New()
+			m = map[string]interface{}{
+				"stdout": false,
+				"sinks": []map[string]interface{}{{
+					"type":    "tcp",
+					"address": "127.0.0.1:1",
+				}},
+			}
+		)
+		t.Assert(l.SetConfigWithMap(m), nil)
+		defer l.Close()
+		t.Assert(len(l.GetSinks()), 1)
+		// The configuration map of caller is not changed.
+		t.Assert(len(m), 2)
+		t.AssertNE(m["sinks"], nil)
+
+		// The sinks of previous configuration are closed after replaced.
+		oldSink := l.GetSinks()[0].(*SinkTcp)
+		t.Assert(l.SetConfigWithMap(m), nil)
+		t.Assert(len(l.GetSinks()), 1)
+		t.Assert(oldSink.closed.Val(), true)
+		t.Assert(l.GetSinks()[0].(*SinkTcp).closed.Val(), false)
+
+		// The sinks are not changed if any sink fails creating.
+		currentSink := l.GetSinks()[0]
+		t.AssertNE(l.SetConfigWithMap(map[string]interface{}{
+			"sinks": []map[string]interface{}{
+				{"type": "tcp", "address": "127.0.0.1:1"},
+				{"type": "unknown"},
+			},
+		}), nil)
+		t.Assert(len(l.GetSinks()), 1)
+		t.Assert(l.GetSinks()[0] == currentSink, true)
+	})
+}
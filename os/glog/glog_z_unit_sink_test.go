@@ -0,0 +1,159 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog_test
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/container/garray"
+	"github.com/gogf/gf/container/gtype"
+	"github.com/gogf/gf/os/glog"
+	"github.com/gogf/gf/test/gtest"
+	"github.com/gogf/gf/text/gstr"
+)
+
+func Test_SinkTcp(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		t.Assert(err, nil)
+		defer ln.Close()
+		lines := garray.NewStrArray(true)
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				lines.Append(scanner.Text())
+			}
+		}()
+
+		sink, err := glog.NewSinkTcp(glog.SinkTcpOptions{Address: ln.Addr().String()})
+		t.Assert(err, nil)
+		l := glog.New()
+		l.SetStdoutPrint(false)
+		t.Assert(l.SetFormat(glog.FORMAT_JSON), nil)
+		l.AddSink(sink)
+		l.Info(1)
+		l.Info(2)
+		t.Assert(l.Close(), nil)
+
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(lines.Len(), 2)
+		t.Assert(gstr.Contains(lines.At(0), `"msg":"1"`), true)
+		t.Assert(gstr.Contains(lines.At(1), `"msg":"2"`), true)
+
+		_, err = sink.Write([]byte("3"))
+		t.Assert(err, glog.ErrorSinkClosed)
+	})
+}
+
+func Test_SinkHttp(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			requests = gtype.NewInt()
+			bodies   = garray.NewStrArray(true)
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The first request fails and is retried.
+			if requests.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies.Append(string(body))
+			t.Assert(r.Header.Get("Content-Type"), "application/x-ndjson")
+			t.Assert(r.Header.Get("Authorization"), "token")
+		}))
+		defer server.Close()
+
+		sink, err := glog.NewSinkHttp(glog.SinkHttpOptions{
+			SinkOptions: glog.SinkOptions{
+				RetryInterval: 10 * time.Millisecond,
+			},
+			Url:     server.URL,
+			Headers: map[string]string{"Authorization": "token"},
+		})
+		t.Assert(err, nil)
+		sink.Write([]byte("a\n"))
+		sink.Write([]byte("b"))
+		t.Assert(sink.Flush(), nil)
+		t.Assert(requests.Val(), 2)
+		t.Assert(bodies.Len(), 1)
+		t.Assert(bodies.At(0), "a\nb\n")
+		t.Assert(sink.Close(), nil)
+	})
+}
+
+func Test_SinkSyslog(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		t.Assert(err, nil)
+		defer conn.Close()
+
+		l := glog.New()
+		l.SetStdoutPrint(false)
+		t.Assert(l.SetConfigWithMap(map[string]interface{}{
+			"sinks": []map[string]interface{}{{
+				"type":     "syslog",
+				"address":  conn.LocalAddr().String(),
+				"appName":  "myapp",
+				"hostname": "myhost",
+				"facility": 16,
+			}},
+		}), nil)
+		t.Assert(len(l.GetSinks()), 1)
+		l.Error("hello")
+		t.Assert(l.Flush(), nil)
+
+		buffer := make([]byte, 1024)
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := conn.ReadFrom(buffer)
+		t.Assert(err, nil)
+		message := string(buffer[:n])
+		// local0(16) * 8 + error(3) = 131
+		t.Assert(gstr.HasPrefix(message, "<131>1 "), true)
+		t.Assert(gstr.Contains(message, " myhost myapp "), true)
+		// The time and level are only in the syslog header.
+		t.Assert(gstr.Contains(message, " - - hello"), true)
+		t.Assert(gstr.Contains(message, "[ERRO]"), false)
+		t.Assert(l.Close(), nil)
+	})
+}
+
+func Test_Sink_Drop(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// Unreachable server, which makes the queue full.
+		sink, err := glog.NewSinkTcp(glog.SinkTcpOptions{
+			SinkOptions: glog.SinkOptions{
+				QueueSize:  1,
+				BatchSize:  1,
+				MaxRetries: -1,
+			},
+			Address: "127.0.0.1:1",
+		})
+		t.Assert(err, nil)
+		for i := 0; i < 100; i++ {
+			n, err := sink.Write([]byte("a"))
+			t.Assert(n, 1)
+			t.Assert(err, nil)
+		}
+		t.Assert(sink.Close(), nil)
+		t.Assert(sink.Dropped(), 100)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := glog.NewSinkWithMap(map[string]interface{}{"type": "unknown"})
+		t.AssertNE(err, nil)
+	})
+}
@@ -13,7 +13,8 @@ import (
 )
 
 var (
-	ErrorDisabled = errors.New("this feature is disabled in this storage")
+	ErrorDisabled     = errors.New("this feature is disabled in this storage")
+	ErrorSizeExceeded = errors.New("session data exceeds the size limit of this storage")
 )
 
 // NewSessionId creates and returns a new and unique session id string,
@@ -15,8 +15,9 @@ import (
 
 // Manager for sessions.
 type Manager struct {
-	ttl     time.Duration // TTL for sessions.
-	storage Storage       // Storage interface for session storage.
+	ttl         time.Duration // TTL for sessions, which is the idle timeout refreshed by every access.
+	maxLifetime time.Duration // Absolute lifetime for sessions since created, 0 means no limits.
+	storage     Storage       // Storage interface for session storage.
 
 	// sessionData is the memory data cache for session TTL,
 	// which is available only if the Storage does not stores any session data in synchronizing.
@@ -67,6 +68,101 @@ func (m *Manager) TTL() time.Duration {
 	return m.ttl
 }
 
+// SetMaxLifetime sets the absolute lifetime for sessions, after which the session expires since
+// it's created no matter whether it is accessed, which is 0 in default meaning no limits.
+//
+// Note that it only takes effect for sessions created after it is set.
+func (m *Manager) SetMaxLifetime(lifetime time.Duration) {
+	m.maxLifetime = lifetime
+}
+
+// MaxLifetime returns the absolute lifetime for sessions.
+func (m *Manager) MaxLifetime() time.Duration {
+	return m.maxLifetime
+}
+
+// UserSessionIds returns the ids of alive sessions bound to user <uid> by Session.SetUser.
+// The ids of expired sessions are deleted from the index in this function.
+//
+// It returns ErrorDisabled if the storage does not implement StorageUserIndexer.
+func (m *Manager) UserSessionIds(uid string) ([]string, error) {
+	indexer, ok := m.storage.(StorageUserIndexer)
+	if !ok {
+		return nil, ErrorDisabled
+	}
+	ids, err := indexer.GetUserSessions(uid)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		aliveIds   = make([]string, 0, len(ids))
+		expiredIds = make([]string, 0)
+	)
+	for _, id := range ids {
+		if m.exists(id) {
+			aliveIds = append(aliveIds, id)
+		} else {
+			expiredIds = append(expiredIds, id)
+		}
+	}
+	if len(expiredIds) > 0 {
+		if err = indexer.RemoveUserSession(uid, expiredIds...); err != nil {
+			return nil, err
+		}
+	}
+	return aliveIds, nil
+}
+
+// RevokeUser destroys all sessions bound to user <uid> by Session.SetUser,
+// which can be used for logging out the user everywhere.
+//
+// It returns ErrorDisabled if the storage does not implement StorageUserIndexer.
+func (m *Manager) RevokeUser(uid string) error {
+	indexer, ok := m.storage.(StorageUserIndexer)
+	if !ok {
+		return ErrorDisabled
+	}
+	ids, err := indexer.GetUserSessions(uid)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err = m.destroy(id, ""); err != nil {
+			return err
+		}
+	}
+	return indexer.RemoveUserSession(uid, ids...)
+}
+
+// exists checks whether the session of <id> exists and is not expired.
+func (m *Manager) exists(id string) bool {
+	var data *gmap.StrAnyMap
+	if r, _ := m.sessionData.Get(id); r != nil {
+		data = r.(*gmap.StrAnyMap)
+	}
+	data, err := m.storage.GetSession(id, m.ttl, data)
+	return err == nil && data != nil
+}
+
+// destroy deletes the session of <id> from memory and storage, and deletes it from the index
+// of user <uid> if <uid> is not empty.
+func (m *Manager) destroy(id string, uid string) error {
+	if _, err := m.sessionData.Remove(id); err != nil {
+		return err
+	}
+	if destroyer, ok := m.storage.(StorageDestroyer); ok {
+		if err := destroyer.Destroy(id); err != nil {
+			return err
+		}
+	} else if err := m.storage.RemoveAll(id); err != nil && err != ErrorDisabled {
+		return err
+	}
+	if indexer, ok := m.storage.(StorageUserIndexer); ok && uid != "" {
+		return indexer.RemoveUserSession(uid, id)
+	}
+	return nil
+}
+
 // UpdateSessionTTL updates the ttl for given session.
 func (m *Manager) UpdateSessionTTL(sessionId string, data *gmap.StrAnyMap) {
 	m.sessionData.Set(sessionId, data, m.ttl)
@@ -31,6 +31,15 @@ type Session struct {
 	idFunc func(ttl time.Duration) (id string)
 }
 
+const (
+	// Reserved session key for the creating timestamp in milliseconds of the session,
+	// which is used for the absolute lifetime.
+	sessionKeyCreatedAt = "_gf_session_created_at"
+
+	// Reserved session key for the user id bound to the session.
+	sessionKeyUserId = "_gf_session_uid"
+)
+
 // init does the lazy initialization for session.
 // It here initializes real session if necessary.
 func (s *Session) init() {
@@ -49,6 +58,25 @@ func (s *Session) init() {
 			if s.data, err = s.manager.storage.GetSession(s.id, s.manager.ttl, s.data); err != nil {
 				intlog.Errorf("session restoring failed for id '%s': %v", s.id, err)
 			}
+			// The session id carrying the session data is marked dirty for re-encoding,
+			// which extends its TTL.
+			if refresher, ok := s.manager.storage.(StorageRefresher); ok && s.data != nil {
+				if refresher.NeedRefresh(s.id, s.manager.ttl) {
+					s.dirty = true
+				}
+			}
+		}
+		// Destroy the session if it exceeds its absolute lifetime.
+		if s.data != nil && s.manager.maxLifetime > 0 {
+			createdAt := gconv.Int64(s.getReserved(sessionKeyCreatedAt))
+			if createdAt > 0 && createdAt+s.manager.maxLifetime.Nanoseconds()/1e6 < gtime.TimestampMilli() {
+				uid := gconv.String(s.getReserved(sessionKeyUserId))
+				if err = s.manager.destroy(s.id, uid); err != nil {
+					intlog.Errorf("session destroying failed for id '%s': %v", s.id, err)
+				}
+				s.id = ""
+				s.data = nil
+			}
 		}
 	}
 	// Use custom session id creating function.
@@ -86,6 +114,19 @@ func (s *Session) Close() {
 					panic(err)
 				}
 			}
+			// The user index expires along with the sessions of the user.
+			if updater, ok := s.manager.storage.(StorageUserIndexUpdater); ok && size > 0 {
+				if uid := gconv.String(s.getReserved(sessionKeyUserId)); uid != "" {
+					if err := updater.UpdateUserTTL(uid, s.manager.ttl); err != nil {
+						intlog.Errorf("user index TTL updating failed for user '%s': %v", uid, err)
+					}
+				}
+			}
+		}
+		// The session data is carried by the session id for StorageEncoder,
+		// which needs no caching in memory.
+		if _, ok := s.manager.storage.(StorageEncoder); ok {
+			return
 		}
 		if s.dirty || size > 0 {
 			s.manager.UpdateSessionTTL(s.id, s.data)
@@ -93,6 +134,27 @@ func (s *Session) Close() {
 	}
 }
 
+// Encode re-encodes the session id from the session data if the session is dirty and the
+// storage implements StorageEncoder, like StorageCookie. It does nothing for other storages.
+//
+// Note that it should be called before the session id is sent to client.
+func (s *Session) Encode() error {
+	if !s.dirty {
+		return nil
+	}
+	encoder, ok := s.manager.storage.(StorageEncoder)
+	if !ok {
+		return nil
+	}
+	s.init()
+	id, err := encoder.Encode(s.data, s.manager.ttl)
+	if err != nil {
+		return err
+	}
+	s.id = id
+	return nil
+}
+
 // Set sets key-value pair to this session.
 func (s *Session) Set(key string, value interface{}) error {
 	s.init()
@@ -104,7 +166,7 @@ func (s *Session) Set(key string, value interface{}) error {
 		}
 	}
 	s.dirty = true
-	return nil
+	return s.markCreated()
 }
 
 // Sets batch sets the session using map.
@@ -124,7 +186,7 @@ func (s *Session) SetMap(data map[string]interface{}) error {
 		}
 	}
 	s.dirty = true
-	return nil
+	return s.markCreated()
 }
 
 // Remove removes key along with its value from this session.
@@ -200,10 +262,13 @@ func (s *Session) SetIdFunc(f func(ttl time.Duration) string) error {
 func (s *Session) Map() map[string]interface{} {
 	if s.id != "" {
 		s.init()
-		if data := s.manager.storage.GetMap(s.id); data != nil {
-			return data
+		data := s.manager.storage.GetMap(s.id)
+		if data == nil {
+			data = s.data.Map()
 		}
-		return s.data.Map()
+		delete(data, sessionKeyCreatedAt)
+		delete(data, sessionKeyUserId)
+		return data
 	}
 	return nil
 }
@@ -212,14 +277,121 @@ func (s *Session) Map() map[string]interface{} {
 func (s *Session) Size() int {
 	if s.id != "" {
 		s.init()
-		if size := s.manager.storage.GetSize(s.id); size >= 0 {
-			return size
+		size := s.manager.storage.GetSize(s.id)
+		if size < 0 {
+			size = s.data.Size()
+		}
+		for _, key := range []string{sessionKeyCreatedAt, sessionKeyUserId} {
+			if size > 0 && s.getReserved(key) != nil {
+				size--
+			}
 		}
-		return s.data.Size()
+		return size
 	}
 	return 0
 }
 
+// Regenerate creates a new session id for current session and migrates all session data
+// to the new id, and then destroys the session of old id. It is usually called when user
+// logs in or its privilege changes, which protects the session from session fixation.
+func (s *Session) Regenerate() error {
+	s.init()
+	var (
+		oldId = s.id
+		data  = s.manager.storage.GetMap(oldId)
+	)
+	if data == nil {
+		data = s.data.MapCopy()
+	}
+	// The old session data is not shared by the new session.
+	s.data = gmap.NewStrAnyMapFrom(data, true)
+	s.id = ""
+	if s.idFunc != nil {
+		s.id = s.idFunc(s.manager.ttl)
+	}
+	if s.id == "" {
+		s.id = s.manager.storage.New(s.manager.ttl)
+	}
+	if s.id == "" || s.id == oldId {
+		s.id = NewSessionId()
+	}
+	if err := s.manager.storage.SetMap(s.id, data, s.manager.ttl); err != nil && err != ErrorDisabled {
+		return err
+	}
+	s.dirty = true
+	uid := s.User()
+	if err := s.manager.destroy(oldId, uid); err != nil {
+		return err
+	}
+	// Updating the user index with new session id.
+	if uid != "" {
+		if indexer, ok := s.manager.storage.(StorageUserIndexer); ok {
+			return indexer.AddUserSession(uid, s.id, s.manager.ttl)
+		}
+	}
+	return nil
+}
+
+// SetUser binds current session to user <uid>, and adds the session id to the user index
+// if the storage implements StorageUserIndexer, so that all sessions of the user can be
+// revoked by Manager.RevokeUser.
+//
+// It is recommended calling Regenerate before binding user to the session when user logs in.
+func (s *Session) SetUser(uid string) error {
+	if err := s.Set(sessionKeyUserId, uid); err != nil {
+		return err
+	}
+	if indexer, ok := s.manager.storage.(StorageUserIndexer); ok {
+		// Cleaning up the expired session ids of the user.
+		if _, err := s.manager.UserSessionIds(uid); err != nil {
+			return err
+		}
+		return indexer.AddUserSession(uid, s.id, s.manager.ttl)
+	}
+	return nil
+}
+
+// User returns the user id bound to current session by SetUser.
+func (s *Session) User() string {
+	if s.id == "" {
+		return ""
+	}
+	s.init()
+	return gconv.String(s.getReserved(sessionKeyUserId))
+}
+
+// markCreated marks the creating time of the session for absolute lifetime if it's not marked.
+func (s *Session) markCreated() error {
+	if s.manager.maxLifetime <= 0 || s.getReserved(sessionKeyCreatedAt) != nil {
+		return nil
+	}
+	createdAt := gtime.TimestampMilli()
+	if err := s.manager.storage.Set(s.id, sessionKeyCreatedAt, createdAt, s.manager.ttl); err != nil {
+		if err == ErrorDisabled {
+			s.data.Set(sessionKeyCreatedAt, createdAt)
+		} else {
+			return err
+		}
+	}
+	return nil
+}
+
+// getReserved retrieves the value of reserved key from storage or memory data.
+func (s *Session) getReserved(key string) interface{} {
+	if v := s.manager.storage.Get(s.id, key); v != nil {
+		return v
+	}
+	if s.data != nil {
+		return s.data.Get(key)
+	}
+	return nil
+}
+
+// isReservedKey checks whether <key> is a reserved session key, which is invisible to users.
+func isReservedKey(key string) bool {
+	return key == sessionKeyCreatedAt || key == sessionKeyUserId
+}
+
 // Contains checks whether key exist in the session.
 func (s *Session) Contains(key string) bool {
 	s.init()
@@ -239,11 +411,13 @@ func (s *Session) Get(key string, def ...interface{}) interface{} {
 		return nil
 	}
 	s.init()
-	if v := s.manager.storage.Get(s.id, key); v != nil {
-		return v
-	}
-	if v := s.data.Get(key); v != nil {
-		return v
+	if !isReservedKey(key) {
+		if v := s.manager.storage.Get(s.id, key); v != nil {
+			return v
+		}
+		if v := s.data.Get(key); v != nil {
+			return v
+		}
 	}
 	if len(def) > 0 {
 		return def[0]
@@ -59,3 +59,50 @@ type Storage interface {
 	// This function is called ever after session, which is not dirty, is closed.
 	UpdateTTL(id string, ttl time.Duration) error
 }
+
+// StorageEncoder is the interface for session storage which stores the session data in the
+// session id itself, like StorageCookie. The session id is re-encoded from the session data
+// after the session is changed.
+type StorageEncoder interface {
+	// Encode encodes the session data to a new session id.
+	Encode(data *gmap.StrAnyMap, ttl time.Duration) (id string, err error)
+}
+
+// StorageRefresher is the interface for StorageEncoder storage whose session id carries the
+// timestamp of the session TTL, which needs re-encoding the session id to extend the TTL when
+// the session is accessed but not changed.
+type StorageRefresher interface {
+	// NeedRefresh reports whether session id <id> should be re-encoded for extending its TTL.
+	NeedRefresh(id string, ttl time.Duration) bool
+}
+
+// StorageDestroyer is the interface for session storage which is able to delete the whole
+// session from storage, which is used for session regenerating and revoking.
+type StorageDestroyer interface {
+	// Destroy deletes the session data of given session id from storage.
+	Destroy(id string) error
+}
+
+// StorageUserIndexer is the interface for session storage which maintains the index from
+// user id to its session ids, which enables listing and revoking all sessions of a user.
+type StorageUserIndexer interface {
+	// AddUserSession adds session id <id> to the index of user <uid>.
+	// The parameter <ttl> specifies the TTL of the session, the index should be kept at least
+	// <ttl> for storage which expires the index.
+	AddUserSession(uid string, id string, ttl time.Duration) error
+
+	// RemoveUserSession deletes session ids <ids> from the index of user <uid>.
+	RemoveUserSession(uid string, ids ...string) error
+
+	// GetUserSessions returns all session ids in the index of user <uid>,
+	// which might contain the ids of expired sessions.
+	GetUserSessions(uid string) ([]string, error)
+}
+
+// StorageUserIndexUpdater is the interface for StorageUserIndexer storage which expires the
+// user index, the TTL of which should be extended along with the sessions of the user.
+type StorageUserIndexUpdater interface {
+	// UpdateUserTTL updates the TTL for the index of user <uid>.
+	// This function is called ever after session, which is bound to the user, is closed.
+	UpdateUserTTL(uid string, ttl time.Duration) error
+}
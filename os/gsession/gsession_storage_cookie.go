@@ -0,0 +1,246 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/container/gmap"
+	"github.com/gogf/gf/crypto/gaes"
+	"github.com/gogf/gf/encoding/gbinary"
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/internal/json"
+	"github.com/gogf/gf/os/gtime"
+)
+
+// StorageCookie implements the Session Storage interface with cookie, which stores the
+// session data in the session id itself and keeps no data in server side.
+//
+// The session id is in format "<body>.<signature>", in which the body contains the updated
+// timestamp and the json data of the session, optionally encrypted with AES-GCM, and the
+// signature is the HMAC-SHA256 of the body.
+//
+// The TTL of the session is an idle TTL like other storages, as the session id is re-encoded
+// with a fresh timestamp when the session is accessed after half of the TTL.
+type StorageCookie struct {
+	hashKeys   [][]byte // HMAC keys, the first one for signing.
+	cryptoKeys [][]byte // AES-GCM keys, the first one for encrypting.
+	maxSize    int      // Max size of the session id.
+}
+
+// StorageCookieOptions is the options for cookie storage.
+type StorageCookieOptions struct {
+	// HashKeys are the HMAC keys, which is required.
+	// The first key is used for signing, and all keys are accepted for verifying,
+	// so the new key can be prepended for key rotation.
+	HashKeys [][]byte
+
+	// CryptoKeys are the AES-GCM keys in 16/24/32 bytes, which enables the encryption if given.
+	// The first key is used for encrypting, and all keys are tried for decrypting.
+	CryptoKeys [][]byte
+
+	// MaxSize is the max size of the session id, which is DefaultStorageCookieMaxSize in default.
+	MaxSize int
+}
+
+var (
+	// DefaultStorageCookieMaxSize is the default max size of the session id for cookie storage,
+	// as most browsers limit the size of a cookie to 4KB including its name and attributes.
+	DefaultStorageCookieMaxSize = 4000
+)
+
+// NewStorageCookie creates and returns a cookie storage object for session.
+// It panics if no hash key given, or any crypto key is invalid.
+func NewStorageCookie(options StorageCookieOptions) *StorageCookie {
+	if len(options.HashKeys) == 0 || len(options.HashKeys[0]) == 0 {
+		panic(gerror.New("hash key is required for cookie storage"))
+	}
+	for _, key := range options.CryptoKeys {
+		if n := len(key); n != 16 && n != 24 && n != 32 {
+			panic(gerror.Newf("invalid crypto key size %d, which should be 16, 24 or 32", n))
+		}
+	}
+	s := &StorageCookie{
+		hashKeys:   options.HashKeys,
+		cryptoKeys: options.CryptoKeys,
+		maxSize:    options.MaxSize,
+	}
+	if s.maxSize <= 0 {
+		s.maxSize = DefaultStorageCookieMaxSize
+	}
+	return s
+}
+
+// New creates a session id.
+// This function can be used for custom session creation.
+func (s *StorageCookie) New(ttl time.Duration) (id string) {
+	return ""
+}
+
+// Get retrieves session value with given key.
+// It returns nil if the key does not exist in the session.
+func (s *StorageCookie) Get(id string, key string) interface{} {
+	return nil
+}
+
+// GetMap retrieves all key-value pairs as map from storage.
+func (s *StorageCookie) GetMap(id string) map[string]interface{} {
+	return nil
+}
+
+// GetSize retrieves the size of key-value pairs from storage.
+func (s *StorageCookie) GetSize(id string) int {
+	return -1
+}
+
+// Set sets key-value session pair to the storage.
+// The parameter <ttl> specifies the TTL for the session id (not for the key-value pair).
+func (s *StorageCookie) Set(id string, key string, value interface{}, ttl time.Duration) error {
+	return ErrorDisabled
+}
+
+// SetMap batch sets key-value session pairs with map to the storage.
+// The parameter <ttl> specifies the TTL for the session id(not for the key-value pair).
+func (s *StorageCookie) SetMap(id string, data map[string]interface{}, ttl time.Duration) error {
+	return ErrorDisabled
+}
+
+// Remove deletes key with its value from storage.
+func (s *StorageCookie) Remove(id string, key string) error {
+	return ErrorDisabled
+}
+
+// RemoveAll deletes all key-value pairs from storage.
+func (s *StorageCookie) RemoveAll(id string) error {
+	return ErrorDisabled
+}
+
+// GetSession decodes and returns the session data as *gmap.StrAnyMap from given session id.
+//
+// The parameter <ttl> specifies the TTL for this session, and it returns nil if the TTL is exceeded.
+// It returns error if the session id is not signed or encrypted by the keys of the storage.
+//
+// This function is called ever when session starts.
+func (s *StorageCookie) GetSession(id string, ttl time.Duration, data *gmap.StrAnyMap) (*gmap.StrAnyMap, error) {
+	content, err := s.decode(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < 8 {
+		return nil, gerror.New("invalid session content")
+	}
+	timestampMilli := gbinary.DecodeToInt64(content[:8])
+	if timestampMilli+ttl.Nanoseconds()/1e6 < gtime.TimestampMilli() {
+		return nil, nil
+	}
+	var m map[string]interface{}
+	if err = json.UnmarshalUseNumber(content[8:], &m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, nil
+	}
+	return gmap.NewStrAnyMapFrom(m, true), nil
+}
+
+// SetSession does nothing, as the session data is stored in the session id by Encode.
+func (s *StorageCookie) SetSession(id string, data *gmap.StrAnyMap, ttl time.Duration) error {
+	return nil
+}
+
+// UpdateTTL does nothing, as the TTL of the session is extended by re-encoding the session id
+// with a fresh timestamp, which is done by Session.Encode if NeedRefresh returns true.
+func (s *StorageCookie) UpdateTTL(id string, ttl time.Duration) error {
+	return nil
+}
+
+// NeedRefresh reports whether session id <id> should be re-encoded with a fresh timestamp,
+// which is true if half of the <ttl> has elapsed since it was encoded. It limits the cookie
+// re-issuing rate for sessions being accessed frequently but not changed.
+func (s *StorageCookie) NeedRefresh(id string, ttl time.Duration) bool {
+	content, err := s.decode(id)
+	if err != nil || len(content) < 8 {
+		return false
+	}
+	timestampMilli := gbinary.DecodeToInt64(content[:8])
+	return timestampMilli+ttl.Nanoseconds()/1e6/2 <= gtime.TimestampMilli()
+}
+
+// Encode encodes the session data to a new session id, which is signed and optionally encrypted.
+// It returns ErrorSizeExceeded if the size of the session id exceeds the max size.
+func (s *StorageCookie) Encode(data *gmap.StrAnyMap, ttl time.Duration) (id string, err error) {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	content = append(gbinary.EncodeInt64(gtime.TimestampMilli()), content...)
+	// Encrypt with AES-GCM.
+	if len(s.cryptoKeys) > 0 {
+		if content, err = gaes.EncryptGCM(content, s.cryptoKeys[0]); err != nil {
+			return "", err
+		}
+	}
+	body := base64.RawURLEncoding.EncodeToString(content)
+	id = body + "." + base64.RawURLEncoding.EncodeToString(s.sign(body, s.hashKeys[0]))
+	if len(id) > s.maxSize {
+		return "", gerror.Wrapf(
+			ErrorSizeExceeded, "session id size %d exceeds the max size %d", len(id), s.maxSize,
+		)
+	}
+	return id, nil
+}
+
+// decode verifies the signature of session <id> and returns its decrypted content.
+func (s *StorageCookie) decode(id string) ([]byte, error) {
+	if len(id) > s.maxSize {
+		return nil, ErrorSizeExceeded
+	}
+	pos := strings.LastIndexByte(id, '.')
+	if pos == -1 {
+		return nil, gerror.New("invalid session id format")
+	}
+	body := id[:pos]
+	signature, err := base64.RawURLEncoding.DecodeString(id[pos+1:])
+	if err != nil {
+		return nil, err
+	}
+	verified := false
+	for _, key := range s.hashKeys {
+		if hmac.Equal(signature, s.sign(body, key)) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, gerror.New("invalid session id signature")
+	}
+	content, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.cryptoKeys) == 0 {
+		return content, nil
+	}
+	// Decrypt with AES-GCM.
+	for _, key := range s.cryptoKeys {
+		if decrypted, err := gaes.DecryptGCM(content, key); err == nil {
+			return decrypted, nil
+		}
+	}
+	return nil, gerror.New("session id decryption failed")
+}
+
+// sign returns the HMAC-SHA256 signature of <body> using <key>.
+func (s *StorageCookie) sign(body string, key []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(body))
+	return h.Sum(nil)
+}
@@ -0,0 +1,304 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/container/gmap"
+	"github.com/gogf/gf/database/gdb"
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/internal/intlog"
+	"github.com/gogf/gf/internal/json"
+	"github.com/gogf/gf/os/gtime"
+	"github.com/gogf/gf/os/gtimer"
+)
+
+// StorageDb implements the Session Storage interface with database using gdb.
+//
+// The session data is stored as json in table rows along with its expiring timestamp,
+// and the expired rows are deleted periodically by the sweeper.
+type StorageDb struct {
+	db            gdb.DB          // Database for session storage.
+	table         string          // Table name without prefix.
+	updatingIdMap *gmap.StrIntMap // Updating TTL in seconds for session id.
+}
+
+const (
+	defaultStorageDbTable = "gf_session"
+)
+
+var (
+	// DefaultStorageDbLoopInterval is the interval updating TTL for session ids
+	// in last duration.
+	DefaultStorageDbLoopInterval = 10 * time.Second
+
+	// DefaultStorageDbSweepInterval is the interval deleting the expired sessions.
+	DefaultStorageDbSweepInterval = time.Minute
+)
+
+// NewStorageDb creates and returns a database storage object for session.
+// The optional parameter <table> specifies the table name without prefix, which is "gf_session" in default.
+//
+// The table is created automatically if it does not exist, which is supported for mysql, pgsql
+// and sqlite. For other databases, please create the table manually with the columns:
+// id(varchar primary key), data(text) and expire_at(bigint, expiring timestamp in milliseconds).
+func NewStorageDb(db gdb.DB, table ...string) *StorageDb {
+	if db == nil {
+		panic("db instance for storage cannot be empty")
+	}
+	s := &StorageDb{
+		db:            db,
+		table:         defaultStorageDbTable,
+		updatingIdMap: gmap.NewStrIntMap(true),
+	}
+	if len(table) > 0 && table[0] != "" {
+		s.table = table[0]
+	}
+	if err := s.createTable(); err != nil {
+		panic(err)
+	}
+	// Batch updates the TTL for session ids timely.
+	gtimer.AddSingleton(DefaultStorageDbLoopInterval, s.updateSessionTimely)
+	// Deletes the expired sessions timely.
+	gtimer.AddSingleton(DefaultStorageDbSweepInterval, func() {
+		if err := s.sweep(); err != nil {
+			intlog.Error(err)
+		}
+	})
+	return s
+}
+
+// New creates a session id.
+// This function can be used for custom session creation.
+func (s *StorageDb) New(ttl time.Duration) (id string) {
+	return ""
+}
+
+// Get retrieves session value with given key.
+// It returns nil if the key does not exist in the session.
+func (s *StorageDb) Get(id string, key string) interface{} {
+	return nil
+}
+
+// GetMap retrieves all key-value pairs as map from storage.
+func (s *StorageDb) GetMap(id string) map[string]interface{} {
+	return nil
+}
+
+// GetSize retrieves the size of key-value pairs from storage.
+func (s *StorageDb) GetSize(id string) int {
+	return -1
+}
+
+// Set sets key-value session pair to the storage.
+// The parameter <ttl> specifies the TTL for the session id (not for the key-value pair).
+func (s *StorageDb) Set(id string, key string, value interface{}, ttl time.Duration) error {
+	return ErrorDisabled
+}
+
+// SetMap batch sets key-value session pairs with map to the storage.
+// The parameter <ttl> specifies the TTL for the session id(not for the key-value pair).
+func (s *StorageDb) SetMap(id string, data map[string]interface{}, ttl time.Duration) error {
+	return ErrorDisabled
+}
+
+// Remove deletes key with its value from storage.
+func (s *StorageDb) Remove(id string, key string) error {
+	return ErrorDisabled
+}
+
+// RemoveAll deletes all key-value pairs from storage.
+func (s *StorageDb) RemoveAll(id string) error {
+	return ErrorDisabled
+}
+
+// GetSession returns the session data as *gmap.StrAnyMap for given session id from storage.
+//
+// The parameter <ttl> specifies the TTL for this session, and it returns nil if the TTL is exceeded.
+// The parameter <data> is the current old session data stored in memory,
+// and for some storage it might be nil if memory storage is disabled.
+//
+// This function is called ever when session starts.
+func (s *StorageDb) GetSession(id string, ttl time.Duration, data *gmap.StrAnyMap) (*gmap.StrAnyMap, error) {
+	if data != nil {
+		return data, nil
+	}
+	intlog.Printf("StorageDb.GetSession: %s, %v", id, ttl)
+	value, err := s.model().
+		Fields("data").
+		Where("id", id).
+		Where("expire_at>=?", gtime.TimestampMilli()).
+		Value()
+	if err != nil {
+		return nil, err
+	}
+	content := value.Bytes()
+	if len(content) == 0 {
+		return nil, nil
+	}
+	var m map[string]interface{}
+	if err = json.UnmarshalUseNumber(content, &m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, nil
+	}
+	return gmap.NewStrAnyMapFrom(m, true), nil
+}
+
+// SetSession updates the data map for specified session id.
+// This function is called ever after session, which is changed dirty, is closed.
+// This copy all session data map from memory to storage.
+func (s *StorageDb) SetSession(id string, data *gmap.StrAnyMap, ttl time.Duration) error {
+	intlog.Printf("StorageDb.SetSession: %s, %v, %v", id, data, ttl)
+	content, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	record := gdb.Map{
+		"data":      string(content),
+		"expire_at": s.expireAt(ttl),
+	}
+	// It updates the row first, and inserts it if it does not exist,
+	// which is compatible with all kinds of database.
+	result, err := s.model().Data(record).Where("id", id).Update()
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n > 0 {
+		return nil
+	}
+	record["id"] = id
+	if _, err = s.model().Data(record).Insert(); err != nil {
+		// The row might be inserted concurrently, just updating it.
+		delete(record, "id")
+		if _, updateErr := s.model().Data(record).Where("id", id).Update(); updateErr != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateTTL updates the TTL for specified session id.
+// This function is called ever after session, which is not dirty, is closed.
+// It just adds the session id to the async handling queue, which updates only the expiring
+// timestamp without rewriting the session data.
+func (s *StorageDb) UpdateTTL(id string, ttl time.Duration) error {
+	intlog.Printf("StorageDb.UpdateTTL: %s, %v", id, ttl)
+	if ttl >= DefaultStorageDbLoopInterval {
+		s.updatingIdMap.Set(id, int(ttl.Seconds()))
+		return nil
+	}
+	return s.doUpdateTTL(ttl, id)
+}
+
+// Destroy deletes the session row of given session id.
+func (s *StorageDb) Destroy(id string) error {
+	s.updatingIdMap.Remove(id)
+	_, err := s.model().Where("id", id).Delete()
+	return err
+}
+
+// updateSessionTimely batch updates the TTL for sessions timely, grouping session ids by TTL.
+func (s *StorageDb) updateSessionTimely() {
+	var (
+		id         string
+		ttlSeconds int
+		ttlIds     = make(map[int][]string)
+	)
+	for {
+		if id, ttlSeconds = s.updatingIdMap.Pop(); id == "" {
+			break
+		}
+		ttlIds[ttlSeconds] = append(ttlIds[ttlSeconds], id)
+	}
+	for ttlSeconds, ids := range ttlIds {
+		if err := s.doUpdateTTL(time.Duration(ttlSeconds)*time.Second, ids...); err != nil {
+			intlog.Error(err)
+		}
+	}
+}
+
+// doUpdateTTL updates the expiring timestamp for session ids.
+func (s *StorageDb) doUpdateTTL(ttl time.Duration, ids ...string) error {
+	intlog.Printf("StorageDb.doUpdateTTL: %v, %v", ids, ttl)
+	_, err := s.model().Data("expire_at", s.expireAt(ttl)).Where("id", ids).Update()
+	return err
+}
+
+// sweep deletes all expired sessions.
+func (s *StorageDb) sweep() error {
+	_, err := s.model().Where("expire_at<?", gtime.TimestampMilli()).Delete()
+	return err
+}
+
+// createTable creates the session table if it does not exist.
+func (s *StorageDb) createTable() error {
+	var (
+		ctx   = context.TODO()
+		table = s.db.GetPrefix() + s.table
+	)
+	tables, err := s.db.Tables(ctx)
+	if err != nil {
+		return err
+	}
+	for _, v := range tables {
+		if v == table {
+			return nil
+		}
+	}
+	var (
+		charLeft, charRight = s.db.GetChars()
+		quotedTable         = charLeft + table + charRight
+		sqls                []string
+	)
+	switch s.db.GetConfig().Type {
+	case "mysql", "mariadb", "tidb":
+		sqls = []string{fmt.Sprintf(
+			"CREATE TABLE IF NOT EXISTS %s ("+
+				"`id` VARCHAR(128) NOT NULL, `data` MEDIUMTEXT NOT NULL, `expire_at` BIGINT NOT NULL, "+
+				"PRIMARY KEY (`id`), KEY `idx_expire_at` (`expire_at`)"+
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4",
+			quotedTable,
+		)}
+	case "pgsql", "sqlite":
+		sqls = []string{
+			fmt.Sprintf(
+				`CREATE TABLE IF NOT EXISTS %s (id VARCHAR(128) NOT NULL PRIMARY KEY, data TEXT NOT NULL, expire_at BIGINT NOT NULL)`,
+				quotedTable,
+			),
+			fmt.Sprintf(
+				`CREATE INDEX IF NOT EXISTS %s ON %s (expire_at)`,
+				charLeft+table+"_expire_at"+charRight, quotedTable,
+			),
+		}
+	default:
+		return gerror.Newf(
+			`table "%s" does not exist and cannot be created automatically for database type "%s"`,
+			table, s.db.GetConfig().Type,
+		)
+	}
+	for _, sql := range sqls {
+		if _, err = s.db.Exec(sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// model returns the model of the session table.
+func (s *StorageDb) model() *gdb.Model {
+	return s.db.Model(s.table).Safe()
+}
+
+// expireAt returns the expiring timestamp in milliseconds for <ttl>.
+func (s *StorageDb) expireAt(ttl time.Duration) int64 {
+	return gtime.TimestampMilli() + ttl.Nanoseconds()/1e6
+}
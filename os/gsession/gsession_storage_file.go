@@ -8,10 +8,12 @@ package gsession
 
 import (
 	"github.com/gogf/gf/container/gmap"
+	"github.com/gogf/gf/crypto/gmd5"
 	"github.com/gogf/gf/errors/gerror"
 	"github.com/gogf/gf/internal/intlog"
 	"github.com/gogf/gf/internal/json"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/gogf/gf/crypto/gaes"
@@ -32,6 +34,7 @@ type StorageFile struct {
 	cryptoKey     []byte
 	cryptoEnabled bool
 	updatingIdSet *gset.StrSet
+	userMu        sync.Mutex // Mutex for user index files.
 }
 
 var (
@@ -230,6 +233,92 @@ func (s *StorageFile) UpdateTTL(id string, ttl time.Duration) error {
 	return nil
 }
 
+// Destroy deletes the session file of given session id.
+func (s *StorageFile) Destroy(id string) error {
+	s.updatingIdSet.Remove(id)
+	path := s.sessionFilePath(id)
+	if !gfile.Exists(path) {
+		return nil
+	}
+	return gfile.Remove(path)
+}
+
+// AddUserSession adds session id <id> to the index file of user <uid>.
+func (s *StorageFile) AddUserSession(uid string, id string, ttl time.Duration) error {
+	s.userMu.Lock()
+	defer s.userMu.Unlock()
+	ids, err := s.getUserSessions(uid)
+	if err != nil {
+		return err
+	}
+	for _, v := range ids {
+		if v == id {
+			return nil
+		}
+	}
+	return s.setUserSessions(uid, append(ids, id))
+}
+
+// RemoveUserSession deletes session ids <ids> from the index file of user <uid>.
+func (s *StorageFile) RemoveUserSession(uid string, ids ...string) error {
+	s.userMu.Lock()
+	defer s.userMu.Unlock()
+	oldIds, err := s.getUserSessions(uid)
+	if err != nil {
+		return err
+	}
+	removingSet := gset.NewStrSetFrom(ids)
+	newIds := make([]string, 0, len(oldIds))
+	for _, id := range oldIds {
+		if !removingSet.Contains(id) {
+			newIds = append(newIds, id)
+		}
+	}
+	return s.setUserSessions(uid, newIds)
+}
+
+// GetUserSessions returns all session ids in the index file of user <uid>.
+func (s *StorageFile) GetUserSessions(uid string) ([]string, error) {
+	s.userMu.Lock()
+	defer s.userMu.Unlock()
+	return s.getUserSessions(uid)
+}
+
+// userFilePath returns the index file path for given user id.
+func (s *StorageFile) userFilePath(uid string) string {
+	return gfile.Join(s.path, "users", gmd5.MustEncryptString(uid))
+}
+
+// getUserSessions reads the session ids from the index file of user <uid>.
+func (s *StorageFile) getUserSessions(uid string) ([]string, error) {
+	content := gfile.GetBytes(s.userFilePath(uid))
+	if len(content) == 0 {
+		return nil, nil
+	}
+	var ids []string
+	if err := json.Unmarshal(content, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// setUserSessions writes the session ids to the index file of user <uid>,
+// it deletes the index file if <ids> is empty.
+func (s *StorageFile) setUserSessions(uid string, ids []string) error {
+	path := s.userFilePath(uid)
+	if len(ids) == 0 {
+		if gfile.Exists(path) {
+			return gfile.Remove(path)
+		}
+		return nil
+	}
+	content, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	return gfile.PutBytes(path, content)
+}
+
 // updateSessionTTL updates the TTL for specified session id.
 func (s *StorageFile) updateSessionTTl(id string) error {
 	intlog.Printf("StorageFile.updateSession: %s", id)
@@ -8,15 +8,20 @@ package gsession
 
 import (
 	"github.com/gogf/gf/container/gmap"
+	"github.com/gogf/gf/container/gset"
 	"time"
 )
 
 // StorageMemory implements the Session Storage interface with memory.
-type StorageMemory struct{}
+type StorageMemory struct {
+	users *gmap.StrAnyMap // User id to its session id set, which is type of *gset.StrSet.
+}
 
 // NewStorageMemory creates and returns a file storage object for session.
 func NewStorageMemory() *StorageMemory {
-	return &StorageMemory{}
+	return &StorageMemory{
+		users: gmap.NewStrAnyMap(true),
+	}
 }
 
 // New creates a session id.
@@ -88,6 +93,46 @@ func (s *StorageMemory) UpdateTTL(id string, ttl time.Duration) error {
 	return nil
 }
 
+// Destroy does nothing, as the session data is stored in the memory of session manager.
+func (s *StorageMemory) Destroy(id string) error {
+	return nil
+}
+
+// AddUserSession adds session id <id> to the index of user <uid>.
+func (s *StorageMemory) AddUserSession(uid string, id string, ttl time.Duration) error {
+	s.users.LockFunc(func(m map[string]interface{}) {
+		if _, ok := m[uid]; !ok {
+			m[uid] = gset.NewStrSet(true)
+		}
+		m[uid].(*gset.StrSet).Add(id)
+	})
+	return nil
+}
+
+// RemoveUserSession deletes session ids <ids> from the index of user <uid>.
+func (s *StorageMemory) RemoveUserSession(uid string, ids ...string) error {
+	s.users.LockFunc(func(m map[string]interface{}) {
+		if v, ok := m[uid]; ok {
+			set := v.(*gset.StrSet)
+			for _, id := range ids {
+				set.Remove(id)
+			}
+			if set.Size() == 0 {
+				delete(m, uid)
+			}
+		}
+	})
+	return nil
+}
+
+// GetUserSessions returns all session ids in the index of user <uid>.
+func (s *StorageMemory) GetUserSessions(uid string) ([]string, error) {
+	if v := s.users.Get(uid); v != nil {
+		return v.(*gset.StrSet).Slice(), nil
+	}
+	return nil, nil
+}
+
 // doUpdateTTL updates the TTL for session id.
 func (s *StorageMemory) doUpdateTTL(id string) error {
 	return nil
@@ -18,9 +18,10 @@ import (
 
 // StorageRedis implements the Session Storage interface with redis.
 type StorageRedis struct {
-	redis         *gredis.Redis   // Redis client for session storage.
-	prefix        string          // Redis key prefix for session id.
-	updatingIdMap *gmap.StrIntMap // Updating TTL set for session id.
+	redis           *gredis.Redis   // Redis client for session storage.
+	prefix          string          // Redis key prefix for session id.
+	updatingIdMap   *gmap.StrIntMap // Updating TTL set for session id.
+	updatingUserMap *gmap.StrIntMap // Updating TTL set for user index.
 }
 
 var (
@@ -36,8 +37,9 @@ func NewStorageRedis(redis *gredis.Redis, prefix ...string) *StorageRedis {
 		return nil
 	}
 	s := &StorageRedis{
-		redis:         redis,
-		updatingIdMap: gmap.NewStrIntMap(true),
+		redis:           redis,
+		updatingIdMap:   gmap.NewStrIntMap(true),
+		updatingUserMap: gmap.NewStrIntMap(true),
 	}
 	if len(prefix) > 0 && prefix[0] != "" {
 		s.prefix = prefix[0]
@@ -59,6 +61,15 @@ func NewStorageRedis(redis *gredis.Redis, prefix ...string) *StorageRedis {
 				}
 			}
 		}
+		for {
+			if uid, ttlSeconds := s.updatingUserMap.Pop(); uid == "" {
+				break
+			} else {
+				if err = s.doUpdateUserTTL(uid, ttlSeconds); err != nil {
+					intlog.Error(err)
+				}
+			}
+		}
 		intlog.Print("StorageRedis.timer end")
 	})
 	return s
@@ -171,6 +182,83 @@ func (s *StorageRedis) doUpdateTTL(id string, ttlSeconds int) error {
 	return err
 }
 
+// Destroy deletes the session data of given session id from redis.
+func (s *StorageRedis) Destroy(id string) error {
+	s.updatingIdMap.Remove(id)
+	_, err := s.redis.Do("DEL", s.key(id))
+	return err
+}
+
+// AddUserSession adds session id <id> to the index set of user <uid>,
+// and extends the TTL of the index set to <ttl>.
+func (s *StorageRedis) AddUserSession(uid string, id string, ttl time.Duration) error {
+	conn := s.redis.Conn()
+	defer conn.Close()
+	if err := conn.Send("MULTI"); err != nil {
+		return err
+	}
+	if err := conn.Send("SADD", s.userKey(uid), id); err != nil {
+		conn.Do("DISCARD")
+		return err
+	}
+	if err := conn.Send("EXPIRE", s.userKey(uid), s.userTTLSeconds(ttl)); err != nil {
+		conn.Do("DISCARD")
+		return err
+	}
+	_, err := conn.Do("EXEC")
+	return err
+}
+
+// UpdateUserTTL updates the TTL for the index set of user <uid>.
+// It adds the user id to the async handling queue like UpdateTTL.
+func (s *StorageRedis) UpdateUserTTL(uid string, ttl time.Duration) error {
+	if ttl >= DefaultStorageRedisLoopInterval {
+		s.updatingUserMap.Set(uid, s.userTTLSeconds(ttl))
+		return nil
+	}
+	return s.doUpdateUserTTL(uid, s.userTTLSeconds(ttl))
+}
+
+// doUpdateUserTTL updates the TTL for the index set of user <uid>.
+func (s *StorageRedis) doUpdateUserTTL(uid string, ttlSeconds int) error {
+	_, err := s.redis.Do("EXPIRE", s.userKey(uid), ttlSeconds)
+	return err
+}
+
+// userTTLSeconds returns the TTL in seconds of the user index set for session <ttl>,
+// which is longer than <ttl> covering the delay of the async TTL updating.
+func (s *StorageRedis) userTTLSeconds(ttl time.Duration) int {
+	return int((ttl + DefaultStorageRedisLoopInterval).Seconds()) + 1
+}
+
+// RemoveUserSession deletes session ids <ids> from the index set of user <uid>.
+func (s *StorageRedis) RemoveUserSession(uid string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, s.userKey(uid))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	_, err := s.redis.Do("SREM", args...)
+	return err
+}
+
+// GetUserSessions returns all session ids in the index set of user <uid>.
+func (s *StorageRedis) GetUserSessions(uid string) ([]string, error) {
+	r, err := s.redis.DoVar("SMEMBERS", s.userKey(uid))
+	if err != nil {
+		return nil, err
+	}
+	return r.Strings(), nil
+}
+
+// userKey returns the redis key of the index set for user <uid>.
+func (s *StorageRedis) userKey(uid string) string {
+	return s.prefix + "users:" + uid
+}
+
 func (s *StorageRedis) key(id string) string {
 	return s.prefix + id
 }
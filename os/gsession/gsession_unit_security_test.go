@@ -0,0 +1,150 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/os/gsession"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_Session_Regenerate(t *testing.T) {
+	for _, storage := range []gsession.Storage{
+		gsession.NewStorageMemory(),
+		gsession.NewStorageFile(),
+	} {
+		manager := gsession.New(time.Minute, storage)
+		gtest.C(t, func(t *gtest.T) {
+			s := manager.New()
+			s.Set("k1", "v1")
+			s.Close()
+			oldId := s.Id()
+
+			s = manager.New(oldId)
+			t.Assert(s.Get("k1"), "v1")
+			t.Assert(s.Regenerate(), nil)
+			t.AssertNE(s.Id(), oldId)
+			t.Assert(s.Get("k1"), "v1")
+			s.Set("k2", "v2")
+			s.Close()
+
+			t.Assert(manager.New(oldId).Size(), 0)
+			s = manager.New(s.Id())
+			t.Assert(s.Size(), 2)
+			t.Assert(s.Get("k1"), "v1")
+			t.Assert(s.Get("k2"), "v2")
+		})
+	}
+}
+
+func Test_Session_MaxLifetime(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Minute, gsession.NewStorageMemory())
+		manager.SetMaxLifetime(time.Second)
+		t.Assert(manager.MaxLifetime(), time.Second)
+
+		s := manager.New()
+		s.Set("k", "v")
+		s.Close()
+		id := s.Id()
+		t.Assert(len(s.Map()), 1)
+		t.Assert(s.Size(), 1)
+
+		// It expires although it is accessed frequently.
+		for i := 0; i < 3; i++ {
+			time.Sleep(400 * time.Millisecond)
+			s = manager.New(id)
+			s.Close()
+		}
+		s = manager.New(id)
+		t.Assert(s.Get("k"), nil)
+		t.AssertNE(s.Id(), id)
+	})
+}
+
+func Test_Manager_RevokeUser(t *testing.T) {
+	for _, storage := range []gsession.Storage{
+		gsession.NewStorageMemory(),
+		gsession.NewStorageFile(),
+	} {
+		manager := gsession.New(time.Minute, storage)
+		gtest.C(t, func(t *gtest.T) {
+			ids := make([]string, 0)
+			for i := 0; i < 3; i++ {
+				s := manager.New()
+				t.Assert(s.Regenerate(), nil)
+				t.Assert(s.SetUser("john"), nil)
+				s.Set("k", "v")
+				t.Assert(s.User(), "john")
+				t.Assert(s.Size(), 1)
+				// Reserved keys are invisible.
+				t.Assert(s.Get("_gf_session_uid"), nil)
+				t.Assert(s.Contains("_gf_session_uid"), false)
+				t.Assert(len(s.Map()), 1)
+				s.Close()
+				ids = append(ids, s.Id())
+			}
+			other := manager.New()
+			other.SetUser("smith")
+			other.Close()
+
+			userIds, err := manager.UserSessionIds("john")
+			t.Assert(err, nil)
+			t.AssertIN(ids, userIds)
+			t.Assert(len(userIds), 3)
+
+			// Regenerating updates the index.
+			s := manager.New(ids[0])
+			t.Assert(s.Regenerate(), nil)
+			s.Close()
+			ids[0] = s.Id()
+			userIds, _ = manager.UserSessionIds("john")
+			t.AssertIN(ids, userIds)
+			t.Assert(len(userIds), 3)
+
+			t.Assert(manager.RevokeUser("john"), nil)
+			for _, id := range ids {
+				t.Assert(manager.New(id).Get("k"), nil)
+			}
+			userIds, _ = manager.UserSessionIds("john")
+			t.Assert(len(userIds), 0)
+			t.Assert(manager.New(other.Id()).User(), "smith")
+		})
+	}
+	gtest.C(t, func(t *gtest.T) {
+		manager := gsession.New(time.Minute, gsession.NewStorageCookie(gsession.StorageCookieOptions{
+			HashKeys: [][]byte{[]byte("key")},
+		}))
+		t.Assert(manager.RevokeUser("john"), gsession.ErrorDisabled)
+	})
+}
+
+func Test_Session_MaxLifetime_UserIndex(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			storage = gsession.NewStorageMemory()
+			manager = gsession.New(time.Minute, storage)
+		)
+		manager.SetMaxLifetime(500 * time.Millisecond)
+
+		s := manager.New()
+		t.Assert(s.SetUser("john"), nil)
+		s.Close()
+		id := s.Id()
+		ids, _ := storage.GetUserSessions("john")
+		t.Assert(ids, []string{id})
+
+		// Destroying the expired session removes it from the user index.
+		time.Sleep(600 * time.Millisecond)
+		s = manager.New(id)
+		t.AssertNE(s.Id(), id)
+		ids, _ = storage.GetUserSessions("john")
+		t.Assert(len(ids), 0)
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/errors/gerror"
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/os/gsession"
+	"github.com/gogf/gf/test/gtest"
+)
+
+func Test_StorageCookie(t *testing.T) {
+	var (
+		storage = gsession.NewStorageCookie(gsession.StorageCookieOptions{
+			HashKeys: [][]byte{[]byte("hash-key")},
+		})
+		manager   = gsession.New(time.Second, storage)
+		sessionId = ""
+	)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New()
+		defer s.Close()
+		s.Set("k1", "v1")
+		s.SetMap(g.Map{
+			"k2": "v2",
+			"k3": 3,
+		})
+		t.Assert(s.IsDirty(), true)
+		t.Assert(s.Encode(), nil)
+		sessionId = s.Id()
+		t.Assert(strings.Count(sessionId, "."), 1)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(sessionId)
+		t.Assert(s.Get("k1"), "v1")
+		t.Assert(s.Get("k2"), "v2")
+		t.Assert(s.Get("k3"), 3)
+		t.Assert(s.Size(), 3)
+		t.Assert(s.Encode(), nil)
+		t.Assert(s.Id(), sessionId)
+
+		s.Remove("k1")
+		t.Assert(s.Encode(), nil)
+		t.AssertNE(s.Id(), sessionId)
+		t.Assert(manager.New(s.Id()).Contains("k1"), false)
+		t.Assert(manager.New(s.Id()).Get("k2"), "v2")
+	})
+
+	// Tampered session id.
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(manager.New(sessionId[1:]).Size(), 0)
+		t.Assert(manager.New(sessionId+"a").Size(), 0)
+		t.Assert(manager.New("invalid").Size(), 0)
+	})
+
+	// Expired session id.
+	time.Sleep(1500 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(manager.New(sessionId).Size(), 0)
+	})
+}
+
+func Test_StorageCookie_Refresh(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			storage = gsession.NewStorageCookie(gsession.StorageCookieOptions{
+				HashKeys: [][]byte{[]byte("hash-key")},
+			})
+			manager = gsession.New(time.Second, storage)
+			s       = manager.New()
+		)
+		s.Set("k", "v")
+		t.Assert(s.Encode(), nil)
+		sessionId := s.Id()
+
+		// It is not re-encoded within half of the TTL.
+		s = manager.New(sessionId)
+		t.Assert(s.Get("k"), "v")
+		t.Assert(s.IsDirty(), false)
+
+		// It is re-encoded with fresh timestamp after half of the TTL if accessed.
+		time.Sleep(600 * time.Millisecond)
+		s = manager.New(sessionId)
+		t.Assert(s.Get("k"), "v")
+		t.Assert(s.IsDirty(), true)
+		t.Assert(s.Encode(), nil)
+		refreshedId := s.Id()
+		t.AssertNE(refreshedId, sessionId)
+
+		time.Sleep(600 * time.Millisecond)
+		t.Assert(manager.New(sessionId).Get("k"), nil)
+		t.Assert(manager.New(refreshedId).Get("k"), "v")
+	})
+}
+
+func Test_StorageCookie_Crypto(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			oldKey  = []byte("1234567891234567")
+			newKey  = []byte("12345678912345678912345678912345")
+			storage = gsession.NewStorageCookie(gsession.StorageCookieOptions{
+				HashKeys:   [][]byte{[]byte("hash-key")},
+				CryptoKeys: [][]byte{oldKey},
+			})
+			manager = gsession.New(time.Minute, storage)
+			s       = manager.New()
+		)
+		s.Set("secret", "value")
+		t.Assert(s.Encode(), nil)
+		t.Assert(strings.Contains(s.Id(), "value"), false)
+		t.Assert(manager.New(s.Id()).Get("secret"), "value")
+
+		// Key rotation, in which the old keys are accepted for decoding.
+		rotated := gsession.New(time.Minute, gsession.NewStorageCookie(gsession.StorageCookieOptions{
+			HashKeys:   [][]byte{[]byte("new-hash-key"), []byte("hash-key")},
+			CryptoKeys: [][]byte{newKey, oldKey},
+		}))
+		r := rotated.New(s.Id())
+		t.Assert(r.Get("secret"), "value")
+		r.Set("secret", "new")
+		t.Assert(r.Encode(), nil)
+		t.Assert(rotated.New(r.Id()).Get("secret"), "new")
+		t.Assert(manager.New(r.Id()).Size(), 0)
+	})
+}
+
+func Test_StorageCookie_MaxSize(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			storage = gsession.NewStorageCookie(gsession.StorageCookieOptions{
+				HashKeys: [][]byte{[]byte("hash-key")},
+				MaxSize:  100,
+			})
+			manager = gsession.New(time.Minute, storage)
+			s       = manager.New()
+		)
+		s.Set("k", strings.Repeat("v", 100))
+		err := s.Encode()
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Cause(err), gsession.ErrorSizeExceeded)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		gsession.NewStorageCookie(gsession.StorageCookieOptions{})
+	})
+}
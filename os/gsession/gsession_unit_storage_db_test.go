@@ -0,0 +1,154 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsession_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/container/gmap"
+	"github.com/gogf/gf/database/gdb"
+	"github.com/gogf/gf/frame/g"
+	"github.com/gogf/gf/os/gfile"
+	"github.com/gogf/gf/os/gsession"
+	"github.com/gogf/gf/test/gtest"
+)
+
+// storageDbTestNodes are the database configurations of all dialects for testing.
+// The tests of a dialect are skipped if its database is unreachable.
+var storageDbTestNodes = map[string]gdb.ConfigNode{
+	"mysql": {
+		Host: "127.0.0.1",
+		Port: "3306",
+		User: "root",
+		Pass: "12345678",
+		Type: "mysql",
+		Role: "master",
+	},
+	"pgsql": {
+		Host: "127.0.0.1",
+		Port: "5432",
+		User: "postgres",
+		Pass: "12345678",
+		Name: "test",
+		Type: "pgsql",
+		Role: "master",
+	},
+	"sqlite": {
+		Name: gfile.TempDir("gsession_storage_db_test.sqlite"),
+		Type: "sqlite",
+		Role: "master",
+	},
+}
+
+// runStorageDbTest runs <f> with database of each dialect and table <table>,
+// which is dropped before and after running.
+func runStorageDbTest(t *testing.T, table string, f func(t *testing.T, db gdb.DB)) {
+	for dialect, node := range storageDbTestNodes {
+		t.Run(dialect, func(t *testing.T) {
+			group := "session_" + dialect
+			gdb.AddConfigNode(group, node)
+			db, err := gdb.New(group)
+			if err == nil {
+				err = db.PingMaster()
+			}
+			if err != nil {
+				t.Skipf("%s is unreachable: %v", dialect, err)
+			}
+			if dialect == "mysql" {
+				if _, err = db.Exec("CREATE DATABASE IF NOT EXISTS `test` CHARACTER SET UTF8"); err != nil {
+					t.Fatal(err)
+				}
+				db.SetSchema("test")
+			}
+			charLeft, charRight := db.GetChars()
+			dropSql := "DROP TABLE IF EXISTS " + charLeft + table + charRight
+			_, _ = db.Exec(dropSql)
+			defer db.Exec(dropSql)
+			f(t, db)
+		})
+	}
+}
+
+func Test_StorageDb(t *testing.T) {
+	runStorageDbTest(t, "gf_session_test", testStorageDb)
+}
+
+func testStorageDb(t *testing.T, db gdb.DB) {
+	table := "gf_session_test"
+	storage := gsession.NewStorageDb(db, table)
+	manager := gsession.New(time.Second, storage)
+	sessionId := ""
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New()
+		defer s.Close()
+		s.Set("k1", "v1")
+		s.Set("k2", "v2")
+		s.Sets(g.Map{
+			"k3": "v3",
+			"k4": "v4",
+		})
+		t.Assert(s.IsDirty(), true)
+		sessionId = s.Id()
+	})
+
+	time.Sleep(500 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(sessionId)
+		t.Assert(s.Get("k1"), "v1")
+		t.Assert(s.Get("k2"), "v2")
+		t.Assert(s.Get("k3"), "v3")
+		t.Assert(s.Get("k4"), "v4")
+		t.Assert(len(s.Map()), 4)
+		t.Assert(s.Id(), sessionId)
+		t.Assert(s.Size(), 4)
+		s.Remove("k4")
+		t.Assert(s.Size(), 3)
+		t.Assert(s.Contains("k4"), false)
+		s.RemoveAll()
+		t.Assert(s.Size(), 0)
+		s.Sets(g.Map{
+			"k5": "v5",
+			"k6": "v6",
+		})
+		t.Assert(s.Size(), 2)
+	})
+
+	time.Sleep(1000 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		s := manager.New(sessionId)
+		t.Assert(s.Size(), 0)
+		t.Assert(s.Get("k5"), nil)
+		t.Assert(s.Get("k6"), nil)
+	})
+}
+
+func Test_StorageDb_UpdateTTL(t *testing.T) {
+	runStorageDbTest(t, "gf_session_test_ttl", testStorageDbUpdateTTL)
+}
+
+func testStorageDbUpdateTTL(t *testing.T, db gdb.DB) {
+	table := "gf_session_test_ttl"
+	storage := gsession.NewStorageDb(db, table)
+	gtest.C(t, func(t *gtest.T) {
+		data := g.Map{"k1": "v1"}
+		t.Assert(storage.SetSession("id1", gmap.NewStrAnyMapFrom(data, true), time.Second), nil)
+
+		// Touching TTL does not rewrite the data.
+		t.Assert(storage.UpdateTTL("id1", 3*time.Second), nil)
+		time.Sleep(1500 * time.Millisecond)
+		m, err := storage.GetSession("id1", 3*time.Second, nil)
+		t.Assert(err, nil)
+		t.Assert(m.Get("k1"), "v1")
+
+		// Destroying deletes the row.
+		t.Assert(storage.Destroy("id1"), nil)
+		m, err = storage.GetSession("id1", 3*time.Second, nil)
+		t.Assert(err, nil)
+		t.Assert(m == nil, true)
+	})
+}
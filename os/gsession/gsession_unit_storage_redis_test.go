@@ -138,3 +138,28 @@ func Test_StorageRedisPrefix(t *testing.T) {
 		t.Assert(s.Get("k6"), nil)
 	})
 }
+
+func Test_StorageRedis_UserIndex(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		redis, err := gredis.NewFromStr("127.0.0.1:6379,0")
+		t.Assert(err, nil)
+		var (
+			prefix  = "gsession:test:"
+			storage = gsession.NewStorageRedis(redis, prefix)
+			manager = gsession.New(time.Minute, storage)
+			s       = manager.New()
+		)
+		t.Assert(s.SetUser("john"), nil)
+		s.Close()
+		defer manager.RevokeUser("john")
+
+		ttl, err := redis.DoVar("TTL", prefix+"users:john")
+		t.Assert(err, nil)
+		t.AssertGE(ttl.Int(), 60)
+
+		t.Assert(s.Regenerate(), nil)
+		ids, err := storage.GetUserSessions("john")
+		t.Assert(err, nil)
+		t.Assert(ids, []string{s.Id()})
+	})
+}
@@ -0,0 +1,24 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gvalid
+
+import (
+	"context"
+)
+
+// CheckCommandOptions validates the option struct <pointer> of gcmd.Command with struct tag "v".
+// It is the validator for option structs of commands, which should be set explicitly as package
+// gcmd cannot import this package due to import cycle, eg:
+//
+// gcmd.Command{Name: "app", Options: &options, Validator: gvalid.CheckCommandOptions}
+// gcmd.SetOptionValidator(gvalid.CheckCommandOptions)
+func CheckCommandOptions(ctx context.Context, pointer interface{}) error {
+	if err := CheckStruct(ctx, pointer, nil); err != nil {
+		return err
+	}
+	return nil
+}